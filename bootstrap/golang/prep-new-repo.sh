@@ -4,6 +4,11 @@ set -euo pipefail
 #                                    Constants
 # =============================================================================
 TESTSUITE_IMPL_DIRNAME="testsuite"
+# The testsuite imports the packages in here (e.g. the forked executor), so they're copied along with it
+LIB_DIRNAME="lib"
+# The fault proxy's server & Dockerfile, for building the image that the optional faultProxyServiceImage param names; the
+#  suite skips the tests that need it until then
+FAULT_PROXY_SERVER_DIRNAME="fault_proxy_server"
 
 # Constants 
 GO_MOD_FILENAME="go.mod"
@@ -44,6 +49,8 @@ cp "${input_dirpath}/.dockerignore" "${output_dirpath}/"
 cp "${input_dirpath}/${GO_MOD_FILENAME}" "${output_dirpath}/"
 cp "${input_dirpath}/go.sum" "${output_dirpath}/"
 cp -r "${input_dirpath}/${TESTSUITE_IMPL_DIRNAME}" "${output_dirpath}/"
+cp -r "${input_dirpath}/${LIB_DIRNAME}" "${output_dirpath}/"
+cp -r "${input_dirpath}/${FAULT_PROXY_SERVER_DIRNAME}" "${output_dirpath}/"
 
 
 # =============================================================================
@@ -73,8 +80,11 @@ if ! sed -i"${SED_INPLACE_FILE_SUFFIX}" "s,${existing_module_name},${new_module_
     echo "Error: Could not replace Go module name in mod file '${go_mod_filepath}'" >&2
     exit 1
 fi
-# We search for old_module_name/testsuite because we don't want the old_module_name/lib entries to get renamed
-if ! sed -i"${SED_INPLACE_FILE_SUFFIX}" "s,${existing_module_name}/${TESTSUITE_IMPL_DIRNAME},${new_module_name}/${TESTSUITE_IMPL_DIRNAME},g" $(find "${output_dirpath}" -type f); then
+# We search for old_module_name/testsuite & old_module_name/lib because those are the only packages that get copied
+if ! sed -i"${SED_INPLACE_FILE_SUFFIX}" \
+        -e "s,${existing_module_name}/${TESTSUITE_IMPL_DIRNAME},${new_module_name}/${TESTSUITE_IMPL_DIRNAME},g" \
+        -e "s,${existing_module_name}/${LIB_DIRNAME}/,${new_module_name}/${LIB_DIRNAME}/,g" \
+        $(find "${output_dirpath}" -type f); then
     echo "Error: Could not replace Go module name in code files" >&2
     exit 1
 fi
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fault_proxy"
+	"github.com/sirupsen/logrus"
+	"net/http"
+	"os"
+)
+
+const (
+	failureExitCode = 1
+)
+
+// Runs a fault proxy as a service, so it can be inserted between two services in a test network
+func main() {
+	listenPortArg := flag.Int("listen-port", 0, "Port to accept proxied connections on")
+	controlPortArg := flag.Int("control-port", 0, "Port to serve the HTTP control API on")
+	targetArg := flag.String("target", "", "Address ('host:port') to forward connections to")
+	flag.Parse()
+
+	if *listenPortArg == 0 || *controlPortArg == 0 || *targetArg == "" {
+		logrus.Errorf("The listen port, control port, and target must all be provided")
+		flag.Usage()
+		os.Exit(failureExitCode)
+	}
+
+	proxy := fault_proxy.NewFaultProxy(*targetArg)
+	if err := proxy.Start(fmt.Sprintf(":%v", *listenPortArg)); err != nil {
+		logrus.Errorf("An error occurred starting the proxy:")
+		fmt.Fprintln(logrus.StandardLogger().Out, err)
+		os.Exit(failureExitCode)
+	}
+
+	controlAddr := fmt.Sprintf(":%v", *controlPortArg)
+	logrus.Infof("Serving the control API on '%v'", controlAddr)
+	if err := http.ListenAndServe(controlAddr, fault_proxy.NewControlHandler(proxy)); err != nil {
+		logrus.Errorf("An error occurred serving the control API:")
+		fmt.Fprintln(logrus.StandardLogger().Out, err)
+		os.Exit(failureExitCode)
+	}
+}
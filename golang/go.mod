@@ -6,6 +6,11 @@ require (
 	github.com/kurtosis-tech/example-microservice v0.0.0-20210708190344-942aa7289105
 	github.com/kurtosis-tech/kurtosis-client/golang v0.0.0-20210719180545-e21b98013e6f
 	github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang v0.0.0-20210721161109-ac945419fc53
+	github.com/kurtosis-tech/minimal-grpc-server v0.0.0-20210504182615-82226e94877b
 	github.com/palantir/stacktrace v0.0.0-20161112013806-78658fd2d177
 	github.com/sirupsen/logrus v1.8.1
+	github.com/stretchr/testify v1.7.0
+	google.golang.org/grpc v1.39.0
+	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v2 v2.4.0
 )
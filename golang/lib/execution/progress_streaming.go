@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package execution
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// The part of the *WithProgress server streams that we use, so that both can share one implementation
+type progressEventSender interface {
+	Send(event *bindings.TestProgressEvent) error
+}
+
+/*
+Runs the phase, streaming the events published to the test's progress publisher (plus everything logged, which the
+ log hook routes to the publisher while the phase runs) until the phase finishes, then streams a PhaseFinishedEvent
+ with the phase's outcome
+
+If the caller goes away mid-stream, the phase still runs to completion so that the test is left in a consistent state.
+ The phase's error is returned, so that the stream ends with an error status when the phase fails.
+*/
+func streamPhaseProgress(
+	phase string,
+	publisher *test_progress.Publisher,
+	logHook *test_progress.LogHook,
+	stream progressEventSender,
+	phaseFunc func() error,
+) error {
+	publisher.SetPhase(phase)
+	defer publisher.SetPhase("")
+
+	subscription := publisher.Subscribe()
+	logHook.SetPublisher(publisher)
+
+	resultChan := make(chan error, 1)
+	go func() {
+		resultChan <- phaseFunc()
+	}()
+
+	// Only the first send error is kept, as every send after it will fail the same way
+	var sendErr error
+	sendEvent := func(event *bindings.TestProgressEvent) {
+		if sendErr != nil {
+			return
+		}
+		sendErr = stream.Send(event)
+	}
+
+	var phaseErr error
+	isPhaseFinished := false
+	for !isPhaseFinished {
+		select {
+		case event := <-subscription.GetEvents():
+			sendEvent(event)
+		case phaseErr = <-resultChan:
+			isPhaseFinished = true
+		}
+	}
+
+	logHook.ClearPublisher(publisher)
+	numDropped := publisher.Unsubscribe(subscription)
+	// Unsubscribing closes the channel, so this drains the events that were published before the phase finished
+	for event := range subscription.GetEvents() {
+		sendEvent(event)
+	}
+	if numDropped > 0 {
+		logrus.Warnf("%v progress events were dropped from the %v progress stream because the stream fell behind", numDropped, phase)
+	}
+
+	finishedEvent := test_progress.NewPhaseFinishedEvent(phaseErr)
+	finishedEvent.Phase = phase
+	sendEvent(finishedEvent)
+
+	if phaseErr != nil {
+		return phaseErr
+	}
+	if sendErr != nil {
+		return stacktrace.Propagate(sendErr, "The %v phase succeeded, but an error occurred streaming its progress", phase)
+	}
+	return nil
+}
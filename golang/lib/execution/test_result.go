@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package execution
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"net"
+	"time"
+)
+
+func newPhaseTiming(phase string, startTime time.Time, duration time.Duration) *bindings.PhaseTiming {
+	return &bindings.PhaseTiming{
+		Phase:     phase,
+		StartTime: timestamppb.New(startTime),
+		Duration:  durationpb.New(duration),
+	}
+}
+
+/*
+Builds the result of a finished test from the phases that ran, classifying the failure (if any) by the phase it
+ happened in and its error chain
+
+The failedPhase is ignored if phaseErr is nil.
+*/
+func newTestResult(
+	testName string,
+	phaseTimings []*bindings.PhaseTiming,
+	failedPhase string,
+	phaseErr error,
+	isTimedOut bool,
+	isCancelled bool,
+) *bindings.TestResult {
+	result := &bindings.TestResult{
+		TestName:        testName,
+		Passed:          phaseErr == nil,
+		FailureCategory: bindings.TestResult_NONE,
+		ArtifactPaths:   map[string]string{},
+		PhaseTimings:    phaseTimings,
+		StepTimings:     []*bindings.StepTiming{},
+	}
+	if phaseErr == nil {
+		return result
+	}
+	result.FailedPhase = failedPhase
+	result.FailureCategory = getFailureCategory(failedPhase, phaseErr, isTimedOut, isCancelled)
+	result.ErrorMessage = fmt.Sprintf("%#s", phaseErr)
+	result.ErrorStack = fmt.Sprintf("%+s", phaseErr)
+	return result
+}
+
+// A phase counts as timed out if the caller gave up on it, or if it took longer than the test configured it to take
+func hasTimedOut(ctx context.Context, duration time.Duration, timeout time.Duration) bool {
+	return ctx.Err() == context.DeadlineExceeded || duration > timeout
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Assertion failures aren't classified here, as only the suite knows how to recognize them
+func getFailureCategory(failedPhase string, phaseErr error, isTimedOut bool, isCancelled bool) bindings.TestResult_FailureCategory {
+	if isCancelled {
+		return bindings.TestResult_CANCELLED
+	}
+	if isTimedOut {
+		return bindings.TestResult_TIMEOUT
+	}
+	if isInfrastructureError(phaseErr) {
+		return bindings.TestResult_INFRASTRUCTURE
+	}
+	if failedPhase == test_lifecycle.SetupPhase {
+		return bindings.TestResult_SETUP_ERROR
+	}
+	return bindings.TestResult_RUN_ERROR
+}
+
+/*
+An error is an infrastructure error if it was caused by a failed gRPC call (which is how Kurtosis, and some services,
+ are called) or by a network error (e.g. a service's HTTP endpoint that couldn't be connected to)
+*/
+func isInfrastructureError(err error) bool {
+	rootCause := stacktrace.RootCause(err)
+	if _, ok := status.FromError(rootCause); ok {
+		return true
+	}
+	_, ok := rootCause.(net.Error)
+	return ok
+}
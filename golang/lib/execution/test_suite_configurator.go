@@ -0,0 +1,23 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package execution
+
+import (
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+)
+
+/*
+Configures the testsuite from the environment the testsuite container is started with
+
+This has the same methods as the testsuite API lib's configurator, so any configurator works with both executors. It
+ can't just alias the lib's, as importing the lib's execution package also registers the lib's protobuf bindings,
+ which conflict with this repo's.
+*/
+type TestSuiteConfigurator interface {
+	SetLogLevel(logLevelStr string) error
+
+	ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error)
+}
@@ -0,0 +1,114 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+/*
+A fork of the testsuite API lib's executor & TestSuiteService (github.com/kurtosis-tech/kurtosis-testsuite-api-lib,
+ at the version pinned in go.mod), which serves the service defined in this repo's suite-api instead of the lib's
+
+The lib's service is generated from its own .proto, so its endpoints can't be added to from outside; this fork exists
+ only to serve the endpoints that the lib doesn't have yet. The suites & tests it serves are the lib's, but suites opt
+ into those endpoints through this repo's test_progress, test_lifecycle, and rpc_api packages; the README lists every
+ package that depends on them, and what to change when this fork is deleted.
+*/
+package execution
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/kurtosis_testsuite_docker_api"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/kurtosis_testsuite_rpc_api_consts"
+	"github.com/kurtosis-tech/minimal-grpc-server/server"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"os"
+	"time"
+)
+
+const (
+	grpcServerStopGracePeriod = 5 * time.Second
+)
+
+/*
+Serves the testsuite to Kurtosis Core, the same as the testsuite API lib's executor does, except that the served
+ TestSuiteService also has the endpoints defined in this repo's suite-api (e.g. streaming a test's progress)
+*/
+type TestSuiteExecutor struct {
+	configurator TestSuiteConfigurator
+}
+
+func NewTestSuiteExecutor(configurator TestSuiteConfigurator) *TestSuiteExecutor {
+	return &TestSuiteExecutor{configurator: configurator}
+}
+
+func (executor TestSuiteExecutor) Run() error {
+	// NOTE: This can be empty if the testsuite is in metadata-providing mode
+	kurtosisApiSocketStr := os.Getenv(kurtosis_testsuite_docker_api.KurtosisApiSocketEnvVar)
+
+	logLevelStr, found := os.LookupEnv(kurtosis_testsuite_docker_api.LogLevelEnvVar)
+	if !found {
+		return stacktrace.NewError("Expected an '%v' environment variable containing the log level string that the testsuite should log at, but none was found", kurtosis_testsuite_docker_api.LogLevelEnvVar)
+	}
+	if logLevelStr == "" {
+		return stacktrace.NewError("The '%v' loglevel environment variable was defined, but is emptystring", kurtosis_testsuite_docker_api.LogLevelEnvVar)
+	}
+
+	customSerializedParamsStr, found := os.LookupEnv(kurtosis_testsuite_docker_api.CustomParamsJsonEnvVar)
+	if !found {
+		return stacktrace.NewError("Expected an '%v' environment variable containing the serialized custom params that the testsuite will consume, but none was found", kurtosis_testsuite_docker_api.CustomParamsJsonEnvVar)
+	}
+	if customSerializedParamsStr == "" {
+		return stacktrace.NewError("The '%v' serialized custom params environment variable was defined, but is emptystring", kurtosis_testsuite_docker_api.CustomParamsJsonEnvVar)
+	}
+
+	if err := executor.configurator.SetLogLevel(logLevelStr); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the loglevel before running the testsuite")
+	}
+
+	suite, err := executor.configurator.ParseParamsAndCreateSuite(customSerializedParamsStr)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred parsing the serialized testsuite params and creating the testsuite")
+	}
+
+	var apiContainerService kurtosis_core_rpc_api_bindings.ApiContainerServiceClient = nil
+	if kurtosisApiSocketStr != "" {
+		// TODO SECURITY: Use HTTPS to ensure we're connecting to the real Kurtosis API servers
+		conn, err := grpc.Dial(kurtosisApiSocketStr, grpc.WithInsecure())
+		if err != nil {
+			return stacktrace.Propagate(
+				err,
+				"An error occurred creating a connection to the Kurtosis API server at '%v'",
+				kurtosisApiSocketStr,
+			)
+		}
+		defer conn.Close()
+
+		apiContainerService = kurtosis_core_rpc_api_bindings.NewApiContainerServiceClient(conn)
+	}
+
+	// Added once for the life of the process, rather than per test, so that the logger's hooks are never swapped out
+	logHook := test_progress.NewLogHook()
+	logrus.AddHook(logHook)
+
+	testsuiteService := NewTestSuiteService(suite, apiContainerService, logHook)
+	testsuiteServiceRegistrationFunc := func(grpcServer *grpc.Server) {
+		bindings.RegisterTestSuiteServiceServer(grpcServer, testsuiteService)
+	}
+
+	testsuiteServer := server.NewMinimalGRPCServer(
+		kurtosis_testsuite_rpc_api_consts.ListenPort,
+		kurtosis_testsuite_rpc_api_consts.ListenProtocol,
+		grpcServerStopGracePeriod,
+		[]func(desc *grpc.Server){
+			testsuiteServiceRegistrationFunc,
+		},
+	)
+	if err := testsuiteServer.Run(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred running the testsuite server")
+	}
+
+	return nil
+}
@@ -0,0 +1,564 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package execution
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/kurtosis_testsuite_docker_api"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"time"
+)
+
+const (
+	// How long teardown waits for a cancelled phase that's still running to exit, before refusing to tear the test down
+	phaseExitGracePeriod = 10 * time.Second
+)
+
+/*
+A suite that publishes its tests' progress (steps, services added, etc.), for the *WithProgress endpoints to stream
+
+Suites that don't implement this still get their log lines and each phase's outcome streamed.
+*/
+type ProgressPublishingTestSuite interface {
+	// Gets the publisher for the given test's progress, or nil if the suite has none for the test
+	GetTestProgressPublisher(testName string) *test_progress.Publisher
+}
+
+/*
+A suite that adds what only it knows about its tests to their results (e.g. the step a test failed at, or the assertion
+ it failed on)
+*/
+type TestResultDetailingTestSuite interface {
+	// Called once the test has finished with the result built from its phases, which the suite may modify, and the
+	//  error of the phase the test failed in (or nil if it passed)
+	AddTestResultDetails(testName string, phaseErr error, result *bindings.TestResult)
+}
+
+type testSetupInfo struct {
+	// Nil until the test's setup succeeds
+	network  networks.Network
+	testName string
+
+	// Cancelled when the test is cancelled or torn down
+	ctx context.Context
+
+	// As configured by the test
+	runTimeout time.Duration
+
+	// Closed once the goroutine running the test's latest phase has exited, which may be well after a cancelled phase
+	//  returned; nil until a phase has started
+	phaseDoneChan <-chan struct{}
+}
+
+type testCancellationInfo struct {
+	cancelFunc context.CancelFunc
+
+	// True only if the cancellation was requested via CancelTest, rather than the test being torn down
+	isCancelled bool
+}
+
+type TestSuiteService struct {
+	// This embedding is required by gRPC
+	bindings.UnimplementedTestSuiteServiceServer
+
+	suite testsuite.TestSuite
+
+	// This will only be non-empty after SetupTest is called, and until TeardownTest is called
+	testSetupInfo *testSetupInfo
+
+	// Mutex to guard the testSetupInfo object, so any accidental concurrent calls of SetupInfo don't generate race conditions
+	testSetupInfoMutex *sync.Mutex
+
+	// Will only be non-nil if an IP:port to a Kurtosis API container was provided
+	kurtosisApiClient kurtosis_core_rpc_api_bindings.ApiContainerServiceClient
+
+	// Mapping of test name -> the timings of the test's phases that have run so far
+	testPhaseTimings map[string][]*bindings.PhaseTiming
+
+	// Mapping of test name -> the test's result, once the test has finished
+	testResults map[string]*bindings.TestResult
+
+	// Guards the test phase timings & results separately from the test setup info, so that results can be gotten while
+	//  a test is running
+	testResultsMutex *sync.Mutex
+
+	// This will only be non-nil after SetupTest is called
+	testCancellationInfo *testCancellationInfo
+
+	// Guards the test cancellation info separately from the test setup info, so that a running test can be cancelled
+	testCancellationInfoMutex *sync.Mutex
+
+	// Already added to the logger the suite logs to; the *WithProgress endpoints point it at their test's publisher
+	logHook *test_progress.LogHook
+}
+
+func NewTestSuiteService(
+	suite testsuite.TestSuite,
+	kurtosisApiClient kurtosis_core_rpc_api_bindings.ApiContainerServiceClient,
+	logHook *test_progress.LogHook,
+) *TestSuiteService {
+	return &TestSuiteService{
+		suite:                     suite,
+		testSetupInfo:             nil,
+		testSetupInfoMutex:        &sync.Mutex{},
+		kurtosisApiClient:         kurtosisApiClient,
+		testPhaseTimings:          map[string][]*bindings.PhaseTiming{},
+		testResults:               map[string]*bindings.TestResult{},
+		testResultsMutex:          &sync.Mutex{},
+		testCancellationInfo:      nil,
+		testCancellationInfoMutex: &sync.Mutex{},
+		logHook:                   logHook,
+	}
+}
+
+func (service *TestSuiteService) IsAvailable(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (service *TestSuiteService) GetTestSuiteMetadata(_ context.Context, _ *emptypb.Empty) (*bindings.TestSuiteMetadata, error) {
+	allTestMetadata := map[string]*bindings.TestMetadata{}
+	for testName, test := range service.suite.GetTests() {
+		testConfigBuilder := testsuite.NewTestConfigurationBuilder()
+		test.Configure(testConfigBuilder)
+		testConfig := testConfigBuilder.Build()
+		usedArtifactUrls := map[string]bool{}
+		for _, artifactUrl := range testConfig.FilesArtifactUrls {
+			usedArtifactUrls[artifactUrl] = true
+		}
+		allTestMetadata[testName] = &bindings.TestMetadata{
+			IsPartitioningEnabled:     testConfig.IsPartitioningEnabled,
+			UsedArtifactUrls:          usedArtifactUrls,
+			TestSetupTimeoutInSeconds: testConfig.SetupTimeoutSeconds,
+			TestRunTimeoutInSeconds:   testConfig.RunTimeoutSeconds,
+		}
+	}
+
+	staticFilesStrKeys := map[string]bool{}
+	for key := range service.suite.GetStaticFiles() {
+		staticFilesStrKeys[string(key)] = true
+	}
+	testSuiteMetadata := &bindings.TestSuiteMetadata{
+		TestMetadata:     allTestMetadata,
+		NetworkWidthBits: service.suite.GetNetworkWidthBits(),
+		StaticFiles:      staticFilesStrKeys,
+	}
+	return testSuiteMetadata, nil
+}
+
+func (service *TestSuiteService) CopyStaticFilesToExecutionVolume(_ context.Context, args *bindings.CopyStaticFilesToExecutionVolumeArgs) (*emptypb.Empty, error) {
+	staticFileDestRelativeFilepaths := args.StaticFileDestRelativeFilepaths
+
+	allStaticFiles := service.suite.GetStaticFiles()
+	staticFileSrcAbsFilepaths := map[services.StaticFileID]string{}
+	staticFileDestAbsFilepaths := map[services.StaticFileID]string{}
+	for staticFileIdStr, destRelativeFilepath := range staticFileDestRelativeFilepaths {
+		staticFileId := services.StaticFileID(staticFileIdStr)
+
+		// Sanity-check that the source filepath exists
+		srcAbsFilepath, found := allStaticFiles[staticFileId]
+		if !found {
+			return nil, stacktrace.NewError("The Kurtosis API gave a relative filepath for static file '%v', but the testsuite didn't declare a static file with this key!", staticFileId)
+		}
+		if _, err := os.Stat(srcAbsFilepath); os.IsNotExist(err) {
+			return nil, stacktrace.NewError("Source filepath '%v' associated with static file '%v' doesn't exist", srcAbsFilepath, staticFileId)
+		}
+		staticFileSrcAbsFilepaths[staticFileId] = srcAbsFilepath
+
+		// Sanity-check that a file has been created at the destination by Kurtosis
+		destAbsFilepath := path.Join(kurtosis_testsuite_docker_api.TestsuiteContainerSuiteExVolMountpoint, destRelativeFilepath)
+		if _, err := os.Stat(destAbsFilepath); os.IsNotExist(err) {
+			return nil, stacktrace.NewError("The Kurtosis API asked us to copy static file '%v' to path '%v' in the suite execution volume, but no file exists there - this is a bug in Kurtosis!", staticFileId, destRelativeFilepath)
+		}
+		staticFileDestAbsFilepaths[staticFileId] = destAbsFilepath
+	}
+
+	for staticFileId, srcAbsFilepath := range staticFileSrcAbsFilepaths {
+		destAbsFilepath := staticFileDestAbsFilepaths[staticFileId]
+		if err := copyFile(srcAbsFilepath, destAbsFilepath); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred copying static file '%v' from '%v' to '%v'", staticFileId, srcAbsFilepath, destAbsFilepath)
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (service *TestSuiteService) SetupTest(ctx context.Context, args *bindings.SetupTestArgs) (*emptypb.Empty, error) {
+	service.testSetupInfoMutex.Lock()
+	defer service.testSetupInfoMutex.Unlock()
+
+	if service.kurtosisApiClient == nil {
+		return nil, stacktrace.NewError("Received a request to setup the test, but the Kurtosis API container client is nil")
+	}
+
+	testName := args.TestName
+	test, found := service.suite.GetTests()[testName]
+	if !found {
+		return nil, stacktrace.NewError(
+			"Testsuite was directed to setup test '%v', but no test with that name exists "+
+				"in the testsuite; this is a Kurtosis code bug",
+			testName,
+		)
+	}
+
+	logrus.Infof("Setting up network for test '%v'...", testName)
+	testConfigBuilder := testsuite.NewTestConfigurationBuilder()
+	test.Configure(testConfigBuilder)
+	testConfig := testConfigBuilder.Build()
+
+	networkCtx := networks.NewNetworkContext(
+		service.kurtosisApiClient,
+		testConfig.FilesArtifactUrls,
+		kurtosis_testsuite_docker_api.TestsuiteContainerSuiteExVolMountpoint,
+	)
+
+	testCtx := service.startTestContext()
+	service.testSetupInfo = &testSetupInfo{
+		network:    nil,
+		testName:   testName,
+		ctx:        testCtx,
+		runTimeout: time.Duration(testConfig.RunTimeoutSeconds) * time.Second,
+	}
+
+	setupStartTime := time.Now()
+	var userNetwork networks.Network
+	phaseDoneChan, err := runUntilCancelled(testCtx, func() error {
+		var setupErr error
+		if cancellableTest, ok := test.(test_lifecycle.CancellableTest); ok {
+			userNetwork, setupErr = cancellableTest.SetupWithContext(testCtx, networkCtx)
+		} else {
+			userNetwork, setupErr = test.Setup(networkCtx)
+		}
+		return setupErr
+	})
+	service.testSetupInfo.phaseDoneChan = phaseDoneChan
+	setupDuration := time.Since(setupStartTime)
+	service.recordPhaseTiming(testName, test_lifecycle.SetupPhase, setupStartTime, setupDuration)
+	if err == nil && userNetwork == nil {
+		err = stacktrace.NewError("The test setup method returned successfully, but yielded a nil network object - this is a bug with the test's setup method accidentally returning a nil network object")
+	}
+	if err != nil {
+		setupTimeout := time.Duration(testConfig.SetupTimeoutSeconds) * time.Second
+		service.finishTest(testName, test_lifecycle.SetupPhase, err, hasTimedOut(ctx, setupDuration, setupTimeout))
+		return nil, stacktrace.Propagate(err, "An error occurred during test setup")
+	}
+	service.testSetupInfo.network = userNetwork
+	logrus.Infof("Successfully set up test network for test '%v'", testName)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (service *TestSuiteService) SetupTestWithProgress(args *bindings.SetupTestArgs, stream bindings.TestSuiteService_SetupTestWithProgressServer) error {
+	publisher := service.getTestProgressPublisher(args.TestName)
+	return streamPhaseProgress(test_lifecycle.SetupPhase, publisher, service.logHook, stream, func() error {
+		_, err := service.SetupTest(stream.Context(), args)
+		return err
+	})
+}
+
+func (service *TestSuiteService) RunTest(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	service.testSetupInfoMutex.Lock()
+	defer service.testSetupInfoMutex.Unlock()
+
+	if service.kurtosisApiClient == nil {
+		return nil, stacktrace.NewError("Received a request to run the test, but the Kurtosis API container client is nil")
+	}
+	if service.testSetupInfo == nil {
+		return nil, stacktrace.NewError("Received a request to run the test, but the test hasn't been set up yet")
+	}
+	if service.testSetupInfo.network == nil {
+		return nil, stacktrace.NewError("Received a request to run the test, but the test's setup didn't succeed")
+	}
+
+	network := service.testSetupInfo.network
+	testName := service.testSetupInfo.testName
+
+	test, found := service.suite.GetTests()[testName]
+	if !found {
+		return nil, stacktrace.NewError(
+			"Testsuite was directed to run test '%v', but no test with that name exists "+
+				"in the testsuite; this is a Kurtosis code bug",
+			testName,
+		)
+	}
+
+	logrus.Infof("Running test logic for test '%v'...", testName)
+	runStartTime := time.Now()
+	runCtx, cancelRunFunc := newRunContext(service.testSetupInfo.ctx, ctx, service.testSetupInfo.runTimeout)
+	defer cancelRunFunc()
+	phaseDoneChan, err := runUntilCancelled(runCtx, func() error {
+		return runTest(runCtx, test, network)
+	})
+	service.testSetupInfo.phaseDoneChan = phaseDoneChan
+	runDuration := time.Since(runStartTime)
+	service.recordPhaseTiming(testName, test_lifecycle.RunPhase, runStartTime, runDuration)
+	service.finishTest(testName, test_lifecycle.RunPhase, err, hasTimedOut(ctx, runDuration, service.testSetupInfo.runTimeout))
+	if err != nil {
+		return nil, stacktrace.Propagate(
+			err,
+			"An error occurred running test '%v'",
+			testName,
+		)
+	}
+	logrus.Infof("Ran test logic for test '%v'", testName)
+	return &emptypb.Empty{}, nil
+}
+
+func (service *TestSuiteService) RunTestWithProgress(_ *emptypb.Empty, stream bindings.TestSuiteService_RunTestWithProgressServer) error {
+	testName, err := service.getSetUpTestName()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the name of the test that was set up")
+	}
+	publisher := service.getTestProgressPublisher(testName)
+	return streamPhaseProgress(test_lifecycle.RunPhase, publisher, service.logHook, stream, func() error {
+		_, err := service.RunTest(stream.Context(), &emptypb.Empty{})
+		return err
+	})
+}
+
+func (service *TestSuiteService) GetTestResult(_ context.Context, args *bindings.GetTestResultArgs) (*bindings.TestResult, error) {
+	service.testResultsMutex.Lock()
+	defer service.testResultsMutex.Unlock()
+
+	result, found := service.testResults[args.TestName]
+	if !found {
+		return nil, stacktrace.NewError("No result exists for test '%v'; either the test hasn't finished yet, or it was never set up", args.TestName)
+	}
+	return result, nil
+}
+
+func (service *TestSuiteService) CancelTest(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	service.testCancellationInfoMutex.Lock()
+	defer service.testCancellationInfoMutex.Unlock()
+
+	if service.testCancellationInfo == nil {
+		return nil, stacktrace.NewError("Received a request to cancel the test, but no test has been set up yet")
+	}
+	logrus.Infof("Cancelling the test...")
+	service.testCancellationInfo.isCancelled = true
+	service.testCancellationInfo.cancelFunc()
+	return &emptypb.Empty{}, nil
+}
+
+func (service *TestSuiteService) TeardownTest(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	service.testSetupInfoMutex.Lock()
+	defer service.testSetupInfoMutex.Unlock()
+
+	if service.testSetupInfo == nil {
+		return nil, stacktrace.NewError("Received a request to tear down the test, but no test is set up (or it was already torn down)")
+	}
+
+	network := service.testSetupInfo.network
+	testName := service.testSetupInfo.testName
+
+	test, found := service.suite.GetTests()[testName]
+	if !found {
+		return nil, stacktrace.NewError(
+			"Testsuite was directed to tear down test '%v', but no test with that name exists "+
+				"in the testsuite; this is a Kurtosis code bug",
+			testName,
+		)
+	}
+
+	// A cancelled phase is abandoned rather than stopped, so it may still be using the network; cancelling the test's
+	//  context tells it to stop, and teardown waits for it to so that the two never race
+	service.stopTestContext()
+	if err := waitForPhaseExit(service.testSetupInfo.phaseDoneChan, phaseExitGracePeriod); err != nil {
+		return nil, stacktrace.Propagate(
+			err,
+			"Refusing to tear down test '%v' while its last phase is still running; teardown can be retried once the phase exits",
+			testName,
+		)
+	}
+
+	logrus.Infof("Tearing down test '%v'...", testName)
+	teardownErr := test_lifecycle.TeardownTestAndNetwork(test, network)
+
+	// Whether or not teardown succeeded, the test is finished with
+	service.testSetupInfo = nil
+
+	if teardownErr != nil {
+		return nil, stacktrace.Propagate(teardownErr, "An error occurred tearing down test '%v'", testName)
+	}
+	logrus.Infof("Tore down test '%v'", testName)
+	return &emptypb.Empty{}, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (service *TestSuiteService) getSetUpTestName() (string, error) {
+	service.testSetupInfoMutex.Lock()
+	defer service.testSetupInfoMutex.Unlock()
+	if service.testSetupInfo == nil {
+		return "", stacktrace.NewError("No test has been set up yet")
+	}
+	return service.testSetupInfo.testName, nil
+}
+
+// Creates the context for a newly-set-up test, cancelling the previous test's if it's still live
+func (service *TestSuiteService) startTestContext() context.Context {
+	service.testCancellationInfoMutex.Lock()
+	defer service.testCancellationInfoMutex.Unlock()
+
+	if service.testCancellationInfo != nil {
+		service.testCancellationInfo.cancelFunc()
+	}
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	service.testCancellationInfo = &testCancellationInfo{
+		cancelFunc:  cancelFunc,
+		isCancelled: false,
+	}
+	return ctx
+}
+
+// Cancels the test's context without counting the test as cancelled
+func (service *TestSuiteService) stopTestContext() {
+	service.testCancellationInfoMutex.Lock()
+	defer service.testCancellationInfoMutex.Unlock()
+	if service.testCancellationInfo != nil {
+		service.testCancellationInfo.cancelFunc()
+	}
+}
+
+func (service *TestSuiteService) isTestCancelled() bool {
+	service.testCancellationInfoMutex.Lock()
+	defer service.testCancellationInfoMutex.Unlock()
+	return service.testCancellationInfo != nil && service.testCancellationInfo.isCancelled
+}
+
+func (service *TestSuiteService) recordPhaseTiming(testName string, phase string, startTime time.Time, duration time.Duration) {
+	service.testResultsMutex.Lock()
+	defer service.testResultsMutex.Unlock()
+	service.testPhaseTimings[testName] = append(service.testPhaseTimings[testName], newPhaseTiming(phase, startTime, duration))
+}
+
+// Records the result of the finished test, with the suite's details added if the suite has any
+func (service *TestSuiteService) finishTest(testName string, lastPhase string, phaseErr error, isTimedOut bool) {
+	service.testResultsMutex.Lock()
+	defer service.testResultsMutex.Unlock()
+
+	result := newTestResult(testName, service.testPhaseTimings[testName], lastPhase, phaseErr, isTimedOut, service.isTestCancelled())
+	if detailingSuite, ok := service.suite.(TestResultDetailingTestSuite); ok {
+		detailingSuite.AddTestResultDetails(testName, phaseErr, result)
+	}
+	service.testResults[testName] = result
+	if !result.Passed {
+		logrus.Infof("Test '%v' failed during %v with failure category %v", testName, lastPhase, result.FailureCategory)
+	}
+}
+
+// Falls back to a publisher of our own, which only the streamed log lines will be published to
+func (service *TestSuiteService) getTestProgressPublisher(testName string) *test_progress.Publisher {
+	if progressPublishingSuite, ok := service.suite.(ProgressPublishingTestSuite); ok {
+		if publisher := progressPublishingSuite.GetTestProgressPublisher(testName); publisher != nil {
+			return publisher
+		}
+	}
+	return test_progress.NewPublisher()
+}
+
+// Little helper function that runs the test (with the context, if the test takes one) and captures panics on test
+//  failures, returning them as errors
+func runTest(ctx context.Context, test testsuite.Test, network networks.Network) (resultErr error) {
+	// See https://medium.com/@hussachai/error-handling-in-go-a-quick-opinionated-guide-9199dd7c7f76 for details
+	defer func() {
+		if recoverResult := recover(); recoverResult != nil {
+			logrus.Tracef("Caught panic while running test: %v", recoverResult)
+			resultErr = stacktrace.NewError("A panic occurred while running the test: %v", recoverResult)
+		}
+	}()
+	if err := test_lifecycle.RunTest(ctx, test, network); err != nil {
+		return stacktrace.Propagate(err, "The test returned an error")
+	}
+	logrus.Tracef("Test completed successfully")
+	return
+}
+
+/*
+Creates the context a test's run gets, which is cancelled when the test is, when the caller of the endpoint gives up on
+ the run, or once the run's timeout elapses, whichever is first
+
+Kurtosis Core never calls CancelTest, so the latter two are what stop a context-respecting run when running under Core.
+*/
+func newRunContext(testCtx context.Context, rpcCtx context.Context, runTimeout time.Duration) (context.Context, context.CancelFunc) {
+	runCtx, cancelFunc := context.WithTimeout(testCtx, runTimeout)
+	go func() {
+		select {
+		case <-rpcCtx.Done():
+			cancelFunc()
+		case <-runCtx.Done():
+		}
+	}()
+	return runCtx, cancelFunc
+}
+
+/*
+Runs the function until it finishes or the context is cancelled, whichever comes first, returning a channel that's
+ closed once the function has actually exited
+
+A function that's cancelled is abandoned rather than stopped, as Go has no way to kill a goroutine; it will keep running
+ until it finishes (hopefully sooner, if it respects the context) or the process exits.
+*/
+func runUntilCancelled(ctx context.Context, function func() error) (<-chan struct{}, error) {
+	resultChan := make(chan error, 1)
+	doneChan := make(chan struct{})
+	go func() {
+		defer close(doneChan)
+		resultChan <- function()
+	}()
+
+	select {
+	case err := <-resultChan:
+		return doneChan, err
+	case <-ctx.Done():
+		return doneChan, stacktrace.Propagate(ctx.Err(), "The test was stopped before it finished")
+	}
+}
+
+// Waits for the phase whose goroutine closes the channel to exit, which is immediate if no phase has run
+func waitForPhaseExit(phaseDoneChan <-chan struct{}, timeout time.Duration) error {
+	if phaseDoneChan == nil {
+		return nil
+	}
+	select {
+	case <-phaseDoneChan:
+		return nil
+	case <-time.After(timeout):
+		return stacktrace.NewError("The phase still hadn't exited %v after the test's context was cancelled", timeout)
+	}
+}
+
+func copyFile(srcFilepath string, destFilepath string) error {
+	srcFp, err := os.Open(srcFilepath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening source file '%v' for reading", srcFilepath)
+	}
+	defer srcFp.Close()
+
+	destFp, err := os.Create(destFilepath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening destination file '%v' for writing", destFilepath)
+	}
+	defer destFp.Close()
+
+	if _, err := io.Copy(destFp, srcFp); err != nil {
+		return stacktrace.Propagate(err, "An error occurred copying all the bytes from '%v' to '%v'", srcFilepath, destFilepath)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_lifecycle
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/palantir/stacktrace"
+)
+
+const (
+	// The phases a test goes through, which e.g. results, reports, and progress events say a test failed in
+	SetupPhase = "setup"
+	RunPhase   = "run"
+)
+
+/*
+A test whose run can stop early once the test is cancelled, times out, or is torn down
+
+Under Kurtosis Core, which never cancels or tears down tests, the context is cancelled once the run times out.
+*/
+type ContextRunnableTest interface {
+	// Called instead of Run, with a context that's cancelled when the test is
+	RunWithContext(ctx context.Context, network networks.Network) error
+}
+
+// A test that can be set up & run with a context, which is cancelled when the test is cancelled or torn down
+type CancellableTest interface {
+	ContextRunnableTest
+
+	// Called instead of Setup, so that the test's network can stop adding services once the test is cancelled
+	SetupWithContext(ctx context.Context, networkCtx *networks.NetworkContext) (networks.Network, error)
+}
+
+/*
+A test with cleanup logic (e.g. releasing resources outside the network) to run once the test is finished with, whether
+ it passed, failed, or was cancelled
+
+The network is nil if the test's setup failed. Teardown is run by the TeardownTest endpoint and by the local runner,
+ but Kurtosis Core doesn't call the endpoint yet, so a test run by Core is never torn down.
+*/
+type TeardownableTest interface {
+	Teardown(network networks.Network) error
+}
+
+// A custom Network with cleanup logic, which is run after its test's teardown
+type TeardownableNetwork interface {
+	Teardown() error
+}
+
+// Runs the test with the context if it takes one, which wrappers of tests should use to pass the context on to the
+//  wrapped test
+func RunTest(ctx context.Context, test interface{ Run(network networks.Network) error }, network networks.Network) error {
+	contextRunnableTest, ok := test.(ContextRunnableTest)
+	if !ok {
+		return test.Run(network)
+	}
+	return contextRunnableTest.RunWithContext(ctx, network)
+}
+
+// Runs the test's teardown if it has one, which wrappers of tests should use to pass teardown on to the wrapped test
+func TeardownTest(test interface{}, network networks.Network) error {
+	teardownableTest, ok := test.(TeardownableTest)
+	if !ok {
+		return nil
+	}
+	if err := teardownableTest.Teardown(network); err != nil {
+		return stacktrace.Propagate(err, "An error occurred tearing down the test")
+	}
+	return nil
+}
+
+// Runs the test's teardown, then its network's teardown, skipping whichever of them has none; the network is nil if
+//  the test's setup failed
+func TeardownTestAndNetwork(test interface{}, network networks.Network) error {
+	if err := TeardownTest(test, network); err != nil {
+		return stacktrace.Propagate(err, "An error occurred running the test's teardown")
+	}
+	if teardownableNetwork, ok := network.(TeardownableNetwork); ok {
+		if err := teardownableNetwork.Teardown(); err != nil {
+			return stacktrace.Propagate(err, "An error occurred tearing down the test's network")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_progress
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"time"
+)
+
+// Each of these creates an event timestamped with the current time; the Publisher fills in the phase
+
+func NewStepStartedEvent(stepPath string) *bindings.TestProgressEvent {
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.Now(),
+		Event: &bindings.TestProgressEvent_StepStarted{
+			StepStarted: &bindings.StepStartedEvent{
+				StepPath: stepPath,
+			},
+		},
+	}
+}
+
+func NewStepFinishedEvent(
+	stepPath string,
+	status bindings.StepFinishedEvent_StepStatus,
+	duration time.Duration,
+	errorMessage string,
+) *bindings.TestProgressEvent {
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.Now(),
+		Event: &bindings.TestProgressEvent_StepFinished{
+			StepFinished: &bindings.StepFinishedEvent{
+				StepPath:     stepPath,
+				Status:       status,
+				Duration:     durationpb.New(duration),
+				ErrorMessage: errorMessage,
+			},
+		},
+	}
+}
+
+func NewServiceAddedEvent(serviceId string, image string, ipAddr string) *bindings.TestProgressEvent {
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.Now(),
+		Event: &bindings.TestProgressEvent_ServiceAdded{
+			ServiceAdded: &bindings.ServiceAddedEvent{
+				ServiceId: serviceId,
+				Image:     image,
+				IpAddr:    ipAddr,
+			},
+		},
+	}
+}
+
+// Unlike the other events, this is timestamped with when the line was logged rather than the current time
+func NewLogLineEvent(logTime time.Time, level string, message string) *bindings.TestProgressEvent {
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.New(logTime),
+		Event: &bindings.TestProgressEvent_LogLine{
+			LogLine: &bindings.LogLineEvent{
+				Level:   level,
+				Message: message,
+			},
+		},
+	}
+}
+
+func NewAssertionFailedEvent(stepPath string, message string) *bindings.TestProgressEvent {
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.Now(),
+		Event: &bindings.TestProgressEvent_AssertionFailed{
+			AssertionFailed: &bindings.AssertionFailedEvent{
+				StepPath: stepPath,
+				Message:  message,
+			},
+		},
+	}
+}
+
+// The phase's error should be nil if the phase passed
+func NewPhaseFinishedEvent(phaseErr error) *bindings.TestProgressEvent {
+	errorMessage := ""
+	if phaseErr != nil {
+		errorMessage = fmt.Sprintf("%#s", phaseErr)
+	}
+	return &bindings.TestProgressEvent{
+		Timestamp: timestamppb.Now(),
+		Event: &bindings.TestProgressEvent_PhaseFinished{
+			PhaseFinished: &bindings.PhaseFinishedEvent{
+				Passed:       phaseErr == nil,
+				ErrorMessage: errorMessage,
+			},
+		},
+	}
+}
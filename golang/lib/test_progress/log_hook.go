@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_progress
+
+import (
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// The levels whose log lines are published, which are info and everything more severe
+var publishedLogLevels = logrus.AllLevels[:logrus.InfoLevel+1]
+
+/*
+A logrus hook that publishes log lines as LogLineEvents to whichever test's publisher is currently set, and drops
+ them while none is
+
+It's meant to be added to a logger once, with each test's publisher set only while that test's phase is streaming, so
+ that the logger's hooks never have to be swapped out (which would race with anything else adding hooks).
+*/
+type LogHook struct {
+	mutex *sync.RWMutex
+
+	// Nil while no test's progress is being streamed
+	publisher *Publisher
+}
+
+func NewLogHook() *LogHook {
+	return &LogHook{
+		mutex:     &sync.RWMutex{},
+		publisher: nil,
+	}
+}
+
+// Publishes log lines to the given publisher, until ClearPublisher is called with it or another publisher is set
+func (hook *LogHook) SetPublisher(publisher *Publisher) {
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+	hook.publisher = publisher
+}
+
+// Stops publishing log lines to the given publisher; does nothing if another publisher has been set since
+func (hook *LogHook) ClearPublisher(publisher *Publisher) {
+	hook.mutex.Lock()
+	defer hook.mutex.Unlock()
+	if hook.publisher == publisher {
+		hook.publisher = nil
+	}
+}
+
+func (hook *LogHook) Levels() []logrus.Level {
+	return publishedLogLevels
+}
+
+func (hook *LogHook) Fire(entry *logrus.Entry) error {
+	hook.mutex.RLock()
+	defer hook.mutex.RUnlock()
+	if hook.publisher == nil {
+		return nil
+	}
+	hook.publisher.Publish(NewLogLineEvent(entry.Time, entry.Level.String(), entry.Message))
+	return nil
+}
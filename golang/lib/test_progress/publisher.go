@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_progress
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"sync"
+)
+
+const (
+	// How far a subscriber can fall behind before events start getting dropped for it
+	subscriptionBufferSize = 1000
+)
+
+/*
+Fans a test's progress events out to everything currently subscribed to them (e.g. an open *WithProgress stream)
+
+Publishing never blocks, so that reporting progress can't slow down or deadlock the test: a subscriber that falls
+ subscriptionBufferSize events behind has further events dropped until it catches up. Publishing also never logs, as
+ log lines are themselves published from a logrus hook.
+*/
+type Publisher struct {
+	mutex *sync.Mutex
+
+	// The phase that published events are stamped with, or empty if no phase is being streamed
+	phase string
+
+	subscriptions map[*Subscription]bool
+}
+
+func NewPublisher() *Publisher {
+	return &Publisher{
+		mutex:         &sync.Mutex{},
+		phase:         "",
+		subscriptions: map[*Subscription]bool{},
+	}
+}
+
+// Sets the phase that subsequently-published events will be stamped with
+func (publisher *Publisher) SetPhase(phase string) {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	publisher.phase = phase
+}
+
+func (publisher *Publisher) Subscribe() *Subscription {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	subscription := &Subscription{
+		events:     make(chan *bindings.TestProgressEvent, subscriptionBufferSize),
+		numDropped: 0,
+	}
+	publisher.subscriptions[subscription] = true
+	return subscription
+}
+
+/*
+Stops publishing to the subscription and closes its events channel (once any events still buffered in it have been
+ read, the channel will report closed), returning the number of events that were dropped for the subscription
+*/
+func (publisher *Publisher) Unsubscribe(subscription *Subscription) int {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	if _, found := publisher.subscriptions[subscription]; !found {
+		return 0
+	}
+	delete(publisher.subscriptions, subscription)
+	close(subscription.events)
+	return subscription.numDropped
+}
+
+// Publishes the event to every subscription, stamped with the current phase; a no-op if there are no subscriptions
+func (publisher *Publisher) Publish(event *bindings.TestProgressEvent) {
+	publisher.mutex.Lock()
+	defer publisher.mutex.Unlock()
+	event.Phase = publisher.phase
+	for subscription := range publisher.subscriptions {
+		select {
+		case subscription.events <- event:
+		default:
+			subscription.numDropped++
+		}
+	}
+}
+
+// A subscriber's feed of events from a Publisher
+type Subscription struct {
+	events chan *bindings.TestProgressEvent
+
+	// Guarded by the publisher's mutex
+	numDropped int
+}
+
+func (subscription *Subscription) GetEvents() <-chan *bindings.TestProgressEvent {
+	return subscription.events
+}
@@ -12,9 +12,19 @@ fi
 action="${1:-}"
 shift 1
 
+# The fault proxy image is built locally alongside the testsuite image, for tests that inject faults between services
+fault_proxy_image="${KURTOSIS_DOCKERHUB_ORG}/kurtosis-golang-example-fault-proxy"
+if [ "${action}" == "build" ] || [ "${action}" == "all" ]; then
+    if ! docker build -t "${fault_proxy_image}" -f "${lang_root_dirpath}/fault_proxy_server/Dockerfile" "${lang_root_dirpath}"; then
+        echo "Error: Building the fault proxy image failed" >&2
+        exit 1
+    fi
+fi
+
 custom_params_json='{
     "apiServiceImage" :"'${KURTOSIS_DOCKERHUB_ORG}'/example-microservices_api",
-    "datastoreServiceImage": "'${KURTOSIS_DOCKERHUB_ORG}'/example-microservices_datastore"
+    "datastoreServiceImage": "'${KURTOSIS_DOCKERHUB_ORG}'/example-microservices_datastore",
+    "faultProxyServiceImage": "'${fault_proxy_image}'"
 }'
 
 bash "${repo_root_dirpath}/.kurtosis/build-and-run-core.sh" \
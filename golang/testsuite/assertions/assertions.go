@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+/*
+Each assertion returns nil if it holds, or an error describing the failure if it doesn't, so that it slots into the
+ Run(network) error contract:
+
+	if err := assertions.Equal(expectedNumBooksRead, person.BooksRead, "books read for person %v", personId); err != nil {
+		return stacktrace.Propagate(err, "The test person's books read is wrong")
+	}
+
+The description (with optional format args) says what's being checked, and the error begins with the location of the
+ assertion in the test. To check several things and report every failure at once, see SoftAssertions.
+*/
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Asserts that the values are deeply equal, describing where they differ if they're not
+func Equal(expected interface{}, actual interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if reflect.DeepEqual(expected, actual) {
+		return nil
+	}
+	return newDifferencesAssertionError(getDifferences(expected, actual), descriptionFmt, descriptionArgs...)
+}
+
+func NotEqual(unexpected interface{}, actual interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if !reflect.DeepEqual(unexpected, actual) {
+		return nil
+	}
+	return newAssertionError(fmt.Sprintf("expected anything but %v", formatValue(reflect.ValueOf(actual))), descriptionFmt, descriptionArgs...)
+}
+
+func True(condition bool, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if condition {
+		return nil
+	}
+	return newAssertionError("expected true, actual false", descriptionFmt, descriptionArgs...)
+}
+
+func False(condition bool, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if !condition {
+		return nil
+	}
+	return newAssertionError("expected false, actual true", descriptionFmt, descriptionArgs...)
+}
+
+func NoError(err error, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return newAssertionError(fmt.Sprintf("expected no error, actual error:\n%v", err), descriptionFmt, descriptionArgs...)
+}
+
+func Error(err error, descriptionFmt string, descriptionArgs ...interface{}) error {
+	if err != nil {
+		return nil
+	}
+	return newAssertionError("expected an error, actual no error", descriptionFmt, descriptionArgs...)
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+/*
+Asserts that the collection contains the element, where the collection may be a slice or array (which must contain an
+ element deeply equal to the given one), a map (which must have the element as a key), or a string (which must
+ contain the element as a substring)
+*/
+func Contains(collection interface{}, element interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	isContained, unsupportedReason := isContainedIn(collection, element)
+	if unsupportedReason != "" {
+		return newAssertionError(unsupportedReason, descriptionFmt, descriptionArgs...)
+	}
+	if isContained {
+		return nil
+	}
+	return newAssertionError(
+		fmt.Sprintf("expected %v to contain %v", formatValue(reflect.ValueOf(collection)), formatValue(reflect.ValueOf(element))),
+		descriptionFmt,
+		descriptionArgs...,
+	)
+}
+
+func NotContains(collection interface{}, element interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	isContained, unsupportedReason := isContainedIn(collection, element)
+	if unsupportedReason != "" {
+		return newAssertionError(unsupportedReason, descriptionFmt, descriptionArgs...)
+	}
+	if !isContained {
+		return nil
+	}
+	return newAssertionError(
+		fmt.Sprintf("expected %v not to contain %v", formatValue(reflect.ValueOf(collection)), formatValue(reflect.ValueOf(element))),
+		descriptionFmt,
+		descriptionArgs...,
+	)
+}
+
+// Asserts that the slice, array, map, string, or channel has the given length
+func Len(collection interface{}, expectedLen int, descriptionFmt string, descriptionArgs ...interface{}) error {
+	actualLen, unsupportedReason := getLen(collection)
+	if unsupportedReason != "" {
+		return newAssertionError(unsupportedReason, descriptionFmt, descriptionArgs...)
+	}
+	if actualLen == expectedLen {
+		return nil
+	}
+	return newAssertionError(
+		fmt.Sprintf("expected length %v, actual length %v: %v", expectedLen, actualLen, formatValue(reflect.ValueOf(collection))),
+		descriptionFmt,
+		descriptionArgs...,
+	)
+}
+
+func Empty(collection interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	actualLen, unsupportedReason := getLen(collection)
+	if unsupportedReason != "" {
+		return newAssertionError(unsupportedReason, descriptionFmt, descriptionArgs...)
+	}
+	if actualLen == 0 {
+		return nil
+	}
+	return newAssertionError(
+		fmt.Sprintf("expected empty, actual length %v: %v", actualLen, formatValue(reflect.ValueOf(collection))),
+		descriptionFmt,
+		descriptionArgs...,
+	)
+}
+
+func NotEmpty(collection interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	actualLen, unsupportedReason := getLen(collection)
+	if unsupportedReason != "" {
+		return newAssertionError(unsupportedReason, descriptionFmt, descriptionArgs...)
+	}
+	if actualLen > 0 {
+		return nil
+	}
+	return newAssertionError("expected non-empty, actual empty", descriptionFmt, descriptionArgs...)
+}
+
+/*
+Asserts that the two slices or arrays have the same elements in any order, counting duplicates, and describes which
+ elements are missing & which are unexpected if they don't
+*/
+func ElementsMatch(expected interface{}, actual interface{}, descriptionFmt string, descriptionArgs ...interface{}) error {
+	expectedValue := reflect.ValueOf(expected)
+	actualValue := reflect.ValueOf(actual)
+	if !isListKind(expectedValue.Kind()) || !isListKind(actualValue.Kind()) {
+		return newAssertionError(
+			fmt.Sprintf("expected two slices or arrays, but got a %v and a %v", expectedValue.Kind(), actualValue.Kind()),
+			descriptionFmt,
+			descriptionArgs...,
+		)
+	}
+
+	// Each actual element can only be matched once, so that duplicates are counted
+	isActualMatched := make([]bool, actualValue.Len())
+	missingElementStrs := []string{}
+	for i := 0; i < expectedValue.Len(); i++ {
+		expectedElement := expectedValue.Index(i).Interface()
+		isFound := false
+		for j := 0; j < actualValue.Len(); j++ {
+			if !isActualMatched[j] && reflect.DeepEqual(expectedElement, actualValue.Index(j).Interface()) {
+				isActualMatched[j] = true
+				isFound = true
+				break
+			}
+		}
+		if !isFound {
+			missingElementStrs = append(missingElementStrs, formatValue(expectedValue.Index(i)))
+		}
+	}
+	unexpectedElementStrs := []string{}
+	for j, isMatched := range isActualMatched {
+		if !isMatched {
+			unexpectedElementStrs = append(unexpectedElementStrs, formatValue(actualValue.Index(j)))
+		}
+	}
+	if len(missingElementStrs) == 0 && len(unexpectedElementStrs) == 0 {
+		return nil
+	}
+
+	detailLines := []string{}
+	if len(missingElementStrs) > 0 {
+		detailLines = append(detailLines, "missing: "+strings.Join(missingElementStrs, ", "))
+	}
+	if len(unexpectedElementStrs) > 0 {
+		detailLines = append(detailLines, "unexpected: "+strings.Join(unexpectedElementStrs, ", "))
+	}
+	return newAssertionError(strings.Join(detailLines, "\n"), descriptionFmt, descriptionArgs...)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Returns whether the element is in the collection, or a reason why that can't be checked
+func isContainedIn(collection interface{}, element interface{}) (bool, string) {
+	collectionValue := reflect.ValueOf(collection)
+	switch collectionValue.Kind() {
+	case reflect.String:
+		elementStr, ok := element.(string)
+		if !ok {
+			return false, fmt.Sprintf("can only check a string for a string element, but the element was %v", formatValue(reflect.ValueOf(element)))
+		}
+		return strings.Contains(collectionValue.String(), elementStr), ""
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < collectionValue.Len(); i++ {
+			if reflect.DeepEqual(collectionValue.Index(i).Interface(), element) {
+				return true, ""
+			}
+		}
+		return false, ""
+	case reflect.Map:
+		for _, key := range collectionValue.MapKeys() {
+			if reflect.DeepEqual(key.Interface(), element) {
+				return true, ""
+			}
+		}
+		return false, ""
+	default:
+		return false, fmt.Sprintf("expected a slice, array, map, or string, but got a %v", collectionValue.Kind())
+	}
+}
+
+// Returns the collection's length, or a reason why it has none
+func getLen(collection interface{}) (int, string) {
+	collectionValue := reflect.ValueOf(collection)
+	switch collectionValue.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.String, reflect.Chan:
+		return collectionValue.Len(), ""
+	default:
+		return 0, fmt.Sprintf("can't get the length of a %v", collectionValue.Kind())
+	}
+}
+
+func isListKind(kind reflect.Kind) bool {
+	return kind == reflect.Slice || kind == reflect.Array
+}
@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestCollectionAssertions(t *testing.T) {
+	testCases := []struct {
+		name      string
+		assertion func() error
+
+		// Empty if the assertion is expected to hold
+		expectedDetail string
+	}{
+		{"Contains slice element", func() error { return Contains([]int{1, 2}, 2, "slice") }, ""},
+		{"Contains missing slice element", func() error { return Contains([]int{1, 2}, 3, "slice") }, "expected [1 2] to contain 3"},
+		{"Contains map key", func() error { return Contains(map[string]int{"a": 1}, "a", "map") }, ""},
+		{"Contains missing map key", func() error { return Contains(map[string]int{"a": 1}, "b", "map") }, `expected map[a:1] to contain "b"`},
+		{"Contains substring", func() error { return Contains("books read", "read", "string") }, ""},
+		{"Contains non-string in string", func() error { return Contains("books read", 3, "string") }, "can only check a string for a string element"},
+		{"Contains in unsupported kind", func() error { return Contains(3, 3, "int") }, "expected a slice, array, map, or string, but got a int"},
+		{"NotContains absent element", func() error { return NotContains([]string{"a"}, "b", "slice") }, ""},
+		{"NotContains present element", func() error { return NotContains([]string{"a"}, "a", "slice") }, `expected [a] not to contain "a"`},
+		{"Len matches", func() error { return Len(map[int]bool{1: true}, 1, "map") }, ""},
+		{"Len differs", func() error { return Len([]int{1, 2}, 3, "slice") }, "expected length 3, actual length 2: [1 2]"},
+		{"Len of unsupported kind", func() error { return Len(3, 1, "int") }, "can't get the length of a int"},
+		{"Empty on empty", func() error { return Empty("", "string") }, ""},
+		{"Empty on non-empty", func() error { return Empty([]int{1}, "slice") }, "expected empty, actual length 1: [1]"},
+		{"NotEmpty on non-empty", func() error { return NotEmpty([]int{1}, "slice") }, ""},
+		{"NotEmpty on empty", func() error { return NotEmpty([]int{}, "slice") }, "expected non-empty, actual empty"},
+		{"ElementsMatch in another order", func() error { return ElementsMatch([]int{1, 2, 2}, []int{2, 1, 2}, "slices") }, ""},
+		{"ElementsMatch counts duplicates", func() error { return ElementsMatch([]int{1, 2, 2}, []int{1, 2}, "slices") }, "missing: 2"},
+		{
+			"ElementsMatch with missing & unexpected elements",
+			func() error { return ElementsMatch([]string{"a", "b"}, []string{"b", "c", "d"}, "slices") },
+			"missing: \"a\"\n  unexpected: \"c\", \"d\"",
+		},
+		{"ElementsMatch on non-lists", func() error { return ElementsMatch([]int{1}, 1, "slices") }, "expected two slices or arrays, but got a slice and a int"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := testCase.assertion()
+			if testCase.expectedDetail == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			failure, ok := GetFailure(err)
+			require.True(t, ok)
+			require.Contains(t, err.Error(), testCase.expectedDetail)
+			require.NotEmpty(t, failure.Description)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+const (
+	// Past this many differences, a diff is summarized rather than listed in full
+	maxDiffLines = 20
+
+	rootDiffPath = "<root>"
+
+	// Guards against cyclic values (e.g. a struct pointing to itself), which would otherwise be descended into forever
+	maxDiffDepth = 32
+
+	// Stands in for the value of a map key that only one of the maps has
+	missingKeyValueStr = "<missing key>"
+
+	// Stands in for both values when they differ somewhere too deeply nested to diff
+	tooDeeplyNestedValueStr = "<nested too deeply to diff>"
+)
+
+// One place where two compared values differ
+type Difference struct {
+	// The path to the differing value inside the compared values (e.g. ".People[2].BooksRead"), or "<root>" if the
+	//  values themselves differ
+	Path string
+
+	Expected string
+	Actual   string
+}
+
+func (difference Difference) String() string {
+	return fmt.Sprintf("%v: expected %v, actual %v", difference.Path, difference.Expected, difference.Actual)
+}
+
+/*
+Finds every place the two values differ, descending into structs, slices, arrays, maps, and pointers so that a mismatch
+ deep inside a large value is easy to find
+*/
+func getDifferences(expected interface{}, actual interface{}) []Difference {
+	return diffValues("", reflect.ValueOf(expected), reflect.ValueOf(actual), 0)
+}
+
+// Describes the differences one "path: expected X, actual Y" line each
+func getDiffString(differences []Difference) string {
+	diffLines := []string{}
+	for idx, difference := range differences {
+		if idx == maxDiffLines {
+			diffLines = append(diffLines, fmt.Sprintf("...and %v more differences", len(differences)-maxDiffLines))
+			break
+		}
+		diffLines = append(diffLines, difference.String())
+	}
+	return strings.Join(diffLines, "\n")
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func diffValues(valuePath string, expected reflect.Value, actual reflect.Value, depth int) []Difference {
+	displayPath := valuePath
+	if displayPath == "" {
+		displayPath = rootDiffPath
+	}
+	if depth > maxDiffDepth {
+		return []Difference{{Path: displayPath, Expected: tooDeeplyNestedValueStr, Actual: tooDeeplyNestedValueStr}}
+	}
+
+	if !expected.IsValid() || !actual.IsValid() {
+		if expected.IsValid() == actual.IsValid() {
+			return []Difference{}
+		}
+		return []Difference{{Path: displayPath, Expected: formatValue(expected), Actual: formatValue(actual)}}
+	}
+	if expected.Type() != actual.Type() {
+		return []Difference{{
+			Path:     displayPath,
+			Expected: fmt.Sprintf("%v (type %v)", formatValue(expected), expected.Type()),
+			Actual:   fmt.Sprintf("%v (type %v)", formatValue(actual), actual.Type()),
+		}}
+	}
+
+	switch expected.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if expected.IsNil() || actual.IsNil() {
+			if expected.IsNil() == actual.IsNil() {
+				return []Difference{}
+			}
+			return []Difference{{Path: displayPath, Expected: formatValue(expected), Actual: formatValue(actual)}}
+		}
+		return diffValues(valuePath, expected.Elem(), actual.Elem(), depth+1)
+	case reflect.Struct:
+		result := []Difference{}
+		for i := 0; i < expected.NumField(); i++ {
+			fieldPath := valuePath + "." + expected.Type().Field(i).Name
+			result = append(result, diffValues(fieldPath, expected.Field(i), actual.Field(i), depth+1)...)
+		}
+		return result
+	case reflect.Slice, reflect.Array:
+		result := []Difference{}
+		if expected.Len() != actual.Len() {
+			result = append(result, Difference{
+				Path:     displayPath,
+				Expected: fmt.Sprintf("length %v", expected.Len()),
+				Actual:   fmt.Sprintf("length %v", actual.Len()),
+			})
+		}
+		for i := 0; i < expected.Len() && i < actual.Len(); i++ {
+			result = append(result, diffValues(fmt.Sprintf("%v[%v]", valuePath, i), expected.Index(i), actual.Index(i), depth+1)...)
+		}
+		return result
+	case reflect.Map:
+		return diffMaps(valuePath, displayPath, expected, actual, depth)
+	default:
+		if formatValue(expected) == formatValue(actual) {
+			return []Difference{}
+		}
+		return []Difference{{Path: displayPath, Expected: formatValue(expected), Actual: formatValue(actual)}}
+	}
+}
+
+func diffMaps(valuePath string, displayPath string, expected reflect.Value, actual reflect.Value, depth int) []Difference {
+	keyStrs := map[string]reflect.Value{}
+	for _, key := range expected.MapKeys() {
+		keyStrs[formatValue(key)] = key
+	}
+	for _, key := range actual.MapKeys() {
+		keyStrs[formatValue(key)] = key
+	}
+	sortedKeyStrs := []string{}
+	for keyStr := range keyStrs {
+		sortedKeyStrs = append(sortedKeyStrs, keyStr)
+	}
+	sort.Strings(sortedKeyStrs)
+
+	result := []Difference{}
+	for _, keyStr := range sortedKeyStrs {
+		key := keyStrs[keyStr]
+		keyPath := fmt.Sprintf("%v[%v]", valuePath, keyStr)
+		expectedValue := expected.MapIndex(key)
+		actualValue := actual.MapIndex(key)
+		switch {
+		case !actualValue.IsValid():
+			result = append(result, Difference{Path: keyPath, Expected: formatValue(expectedValue), Actual: missingKeyValueStr})
+		case !expectedValue.IsValid():
+			result = append(result, Difference{Path: keyPath, Expected: missingKeyValueStr, Actual: formatValue(actualValue)})
+		default:
+			result = append(result, diffValues(keyPath, expectedValue, actualValue, depth+1)...)
+		}
+	}
+	if len(result) == 0 && expected.Len() != actual.Len() {
+		result = append(result, Difference{
+			Path:     displayPath,
+			Expected: fmt.Sprintf("%v entries", expected.Len()),
+			Actual:   fmt.Sprintf("%v entries", actual.Len()),
+		})
+	}
+	return result
+}
+
+// Formats a value for a diff, quoting strings so that whitespace differences are visible
+func formatValue(value reflect.Value) string {
+	if !value.IsValid() {
+		return "<nil>"
+	}
+	if value.Kind() == reflect.String {
+		return fmt.Sprintf("%q", value.String())
+	}
+	// fmt prints the value a reflect.Value holds, even when it came from an unexported field
+	return fmt.Sprintf("%v", value)
+}
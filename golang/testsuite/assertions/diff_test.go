@@ -0,0 +1,167 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+type testPerson struct {
+	Name      string
+	BooksRead int
+	Tags      []string
+	Friends   map[string]*testPerson
+}
+
+// Points to itself, to check that diffing a cyclic value terminates
+type testCycle struct {
+	Next  *testCycle
+	Value int
+}
+
+func TestGetDifferences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected interface{}
+		actual   interface{}
+
+		expectedDiffLines []string
+	}{
+		{
+			name:              "equal structs",
+			expected:          testPerson{Name: "Alice", BooksRead: 3},
+			actual:            testPerson{Name: "Alice", BooksRead: 3},
+			expectedDiffLines: []string{},
+		},
+		{
+			name:              "scalars",
+			expected:          3,
+			actual:            4,
+			expectedDiffLines: []string{"<root>: expected 3, actual 4"},
+		},
+		{
+			name:              "strings are quoted",
+			expected:          "books",
+			actual:            "books ",
+			expectedDiffLines: []string{`<root>: expected "books", actual "books "`},
+		},
+		{
+			name:              "different types",
+			expected:          3,
+			actual:            int64(3),
+			expectedDiffLines: []string{"<root>: expected 3 (type int), actual 3 (type int64)"},
+		},
+		{
+			name:     "struct fields",
+			expected: testPerson{Name: "Alice", BooksRead: 3},
+			actual:   testPerson{Name: "Bob", BooksRead: 4},
+			expectedDiffLines: []string{
+				`.Name: expected "Alice", actual "Bob"`,
+				".BooksRead: expected 3, actual 4",
+			},
+		},
+		{
+			name:     "slice elements & length",
+			expected: []string{"a", "b", "c"},
+			actual:   []string{"a", "x"},
+			expectedDiffLines: []string{
+				"<root>: expected length 3, actual length 2",
+				`[1]: expected "b", actual "x"`,
+			},
+		},
+		{
+			name:     "map keys are sorted, with missing keys marked",
+			expected: map[string]int{"b": 2, "a": 1, "c": 3},
+			actual:   map[string]int{"a": 1, "b": 5, "d": 4},
+			expectedDiffLines: []string{
+				"[\"b\"]: expected 2, actual 5",
+				"[\"c\"]: expected 3, actual <missing key>",
+				"[\"d\"]: expected <missing key>, actual 4",
+			},
+		},
+		{
+			name: "nested through pointers, maps, & slices",
+			expected: &testPerson{
+				Name:    "Alice",
+				Friends: map[string]*testPerson{"bob": {Name: "Bob", Tags: []string{"reader"}}},
+			},
+			actual: &testPerson{
+				Name:    "Alice",
+				Friends: map[string]*testPerson{"bob": {Name: "Bob", Tags: []string{"writer"}}},
+			},
+			expectedDiffLines: []string{`.Friends["bob"].Tags[0]: expected "reader", actual "writer"`},
+		},
+		{
+			name:              "nil & non-nil pointers",
+			expected:          &testPerson{Name: "Alice"},
+			actual:            (*testPerson)(nil),
+			expectedDiffLines: []string{"<root>: expected &{Alice 0 [] map[]}, actual <nil>"},
+		},
+		{
+			name:              "nil & non-nil values",
+			expected:          nil,
+			actual:            3,
+			expectedDiffLines: []string{"<root>: expected <nil>, actual 3"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			differences := getDifferences(testCase.expected, testCase.actual)
+			require.Equal(t, strings.Join(testCase.expectedDiffLines, "\n"), getDiffString(differences))
+		})
+	}
+}
+
+func TestGetDifferences_TerminatesOnCyclicValues(t *testing.T) {
+	expected := &testCycle{Value: 1}
+	expected.Next = expected
+	actual := &testCycle{Value: 2}
+	actual.Next = actual
+
+	differences := getDifferences(expected, actual)
+
+	require.NotEmpty(t, differences)
+	// The cycle is descended into through Next before Value is compared, until the depth limit is hit
+	require.Equal(t, tooDeeplyNestedValueStr, differences[0].Expected)
+	require.Equal(t, tooDeeplyNestedValueStr, differences[0].Actual)
+	require.Equal(t, Difference{Path: ".Value", Expected: "1", Actual: "2"}, differences[len(differences)-1])
+}
+
+func TestGetDiffString_SummarizesLongDiffs(t *testing.T) {
+	numDifferences := maxDiffLines + 5
+	expected := make([]int, numDifferences)
+	actual := make([]int, numDifferences)
+	for i := range actual {
+		actual[i] = 1
+	}
+
+	diffLines := strings.Split(getDiffString(getDifferences(expected, actual)), "\n")
+
+	require.Len(t, diffLines, maxDiffLines+1)
+	require.Equal(t, "[0]: expected 0, actual 1", diffLines[0])
+	require.Equal(t, "...and 5 more differences", diffLines[maxDiffLines])
+}
+
+func TestEqual_FailureHasDifferences(t *testing.T) {
+	err := Equal(testPerson{Name: "Alice", BooksRead: 3}, testPerson{Name: "Alice", BooksRead: 4}, "person %v", "Alice")
+
+	require.Error(t, err)
+	require.Equal(t, FailureErrorCode, stacktrace.GetCode(err))
+	failure, ok := GetFailure(err)
+	require.True(t, ok)
+	require.Equal(t, "person Alice", failure.Description)
+	require.Equal(t, []Difference{{Path: ".BooksRead", Expected: "3", Actual: "4"}}, failure.Differences)
+	require.NotEqual(t, unknownLocation, failure.Location)
+	require.Contains(t, err.Error(), fmt.Sprintf("Assertion failed at %v: person Alice", failure.Location))
+}
+
+func TestEqual_PassesOnEqualValues(t *testing.T) {
+	require.NoError(t, Equal([]int{1, 2}, []int{1, 2}, "numbers"))
+}
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"time"
+)
+
+/*
+Asserts that the assertion (which may be any function that returns nil on success, including a call to one of this
+ package's assertions) holds within the timeout, checking it every poll interval. The failure describes the assertion's
+ last failure.
+
+	err := assertions.Eventually(10*time.Second, 500*time.Millisecond, func() error {
+		person, err := apiClient.GetPerson(personId)
+		if err != nil {
+			return err
+		}
+		return assertions.Equal(3, person.BooksRead, "books read")
+	}, "the API service to see the person's increments")
+*/
+func Eventually(
+	timeout time.Duration,
+	pollInterval time.Duration,
+	assertion func() error,
+	descriptionFmt string,
+	descriptionArgs ...interface{},
+) error {
+	deadline := time.Now().Add(timeout)
+	numAttempts := 0
+	for {
+		numAttempts++
+		lastErr := assertion()
+		if lastErr == nil {
+			return nil
+		}
+		if !time.Now().Add(pollInterval).Before(deadline) {
+			return newAssertionError(
+				fmt.Sprintf("still failing after %v attempts over %v; the last failure was:\n%#s", numAttempts, timeout, lastErr),
+				descriptionFmt,
+				descriptionArgs...,
+			)
+		}
+		time.Sleep(pollInterval)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testPollInterval = 10 * time.Millisecond
+)
+
+func TestEventually(t *testing.T) {
+	testCases := []struct {
+		name    string
+		timeout time.Duration
+
+		// The assertion fails on every attempt before this one, then holds
+		numAttemptsToHold int
+
+		expectErr           bool
+		expectedNumAttempts int
+	}{
+		{
+			name:                "holds immediately",
+			timeout:             time.Second,
+			numAttemptsToHold:   1,
+			expectErr:           false,
+			expectedNumAttempts: 1,
+		},
+		{
+			name:                "holds after retries",
+			timeout:             time.Second,
+			numAttemptsToHold:   3,
+			expectErr:           false,
+			expectedNumAttempts: 3,
+		},
+		{
+			name:                "never holds",
+			timeout:             5 * testPollInterval,
+			numAttemptsToHold:   1000,
+			expectErr:           true,
+			expectedNumAttempts: -1,
+		},
+		{
+			name:                "timeout shorter than the poll interval gets one attempt",
+			timeout:             testPollInterval / 2,
+			numAttemptsToHold:   2,
+			expectErr:           true,
+			expectedNumAttempts: 1,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			numAttempts := 0
+			assertion := func() error {
+				numAttempts++
+				if numAttempts < testCase.numAttemptsToHold {
+					return Equal(testCase.numAttemptsToHold, numAttempts, "attempt number")
+				}
+				return nil
+			}
+
+			startTime := time.Now()
+			err := Eventually(testCase.timeout, testPollInterval, assertion, "the attempt number to reach %v", testCase.numAttemptsToHold)
+			elapsed := time.Since(startTime)
+
+			if testCase.expectedNumAttempts >= 0 {
+				require.Equal(t, testCase.expectedNumAttempts, numAttempts)
+			}
+			if !testCase.expectErr {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			// Eventually gives up rather than sleeping past its deadline
+			require.True(t, elapsed < testCase.timeout+testPollInterval, "Eventually took %v with a timeout of %v", elapsed, testCase.timeout)
+			require.Equal(t, FailureErrorCode, stacktrace.GetCode(err))
+			failure, ok := GetFailure(err)
+			require.True(t, ok)
+			require.Contains(t, failure.Description, "the attempt number to reach")
+			require.Contains(t, failure.Detail, "still failing after")
+			// The last failure of the assertion is included, so it's clear why it never held
+			require.Contains(t, failure.Detail, "attempt number")
+		})
+	}
+}
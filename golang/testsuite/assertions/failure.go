@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"path"
+	"runtime"
+	"strings"
+)
+
+const (
+	// The stacktrace error code of assertion failures, which errors propagated from them inherit, so that
+	//  stacktrace.GetCode(err) == FailureErrorCode tells whether an error came from a failed assertion
+	FailureErrorCode stacktrace.ErrorCode = 1
+
+	// Plenty to get past this package's own frames to the test's
+	maxCallerFramesToSearch = 20
+
+	unknownLocation = "<unknown location>"
+)
+
+// The prefix of every function name in this package, e.g. "github.com/.../assertions."
+var packageFuncNamePrefix = getPackageFuncNamePrefix()
+
+/*
+A failed assertion, which is the root cause of the error the assertion returns (and of every error propagated from it),
+ so that it can be recovered from the error with GetFailure
+*/
+type Failure struct {
+	// Where in the test the assertion was made, e.g. "basic_datastore_test_.go:52 (BasicDatastoreTest.Run)"
+	Location string
+
+	Description string
+
+	// What was expected & what was actually found
+	Detail string
+
+	// Empty unless the assertion compared two values, in which case there's one entry per place the values differ
+	Differences []Difference
+}
+
+func (failure *Failure) Error() string {
+	return fmt.Sprintf("Assertion failed at %v: %v\n%v", failure.Location, failure.Description, indent(failure.Detail))
+}
+
+/*
+Gets the failed assertion that the error was caused by, or false if it wasn't caused by a single failed assertion
+
+The errors from SoftAssertions.GetError aren't caused by a single failed assertion, though they still have
+ FailureErrorCode.
+*/
+func GetFailure(err error) (*Failure, bool) {
+	failure, ok := stacktrace.RootCause(err).(*Failure)
+	return failure, ok
+}
+
+/*
+Creates the error for a failed assertion, which begins with the location in the test that made the assertion (rather
+ than the location inside this package that stacktrace would otherwise point at)
+*/
+func newAssertionError(detail string, descriptionFmt string, descriptionArgs ...interface{}) error {
+	return newFailureError(detail, []Difference{}, descriptionFmt, descriptionArgs...)
+}
+
+// Creates the error for a failed assertion that compared two values, with the differences as the error's detail
+func newDifferencesAssertionError(differences []Difference, descriptionFmt string, descriptionArgs ...interface{}) error {
+	return newFailureError(getDiffString(differences), differences, descriptionFmt, descriptionArgs...)
+}
+
+// Gets the file, line, & function of the first caller outside this package
+func getCallerLocation() string {
+	programCounters := make([]uintptr, maxCallerFramesToSearch)
+	numFrames := runtime.Callers(1, programCounters)
+	frames := runtime.CallersFrames(programCounters[:numFrames])
+	for {
+		frame, hasMore := frames.Next()
+		if !strings.HasPrefix(frame.Function, packageFuncNamePrefix) {
+			return fmt.Sprintf("%v:%v (%v)", path.Base(frame.File), frame.Line, getShortFuncName(frame.Function))
+		}
+		if !hasMore {
+			return unknownLocation
+		}
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func newFailureError(detail string, differences []Difference, descriptionFmt string, descriptionArgs ...interface{}) error {
+	failure := &Failure{
+		Location:    getCallerLocation(),
+		Description: fmt.Sprintf(descriptionFmt, descriptionArgs...),
+		Detail:      detail,
+		Differences: differences,
+	}
+	// The empty message leaves the failure's own message as the error's message
+	return stacktrace.PropagateWithCode(failure, FailureErrorCode, "")
+}
+
+func getPackageFuncNamePrefix() string {
+	programCounter, _, _, _ := runtime.Caller(0)
+	funcName := runtime.FuncForPC(programCounter).Name()
+	lastSlashIdx := strings.LastIndex(funcName, "/")
+	packageSeparatorIdx := lastSlashIdx + strings.Index(funcName[lastSlashIdx:], ".")
+	return funcName[:packageSeparatorIdx+1]
+}
+
+// Turns "github.com/org/repo/pkg.(*Type).Method" into "Type.Method", matching how stacktrace prints functions
+func getShortFuncName(funcName string) string {
+	shortName := funcName[strings.LastIndex(funcName, "/")+1:]
+	if dotIdx := strings.Index(shortName, "."); dotIdx >= 0 {
+		shortName = shortName[dotIdx+1:]
+	}
+	shortName = strings.Replace(shortName, "(*", "", 1)
+	shortName = strings.Replace(shortName, ")", "", 1)
+	return shortName
+}
+
+func indent(text string) string {
+	lines := strings.Split(text, "\n")
+	for idx, line := range lines {
+		lines[idx] = "  " + line
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	defaultPollInitialDelay      = 100 * time.Millisecond
+	defaultPollMaxDelay          = 2 * time.Second
+	defaultPollBackoffMultiplier = 2.0
+)
+
+/*
+Checks a condition once, returning the value it observed along with nil if the condition holds or an error describing
+ why it doesn't (e.g. an assertion's error, or the error from reading the value)
+*/
+type Condition func() (interface{}, error)
+
+/*
+Polls conditions with exponential backoff until they hold, for asserting on services that are only eventually
+ consistent (e.g. reading a value through one API service right after writing it through another)
+
+A Poller is created at the start of a test's Run with the test's configured run timeout, and no poll is allowed to go
+ past the point at which the test would time out: that way a condition that never holds fails the test with the last
+ value it saw, rather than the test being killed without explanation.
+*/
+type Poller struct {
+	runDeadline       time.Time
+	initialDelay      time.Duration
+	maxDelay          time.Duration
+	backoffMultiplier float64
+}
+
+func NewPoller(runTimeout time.Duration) *Poller {
+	return &Poller{
+		runDeadline:       time.Now().Add(runTimeout),
+		initialDelay:      defaultPollInitialDelay,
+		maxDelay:          defaultPollMaxDelay,
+		backoffMultiplier: defaultPollBackoffMultiplier,
+	}
+}
+
+func (poller *Poller) WithBackoff(initialDelay time.Duration, maxDelay time.Duration, backoffMultiplier float64) *Poller {
+	poller.initialDelay = initialDelay
+	poller.maxDelay = maxDelay
+	poller.backoffMultiplier = backoffMultiplier
+	return poller
+}
+
+/*
+Polls the condition until it holds, giving up after the max wait or at the test's run deadline (whichever is sooner),
+ and logging every attempt. Returns the last value the condition observed, along with an error (which includes that
+ value) if the condition never held.
+*/
+func (poller *Poller) PollUntil(
+	maxWait time.Duration,
+	condition Condition,
+	descriptionFmt string,
+	descriptionArgs ...interface{},
+) (interface{}, error) {
+	description := fmt.Sprintf(descriptionFmt, descriptionArgs...)
+	startTime := time.Now()
+	deadline := startTime.Add(maxWait)
+	if deadline.After(poller.runDeadline) {
+		deadline = poller.runDeadline
+	}
+
+	delay := poller.initialDelay
+	numAttempts := 0
+	for {
+		numAttempts++
+		lastValue, lastErr := condition()
+		if lastErr == nil {
+			logrus.Infof("Polled for %v: held on attempt %v after %v", description, numAttempts, time.Since(startTime))
+			return lastValue, nil
+		}
+		logrus.Infof("Polled for %v: attempt %v observed %v, which doesn't hold: %#s", description, numAttempts, lastValue, lastErr)
+
+		if time.Now().Add(delay).After(deadline) {
+			return lastValue, newAssertionError(
+				fmt.Sprintf(
+					"didn't hold within %v (after %v attempts); the last observed value was %v, which failed with:\n%#s",
+					time.Since(startTime),
+					numAttempts,
+					lastValue,
+					lastErr,
+				),
+				"%v",
+				description,
+			)
+		}
+		time.Sleep(delay)
+
+		delay = time.Duration(float64(delay) * poller.backoffMultiplier)
+		if delay > poller.maxDelay {
+			delay = poller.maxDelay
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"strings"
+)
+
+/*
+Collects the results of several assertions so that checking continues past failures, and every failure is reported
+ together in a single error:
+
+	softAssertions := assertions.NewSoftAssertions()
+	softAssertions.Check(assertions.Equal(expectedId, person.Id, "person ID"))
+	softAssertions.Check(assertions.Equal(expectedBooksRead, person.BooksRead, "books read"))
+	if err := softAssertions.GetError(); err != nil {
+		return stacktrace.Propagate(err, "The test person is wrong")
+	}
+*/
+type SoftAssertions struct {
+	numChecked int
+	failures   []error
+}
+
+func NewSoftAssertions() *SoftAssertions {
+	return &SoftAssertions{
+		numChecked: 0,
+		failures:   []error{},
+	}
+}
+
+// Records an assertion's result, which is nil if the assertion held
+func (soft *SoftAssertions) Check(assertionErr error) {
+	soft.numChecked++
+	if assertionErr != nil {
+		soft.failures = append(soft.failures, assertionErr)
+	}
+}
+
+func (soft *SoftAssertions) GetNumFailures() int {
+	return len(soft.failures)
+}
+
+// Returns nil if every assertion held, or a single error listing every failure (each with its location in the test)
+func (soft *SoftAssertions) GetError() error {
+	if len(soft.failures) == 0 {
+		return nil
+	}
+	failureStrs := []string{}
+	for idx, failure := range soft.failures {
+		// The brief format drops the locations inside this package, which would repeat for every failure
+		failureStrs = append(failureStrs, fmt.Sprintf("%v) %#s", idx+1, failure))
+	}
+	return stacktrace.NewErrorWithCode(
+		FailureErrorCode,
+		"%v of %v assertions failed:\n%v",
+		len(soft.failures),
+		soft.numChecked,
+		strings.Join(failureStrs, "\n"),
+	)
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package assertions
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+func TestSoftAssertions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		assertions []error
+
+		// Empty if no error is expected
+		expectedErrHeader      string
+		expectedFailureDetails []string
+	}{
+		{
+			name:                   "nothing checked",
+			assertions:             []error{},
+			expectedErrHeader:      "",
+			expectedFailureDetails: []string{},
+		},
+		{
+			name: "every assertion holds",
+			assertions: []error{
+				Equal(1, 1, "first"),
+				True(true, "second"),
+			},
+			expectedErrHeader:      "",
+			expectedFailureDetails: []string{},
+		},
+		{
+			name: "some assertions fail",
+			assertions: []error{
+				Equal(1, 2, "first"),
+				True(true, "second"),
+				Len([]int{1}, 2, "third"),
+			},
+			expectedErrHeader: "2 of 3 assertions failed:",
+			expectedFailureDetails: []string{
+				"first",
+				"<root>: expected 1, actual 2",
+				"third",
+				"expected length 2, actual length 1",
+			},
+		},
+		{
+			name: "every assertion fails",
+			assertions: []error{
+				False(true, "first"),
+				Empty([]int{1}, "second"),
+			},
+			expectedErrHeader: "2 of 2 assertions failed:",
+			expectedFailureDetails: []string{
+				"first",
+				"expected false, actual true",
+				"second",
+				"expected empty, actual length 1",
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			softAssertions := NewSoftAssertions()
+			for _, assertionErr := range testCase.assertions {
+				softAssertions.Check(assertionErr)
+			}
+
+			err := softAssertions.GetError()
+			if testCase.expectedErrHeader == "" {
+				require.NoError(t, err)
+				require.Equal(t, 0, softAssertions.GetNumFailures())
+				return
+			}
+			require.Error(t, err)
+			require.Equal(t, FailureErrorCode, stacktrace.GetCode(err))
+			errStr := err.Error()
+			require.Contains(t, errStr, testCase.expectedErrHeader)
+			for _, detail := range testCase.expectedFailureDetails {
+				require.Contains(t, errStr, detail)
+			}
+			// The failures are listed in the order they were checked, numbered from 1
+			numFailures := softAssertions.GetNumFailures()
+			lastIdx := -1
+			for failureNum := 1; failureNum <= numFailures; failureNum++ {
+				idx := strings.Index(errStr, fmt.Sprintf("%v) ", failureNum))
+				require.True(t, idx > lastIdx, "Failure %v isn't listed after failure %v", failureNum, failureNum-1)
+				lastIdx = idx
+			}
+			// Several failures don't have a single root cause
+			_, ok := GetFailure(err)
+			require.False(t, ok)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+)
+
+const (
+	// The name the bundle is recorded under in the test's artifact paths
+	BundleArtifactName = "diagnosticsBundle"
+
+	bundleDirPerms  = 0755
+	bundleFilePerms = 0644
+)
+
+// Everything collected about a failed test, written out as a single JSON file
+type Bundle struct {
+	TestName string              `json:"testName"`
+	Owner    string              `json:"owner"`
+	Tags     []test_tags.Tag     `json:"tags"`
+	Params   []test_params.Param `json:"params"`
+	Phase    string              `json:"phase"`
+	Time     time.Time           `json:"time"`
+	Error    string              `json:"error"`
+
+	Services       []*test_metadata.ServiceRecord `json:"services"`
+	ResolvedImages map[services.ServiceID]string  `json:"resolvedImages"`
+	RecentRequests []RequestRecord                `json:"recentRequests"`
+}
+
+/*
+Collects a diagnostics bundle for a test that failed in the given phase and writes it to the given directory,
+ returning the bundle's filepath
+*/
+func WriteBundle(
+	outputDirpath string,
+	phase string,
+	testErr error,
+	metadata *test_metadata.TestMetadata,
+	requestRecorder *RequestRecorder,
+) (string, error) {
+	bundle := &Bundle{
+		TestName:       metadata.GetTestName(),
+		Owner:          metadata.GetOwner(),
+		Tags:           metadata.GetTags(),
+		Params:         metadata.GetParams(),
+		Phase:          phase,
+		Time:           time.Now(),
+		Error:          fmt.Sprintf("%v", testErr),
+		Services:       metadata.GetStartedServices(),
+		ResolvedImages: metadata.GetResolvedServiceImages(),
+		RecentRequests: requestRecorder.GetRecentRequests(),
+	}
+	bundleBytes, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred serializing the diagnostics bundle")
+	}
+
+	if err := os.MkdirAll(outputDirpath, bundleDirPerms); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred creating diagnostics directory '%v'", outputDirpath)
+	}
+	bundleFilepath := path.Join(outputDirpath, fmt.Sprintf("%v-%v.json", bundle.TestName, phase))
+	if err := ioutil.WriteFile(bundleFilepath, bundleBytes, bundleFilePerms); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred writing the diagnostics bundle to '%v'", bundleFilepath)
+	}
+	return bundleFilepath, nil
+}
@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package diagnostics
+
+import (
+	"github.com/palantir/stacktrace"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// A single HTTP request made by a test client
+type RequestRecord struct {
+	StartTime time.Time     `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+	Method    string        `json:"method"`
+	Url       string        `json:"url"`
+
+	// 0 if no response was received
+	StatusCode int `json:"statusCode"`
+
+	// Empty if a response was received
+	Error string `json:"error"`
+}
+
+/*
+An http.RoundTripper that remembers the last N requests made through it
+
+The suite gives a recorder to the clients it creates for the tests (see services_impl.NewApiClient), so that the
+ requests of every test client are captured without touching any other HTTP traffic in the process.
+*/
+type RequestRecorder struct {
+	underlying  http.RoundTripper
+	maxRequests int
+
+	mutex *sync.Mutex
+
+	// Ring buffer of the most recent requests, oldest first once unrolled
+	requests       []RequestRecord
+	nextRequestIdx int
+}
+
+func NewRequestRecorder(underlying http.RoundTripper, maxRequests int) (*RequestRecorder, error) {
+	if maxRequests <= 0 {
+		return nil, stacktrace.NewError("A request recorder must keep at least one request, but the max requests was %v", maxRequests)
+	}
+	return &RequestRecorder{
+		underlying:     underlying,
+		maxRequests:    maxRequests,
+		mutex:          &sync.Mutex{},
+		requests:       []RequestRecord{},
+		nextRequestIdx: 0,
+	}, nil
+}
+
+func (recorder *RequestRecorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	startTime := time.Now()
+	resp, err := recorder.underlying.RoundTrip(request)
+	record := RequestRecord{
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Method:    request.Method,
+		Url:       request.URL.String(),
+	}
+	if err != nil {
+		record.Error = err.Error()
+	} else {
+		record.StatusCode = resp.StatusCode
+	}
+	recorder.record(record)
+	return resp, err
+}
+
+// Gets the most recent requests, oldest first
+func (recorder *RequestRecorder) GetRecentRequests() []RequestRecord {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if len(recorder.requests) < recorder.maxRequests {
+		return append([]RequestRecord{}, recorder.requests...)
+	}
+	result := append([]RequestRecord{}, recorder.requests[recorder.nextRequestIdx:]...)
+	return append(result, recorder.requests[:recorder.nextRequestIdx]...)
+}
+
+// Forgets all requests recorded so far, so that a new test's diagnostics don't include a previous test's requests
+func (recorder *RequestRecorder) Clear() {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.requests = []RequestRecord{}
+	recorder.nextRequestIdx = 0
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (recorder *RequestRecorder) record(record RequestRecord) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	if len(recorder.requests) < recorder.maxRequests {
+		recorder.requests = append(recorder.requests, record)
+		return
+	}
+	recorder.requests[recorder.nextRequestIdx] = record
+	recorder.nextRequestIdx = (recorder.nextRequestIdx + 1) % recorder.maxRequests
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package diagnostics
+
+import (
+	"errors"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+const (
+	requestUrlPrefix = "http://127.0.0.1/person/"
+
+	// Requests to this path fail with failedRequestErr rather than getting a response
+	failingRequestPath = "fail"
+)
+
+var failedRequestErr = errors.New("connection refused")
+
+// A transport that responds with 200 to every request except those to failingRequestPath, without touching the network
+type fakeRoundTripper struct{}
+
+func (roundTripper fakeRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if request.URL.Path == "/person/"+failingRequestPath {
+		return nil, failedRequestErr
+	}
+	return &http.Response{StatusCode: http.StatusOK, Request: request}, nil
+}
+
+func TestRequestRecorder_KeepsMostRecentRequests(t *testing.T) {
+	testCases := []struct {
+		name        string
+		maxRequests int
+		numRequests int
+
+		// The indexes of the requests that should be recorded, oldest first
+		expectedRequestIdxs []int
+	}{
+		{"no requests", 3, 0, []int{}},
+		{"fewer requests than max", 3, 2, []int{0, 1}},
+		{"exactly max requests", 3, 3, []int{0, 1, 2}},
+		{"one more than max", 3, 4, []int{1, 2, 3}},
+		{"wraps around exactly", 3, 6, []int{3, 4, 5}},
+		{"wraps around partway", 3, 8, []int{5, 6, 7}},
+		{"max of one", 1, 5, []int{4}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder, err := NewRequestRecorder(fakeRoundTripper{}, testCase.maxRequests)
+			require.NoError(t, err)
+			for i := 0; i < testCase.numRequests; i++ {
+				sendRequest(t, recorder, strconv.Itoa(i))
+			}
+			requestUrls := []string{}
+			for _, record := range recorder.GetRecentRequests() {
+				requestUrls = append(requestUrls, record.Url)
+			}
+			expectedRequestUrls := []string{}
+			for _, idx := range testCase.expectedRequestIdxs {
+				expectedRequestUrls = append(expectedRequestUrls, requestUrlPrefix+strconv.Itoa(idx))
+			}
+			require.Equal(t, expectedRequestUrls, requestUrls)
+		})
+	}
+}
+
+func TestNewRequestRecorder_RejectsNonPositiveMaxRequests(t *testing.T) {
+	for _, maxRequests := range []int{0, -1} {
+		_, err := NewRequestRecorder(fakeRoundTripper{}, maxRequests)
+		require.Error(t, err, "Expected max requests of %v to be rejected", maxRequests)
+	}
+}
+
+func TestRequestRecorder_RecordsResponsesAndErrors(t *testing.T) {
+	recorder, err := NewRequestRecorder(fakeRoundTripper{}, 2)
+	require.NoError(t, err)
+	sendRequest(t, recorder, "1")
+	_, err = recorder.RoundTrip(createRequest(t, failingRequestPath))
+	require.Equal(t, failedRequestErr, err)
+
+	records := recorder.GetRecentRequests()
+	require.Len(t, records, 2)
+	require.Equal(t, http.MethodGet, records[0].Method)
+	require.Equal(t, http.StatusOK, records[0].StatusCode)
+	require.Empty(t, records[0].Error)
+	require.Equal(t, 0, records[1].StatusCode)
+	require.Equal(t, failedRequestErr.Error(), records[1].Error)
+}
+
+func TestRequestRecorder_ClearStartsOver(t *testing.T) {
+	recorder, err := NewRequestRecorder(fakeRoundTripper{}, 2)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		sendRequest(t, recorder, strconv.Itoa(i))
+	}
+	recorder.Clear()
+	require.Empty(t, recorder.GetRecentRequests())
+
+	sendRequest(t, recorder, "3")
+	records := recorder.GetRecentRequests()
+	require.Len(t, records, 1)
+	require.Equal(t, requestUrlPrefix+"3", records[0].Url)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func createRequest(t *testing.T, path string) *http.Request {
+	request, err := http.NewRequest(http.MethodGet, requestUrlPrefix+path, nil)
+	require.NoError(t, err)
+	return request
+}
+
+func sendRequest(t *testing.T, recorder *RequestRecorder, path string) {
+	_, err := recorder.RoundTrip(createRequest(t, path))
+	require.NoError(t, err)
+}
@@ -6,6 +6,28 @@
 package execution_impl
 
 type ExampleTestsuiteArgs struct {
-	ApiServiceImage	string 			`json:"apiServiceImage"`
-	DatastoreServiceImage string	`json:"datastoreServiceImage"`
+	// Images may be pinned to a tag ("repo:tag"), a digest ("repo@sha256:..."), or both
+	ApiServiceImage       string `json:"apiServiceImage"`
+	DatastoreServiceImage string `json:"datastoreServiceImage"`
+
+	// Optional; only needed by tests that put fault proxies between services
+	FaultProxyServiceImage string `json:"faultProxyServiceImage"`
+
+	// Mapping of test name -> images that test should use instead of the defaults above
+	TestImageOverrides map[string]TestImageOverridesArgs `json:"testImageOverrides"`
+
+	// Optional filters on which tests run; see test_tags.TestFilter for how they combine
+	IncludeTags             []string `json:"includeTags"`
+	ExcludeTags             []string `json:"excludeTags"`
+	IncludeTestNamePatterns []string `json:"includeTestNamePatterns"`
+	ExcludeTestNamePatterns []string `json:"excludeTestNamePatterns"`
+
+	// Optional; the seed for property-based tests to generate their operations from, for replaying a failure
+	PropertyTestSeed int64 `json:"propertyTestSeed"`
+}
+
+// Each field is optional; an empty field means the test uses the suite-wide default
+type TestImageOverridesArgs struct {
+	ApiServiceImage       string `json:"apiServiceImage"`
+	DatastoreServiceImage string `json:"datastoreServiceImage"`
 }
@@ -7,13 +7,39 @@ package execution_impl
 
 import (
 	"encoding/json"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/kurtosis_testsuite_docker_api"
 	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/diagnostics"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fake_network"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/local_execution"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/stand_ins"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
+	"net/http"
 	"strings"
 )
 
+const (
+	// How many of the test clients' most recent requests are kept for a failed test's diagnostics bundle
+	maxRecordedRequests = 100
+
+	// Where the Dockerfile puts the contents of the static_files directory
+	containerStaticFilesDirpath = "/static-files"
+
+	// Running locally, the fault proxy is always an in-process stand-in so no image is ever pulled; this one is used
+	//  when the params don't name one, so that the tests that insert fault proxies can run on a clean checkout
+	defaultLocalFaultProxyImage = "kurtosis-golang-example-fault-proxy:local"
+
+	// The roles of the services that stand-ins back in local runs, for reporting images given for more than one role
+	datastoreStandInRole  = "datastore"
+	apiStandInRole        = "API"
+	faultProxyStandInRole = "fault proxy"
+)
+
 type ExampleTestsuiteConfigurator struct {}
 
 func NewExampleTestsuiteConfigurator() *ExampleTestsuiteConfigurator {
@@ -34,18 +60,177 @@ func (t ExampleTestsuiteConfigurator) SetLogLevel(logLevelStr string) error {
 }
 
 func (t ExampleTestsuiteConfigurator) ParseParamsAndCreateSuite(paramsJsonStr string) (testsuite.TestSuite, error) {
+	args, err := parseArgs(paramsJsonStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the testsuite params")
+	}
+	suite, err := createSuite(args, containerStaticFilesDirpath, kurtosis_testsuite_docker_api.TestsuiteContainerSuiteExVolMountpoint)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the testsuite")
+	}
+	return suite, nil
+}
+
+func (t ExampleTestsuiteConfigurator) ParseParamsAndCreateLocalSuite(
+	paramsJsonStr string,
+	staticFilesDirpath string,
+	outputDirpath string,
+) (testsuite.TestSuite, error) {
+	args, err := parseLocalArgs(paramsJsonStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the testsuite params")
+	}
+	suite, err := createSuite(args, staticFilesDirpath, outputDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the testsuite to run locally")
+	}
+	return suite, nil
+}
+
+/*
+Backs the datastore, API, and fault proxy images that the tests will use (the defaults and every override) with
+ in-process stand-ins, so that the tests can run locally; services using the suite's static files get the given local
+ copies of them
+*/
+func (t ExampleTestsuiteConfigurator) CreateLocalBackend(paramsJsonStr string, staticFiles map[services.StaticFileID]string) (local_execution.Backend, error) {
+	args, err := parseLocalArgs(paramsJsonStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the testsuite params")
+	}
+
+	datastoreImageStrs := []string{args.DatastoreServiceImage}
+	apiImageStrs := []string{args.ApiServiceImage}
+	for _, overridesArgs := range args.TestImageOverrides {
+		if overridesArgs.DatastoreServiceImage != "" {
+			datastoreImageStrs = append(datastoreImageStrs, overridesArgs.DatastoreServiceImage)
+		}
+		if overridesArgs.ApiServiceImage != "" {
+			apiImageStrs = append(apiImageStrs, overridesArgs.ApiServiceImage)
+		}
+	}
+
+	standInFactories := map[string]fake_network.StandInFactory{}
+	// Mapping of image -> the role of the services it was given for, as one image can't back two kinds of stand-in
+	standInImageRoles := map[string]string{}
+	for _, imageStr := range datastoreImageStrs {
+		if err := addStandInFactory(standInFactories, standInImageRoles, imageStr, datastoreStandInRole, stand_ins.NewDatastoreStandInFactory()); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred adding the stand-in for datastore image '%v'", imageStr)
+		}
+	}
+	for _, imageStr := range apiImageStrs {
+		if err := addStandInFactory(standInFactories, standInImageRoles, imageStr, apiStandInRole, stand_ins.NewApiStandInFactory()); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred adding the stand-in for API image '%v'", imageStr)
+		}
+	}
+	if err := addStandInFactory(standInFactories, standInImageRoles, args.FaultProxyServiceImage, faultProxyStandInRole, stand_ins.NewFaultProxyStandInFactory()); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred adding the stand-in for fault proxy image '%v'", args.FaultProxyServiceImage)
+	}
+	return local_execution.NewFakeBackend(standInFactories, staticFiles), nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+/*
+Registers the factory of the stand-in that backs the given image in local runs, failing if the image was already given
+ for services of another role, as the services of one of the roles would then be backed by the wrong stand-in
+*/
+func addStandInFactory(
+	standInFactories map[string]fake_network.StandInFactory,
+	standInImageRoles map[string]string,
+	imageStr string,
+	role string,
+	factory fake_network.StandInFactory,
+) error {
+	// The services are started with the parsed form of each image, so that's what the stand-ins must be keyed by
+	image, err := service_images.ParseImageRef(imageStr)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred parsing image '%v'", imageStr)
+	}
+	key := image.String()
+	if existingRole, found := standInImageRoles[key]; found && existingRole != role {
+		return stacktrace.NewError(
+			"Image '%v' was given for both %v and %v services, but local runs need a distinct image per role to know which stand-in to start",
+			key,
+			existingRole,
+			role,
+		)
+	}
+	standInImageRoles[key] = role
+	standInFactories[key] = factory
+	return nil
+}
+
+func createSuite(args ExampleTestsuiteArgs, staticFilesDirpath string, outputDirpath string) (*testsuite_impl.ExampleTestsuite, error) {
+	testImageOverrides := map[string]service_images.ImageOverrides{}
+	for testName, overridesArgs := range args.TestImageOverrides {
+		testImageOverrides[testName] = service_images.ImageOverrides{
+			DatastoreImage: overridesArgs.DatastoreServiceImage,
+			ApiImage:       overridesArgs.ApiServiceImage,
+		}
+	}
+	imageResolver, err := service_images.NewImageResolver(
+		args.DatastoreServiceImage,
+		args.ApiServiceImage,
+		args.FaultProxyServiceImage,
+		testImageOverrides,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred resolving the service images from the testsuite params")
+	}
+
+	testFilter, err := test_tags.NewTestFilter(
+		args.IncludeTags,
+		args.ExcludeTags,
+		args.IncludeTestNamePatterns,
+		args.ExcludeTestNamePatterns,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the test filter from the testsuite params")
+	}
+
+	requestRecorder, err := diagnostics.NewRequestRecorder(http.DefaultTransport, maxRecordedRequests)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the request recorder")
+	}
+
+	suite, err := testsuite_impl.NewExampleTestsuite(
+		imageResolver,
+		staticFilesDirpath,
+		outputDirpath,
+		requestRecorder,
+		testFilter,
+		args.PropertyTestSeed,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating the testsuite")
+	}
+	return suite, nil
+}
+
+func parseArgs(paramsJsonStr string) (ExampleTestsuiteArgs, error) {
 	paramsJsonBytes := []byte(paramsJsonStr)
 	var args ExampleTestsuiteArgs
 	if err := json.Unmarshal(paramsJsonBytes, &args); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred deserializing the testsuite params JSON")
+		return ExampleTestsuiteArgs{}, stacktrace.Propagate(err, "An error occurred deserializing the testsuite params JSON")
 	}
 
 	if err := validateArgs(args); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred validating the deserialized testsuite params")
+		return ExampleTestsuiteArgs{}, stacktrace.Propagate(err, "An error occurred validating the deserialized testsuite params")
 	}
+	return args, nil
+}
 
-	suite := testsuite_impl.NewExampleTestsuite(args.ApiServiceImage, args.DatastoreServiceImage)
-	return suite, nil
+// Like parseArgs, but fills in the defaults that only make sense when running locally
+func parseLocalArgs(paramsJsonStr string) (ExampleTestsuiteArgs, error) {
+	args, err := parseArgs(paramsJsonStr)
+	if err != nil {
+		return ExampleTestsuiteArgs{}, stacktrace.Propagate(err, "An error occurred parsing the testsuite params")
+	}
+	if strings.TrimSpace(args.FaultProxyServiceImage) == "" {
+		args.FaultProxyServiceImage = defaultLocalFaultProxyImage
+	}
+	return args, nil
 }
 
 func validateArgs(args ExampleTestsuiteArgs) error {
@@ -0,0 +1,50 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fake_network
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+)
+
+/*
+The API container client handed to the fake network's ServiceContexts
+
+Only ExecCommand is implemented (by the service's stand-in); the other ServiceContext methods need a real API
+ container, and calling them panics on the nil embedded client.
+*/
+type fakeApiContainerClient struct {
+	kurtosis_core_rpc_api_bindings.ApiContainerServiceClient
+
+	network *FakeNetworkContext
+}
+
+func (client fakeApiContainerClient) ExecCommand(
+	ctx context.Context,
+	args *kurtosis_core_rpc_api_bindings.ExecCommandArgs,
+	opts ...grpc.CallOption,
+) (*kurtosis_core_rpc_api_bindings.ExecCommandResponse, error) {
+	serviceId := services.ServiceID(args.ServiceId)
+	standIn, err := client.network.GetStandIn(serviceId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the stand-in for service '%v'", serviceId)
+	}
+	executor, ok := standIn.(CommandExecutor)
+	if !ok {
+		return nil, stacktrace.NewError("The stand-in for service '%v' can't execute commands", serviceId)
+	}
+	exitCode, logOutput, err := executor.ExecCommand(args.CommandArgs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred executing command '%v' on the stand-in for service '%v'", args.CommandArgs, serviceId)
+	}
+	return &kurtosis_core_rpc_api_bindings.ExecCommandResponse{
+		ExitCode:  exitCode,
+		LogOutput: logOutput,
+	}, nil
+}
@@ -0,0 +1,468 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fake_network
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	filesDirPrefix = "fake-network-"
+
+	// Fake IPs are handed out from 127.0.0.0/8, skipping 127.0.0.1, so that stand-ins listening on them are
+	//  reachable on Linux without any extra setup (other OSes route only 127.0.0.1 by default)
+	firstFakeIpSuffix = 2
+	maxFakeIpSuffix   = 1<<24 - 2
+
+	generatedFilesDirname = "generated-files"
+
+	endpointAvailabilityTimeout = 2 * time.Second
+)
+
+// Compile-time check that the fake can stand in for the real network context
+var _ network_context.NetworkContext = &FakeNetworkContext{}
+
+// A record of a service added to the fake network
+type AddServiceCall struct {
+	ServiceId               services.ServiceID
+	PartitionId             networks.PartitionID
+	IpAddr                  string
+	ContainerCreationConfig *services.ContainerCreationConfig
+
+	// Mapping of generated file key -> where the file was written on the local filesystem
+	GeneratedFileFilepaths map[string]string
+
+	// The result of calling the service's run config func
+	RunConfig *services.ContainerRunConfig
+}
+
+// A record of a call to RepartitionNetwork
+type RepartitionCall struct {
+	PartitionServices    map[networks.PartitionID]map[services.ServiceID]bool
+	PartitionConnections map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo
+	DefaultConnection    *kurtosis_core_rpc_api_bindings.PartitionConnectionInfo
+}
+
+/*
+An in-memory implementation of network_context.NetworkContext, for unit-testing tests and Networks without Docker
+ or Kurtosis
+
+Adding a service generates its files in a temp directory, calls its run config func, assigns it a fake IP and, if a
+ stand-in is registered for its image, starts that stand-in. Every call is recorded so it can be asserted on.
+ Partitions are recorded but not enforced.
+*/
+type FakeNetworkContext struct {
+	mutex *sync.Mutex
+
+	filesDirpath string
+
+	// Mapping of image -> factory for the stand-ins that services with that image get
+	standInFactories map[string]StandInFactory
+
+	// Mapping of static file ID -> filepath on the local filesystem
+	staticFiles map[services.StaticFileID]string
+
+	nextFakeIpSuffix uint32
+
+	services map[services.ServiceID]*fakeService
+
+	addServiceCalls   []*AddServiceCall
+	removedServiceIds []services.ServiceID
+	repartitionCalls  []*RepartitionCall
+}
+
+type fakeService struct {
+	serviceCtx  *services.ServiceContext
+	partitionId networks.PartitionID
+
+	// Nil if no stand-in is registered for the service's image
+	standIn StandIn
+}
+
+// Destroy should be called when the fake is no longer needed, to stop its stand-ins and remove its temp directory
+func NewFakeNetworkContext() (*FakeNetworkContext, error) {
+	filesDirpath, err := ioutil.TempDir("", filesDirPrefix)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating a temp directory for the fake network's files")
+	}
+	return &FakeNetworkContext{
+		mutex:             &sync.Mutex{},
+		filesDirpath:      filesDirpath,
+		standInFactories:  map[string]StandInFactory{},
+		staticFiles:       map[services.StaticFileID]string{},
+		nextFakeIpSuffix:  firstFakeIpSuffix,
+		services:          map[services.ServiceID]*fakeService{},
+		addServiceCalls:   []*AddServiceCall{},
+		removedServiceIds: []services.ServiceID{},
+		repartitionCalls:  []*RepartitionCall{},
+	}, nil
+}
+
+// Services started with the given image will be backed by stand-ins from the given factory
+func (network *FakeNetworkContext) WithStandIn(image string, factory StandInFactory) *FakeNetworkContext {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	network.standInFactories[image] = factory
+	return network
+}
+
+// Services using the given static file will get the file at the given local filepath
+func (network *FakeNetworkContext) WithStaticFile(staticFileId services.StaticFileID, filepath string) *FakeNetworkContext {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	network.staticFiles[staticFileId] = filepath
+	return network
+}
+
+func (network *FakeNetworkContext) AddService(
+	serviceId services.ServiceID,
+	containerCreationConfig *services.ContainerCreationConfig,
+	generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	// The real network context's default partition is also the empty string
+	serviceCtx, hostPortBindings, err := network.AddServiceToPartition(serviceId, "", containerCreationConfig, generateRunConfigFunc)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to the fake network in the default partition", serviceId)
+	}
+	return serviceCtx, hostPortBindings, nil
+}
+
+func (network *FakeNetworkContext) AddServiceToPartition(
+	serviceId services.ServiceID,
+	partitionId networks.PartitionID,
+	containerCreationConfig *services.ContainerCreationConfig,
+	generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	if _, found := network.services[serviceId]; found {
+		return nil, nil, stacktrace.NewError("Service ID '%v' is already in use in the fake network", serviceId)
+	}
+	ipAddr, err := network.getNextFakeIp()
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred getting a fake IP for service '%v'", serviceId)
+	}
+
+	generatedFileFilepaths, err := network.generateFiles(serviceId, containerCreationConfig.GetFileGeneratingFuncs())
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred generating the files for service '%v'", serviceId)
+	}
+	staticFileFilepaths := map[services.StaticFileID]string{}
+	for staticFileId := range containerCreationConfig.GetUsedStaticFiles() {
+		filepath, found := network.staticFiles[staticFileId]
+		if !found {
+			return nil, nil, stacktrace.NewError("Service '%v' uses static file '%v', but no such static file was given to the fake network", serviceId, staticFileId)
+		}
+		staticFileFilepaths[staticFileId] = filepath
+	}
+	runConfig, err := generateRunConfigFunc(ipAddr, generatedFileFilepaths, staticFileFilepaths)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred generating the run config for service '%v'", serviceId)
+	}
+
+	hostPortBindings := map[string]*kurtosis_core_rpc_api_bindings.PortBinding{}
+	for portSpec := range containerCreationConfig.GetUsedPortsSet() {
+		portNum := strings.Split(portSpec, "/")[0]
+		hostPortBindings[portSpec] = &kurtosis_core_rpc_api_bindings.PortBinding{
+			InterfaceIp:   ipAddr,
+			InterfacePort: portNum,
+		}
+	}
+
+	var standIn StandIn
+	if factory, found := network.standInFactories[containerCreationConfig.GetImage()]; found {
+		standIn = factory(serviceId)
+		if err := standIn.Start(ipAddr, generatedFileFilepaths, runConfig); err != nil {
+			return nil, nil, stacktrace.Propagate(err, "An error occurred starting the stand-in for service '%v'", serviceId)
+		}
+	}
+
+	serviceCtx := services.NewServiceContext(
+		&fakeApiContainerClient{network: network},
+		serviceId,
+		ipAddr,
+		network.filesDirpath,
+		containerCreationConfig.GetTestVolumeMountpoint(),
+	)
+	network.services[serviceId] = &fakeService{
+		serviceCtx:  serviceCtx,
+		partitionId: partitionId,
+		standIn:     standIn,
+	}
+	network.addServiceCalls = append(network.addServiceCalls, &AddServiceCall{
+		ServiceId:               serviceId,
+		PartitionId:             partitionId,
+		IpAddr:                  ipAddr,
+		ContainerCreationConfig: containerCreationConfig,
+		GeneratedFileFilepaths:  generatedFileFilepaths,
+		RunConfig:               runConfig,
+	})
+	return serviceCtx, hostPortBindings, nil
+}
+
+func (network *FakeNetworkContext) GetServiceContext(serviceId services.ServiceID) (*services.ServiceContext, error) {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	service, found := network.services[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service with ID '%v' exists in the fake network", serviceId)
+	}
+	return service.serviceCtx, nil
+}
+
+func (network *FakeNetworkContext) RemoveService(serviceId services.ServiceID, containerStopTimeoutSeconds uint64) error {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	service, found := network.services[serviceId]
+	if !found {
+		return stacktrace.NewError("Can't remove service '%v' because it doesn't exist in the fake network", serviceId)
+	}
+	if service.standIn != nil {
+		if err := service.standIn.Stop(); err != nil {
+			return stacktrace.Propagate(err, "An error occurred stopping the stand-in for service '%v'", serviceId)
+		}
+	}
+	delete(network.services, serviceId)
+	network.removedServiceIds = append(network.removedServiceIds, serviceId)
+	return nil
+}
+
+func (network *FakeNetworkContext) RepartitionNetwork(
+	partitionServices map[networks.PartitionID]map[services.ServiceID]bool,
+	partitionConnections map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+	defaultConnection *kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+) error {
+	if partitionServices == nil {
+		return stacktrace.NewError("Partition services map cannot be nil")
+	}
+	if defaultConnection == nil {
+		return stacktrace.NewError("Default connection cannot be nil")
+	}
+
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	servicePartitions := map[services.ServiceID]networks.PartitionID{}
+	for partitionId, serviceIds := range partitionServices {
+		for serviceId := range serviceIds {
+			if _, found := network.services[serviceId]; !found {
+				return stacktrace.NewError("Partition '%v' contains service '%v', which doesn't exist in the fake network", partitionId, serviceId)
+			}
+			if otherPartitionId, found := servicePartitions[serviceId]; found {
+				return stacktrace.NewError("Service '%v' is in both partition '%v' and partition '%v'", serviceId, otherPartitionId, partitionId)
+			}
+			servicePartitions[serviceId] = partitionId
+		}
+	}
+	for serviceId := range network.services {
+		if _, found := servicePartitions[serviceId]; !found {
+			return stacktrace.NewError("Service '%v' wasn't assigned to a partition", serviceId)
+		}
+	}
+
+	for serviceId, partitionId := range servicePartitions {
+		network.services[serviceId].partitionId = partitionId
+	}
+	network.repartitionCalls = append(network.repartitionCalls, &RepartitionCall{
+		PartitionServices:    partitionServices,
+		PartitionConnections: partitionConnections,
+		DefaultConnection:    defaultConnection,
+	})
+	return nil
+}
+
+func (network *FakeNetworkContext) WaitForEndpointAvailability(
+	serviceId services.ServiceID,
+	port uint32,
+	path string,
+	initialDelaySeconds uint32,
+	retries uint32,
+	retriesDelayMilliseconds uint32,
+	bodyText string,
+) error {
+	serviceCtx, err := network.GetServiceContext(serviceId)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the context of service '%v'", serviceId)
+	}
+	url := fmt.Sprintf("http://%v:%v/%v", serviceCtx.GetIPAddress(), port, strings.TrimPrefix(path, "/"))
+	httpClient := http.Client{Timeout: endpointAvailabilityTimeout}
+
+	time.Sleep(time.Duration(initialDelaySeconds) * time.Second)
+	var lastErr error
+	for i := uint32(0); i < retries; i++ {
+		if lastErr = checkEndpoint(httpClient, url, bodyText); lastErr == nil {
+			return nil
+		}
+		time.Sleep(time.Duration(retriesDelayMilliseconds) * time.Millisecond)
+	}
+	return stacktrace.Propagate(
+		lastErr,
+		"Endpoint '%v' on port '%v' for service '%v' did not become available despite polling %v times with %v between polls",
+		path,
+		port,
+		serviceId,
+		retries,
+		retriesDelayMilliseconds,
+	)
+}
+
+// Stops all the stand-ins and removes the fake network's temp directory
+func (network *FakeNetworkContext) Destroy() error {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+
+	stopErrStrs := []string{}
+	for serviceId, service := range network.services {
+		if service.standIn == nil {
+			continue
+		}
+		if err := service.standIn.Stop(); err != nil {
+			stopErrStrs = append(stopErrStrs, fmt.Sprintf("Service '%v': %v", serviceId, err))
+		}
+	}
+	network.services = map[services.ServiceID]*fakeService{}
+
+	if err := os.RemoveAll(network.filesDirpath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing the fake network's files directory '%v'", network.filesDirpath)
+	}
+	if len(stopErrStrs) > 0 {
+		return stacktrace.NewError("Errors occurred stopping the fake network's stand-ins:\n%v", strings.Join(stopErrStrs, "\n"))
+	}
+	return nil
+}
+
+// Gets every AddService/AddServiceToPartition call that succeeded, in order
+func (network *FakeNetworkContext) GetAddServiceCalls() []*AddServiceCall {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	return append([]*AddServiceCall{}, network.addServiceCalls...)
+}
+
+// Gets the IDs of every service removed so far, in order
+func (network *FakeNetworkContext) GetRemovedServiceIds() []services.ServiceID {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	return append([]services.ServiceID{}, network.removedServiceIds...)
+}
+
+// Gets every RepartitionNetwork call that succeeded, in order
+func (network *FakeNetworkContext) GetRepartitionCalls() []*RepartitionCall {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	return append([]*RepartitionCall{}, network.repartitionCalls...)
+}
+
+// Gets the IDs of the services currently in the network, sorted
+func (network *FakeNetworkContext) GetServiceIds() []services.ServiceID {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	result := []services.ServiceID{}
+	for serviceId := range network.services {
+		result = append(result, serviceId)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+func (network *FakeNetworkContext) GetPartitionId(serviceId services.ServiceID) (networks.PartitionID, error) {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	service, found := network.services[serviceId]
+	if !found {
+		return "", stacktrace.NewError("No service with ID '%v' exists in the fake network", serviceId)
+	}
+	return service.partitionId, nil
+}
+
+// Gets the stand-in backing the given service, or nil if the service's image has no stand-in
+func (network *FakeNetworkContext) GetStandIn(serviceId services.ServiceID) (StandIn, error) {
+	network.mutex.Lock()
+	defer network.mutex.Unlock()
+	service, found := network.services[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service with ID '%v' exists in the fake network", serviceId)
+	}
+	return service.standIn, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// NOTE: Must be called with the mutex held
+func (network *FakeNetworkContext) getNextFakeIp() (string, error) {
+	suffix := network.nextFakeIpSuffix
+	if suffix > maxFakeIpSuffix {
+		return "", stacktrace.NewError("The fake network has run out of IPs")
+	}
+	network.nextFakeIpSuffix++
+	return net.IPv4(127, byte(suffix>>16), byte(suffix>>8), byte(suffix)).String(), nil
+}
+
+// NOTE: Must be called with the mutex held
+func (network *FakeNetworkContext) generateFiles(
+	serviceId services.ServiceID,
+	fileGeneratingFuncs map[string]func(*os.File) error,
+) (map[string]string, error) {
+	serviceFilesDirpath := path.Join(network.filesDirpath, generatedFilesDirname, string(serviceId))
+	if err := os.MkdirAll(serviceFilesDirpath, os.ModePerm); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating directory '%v' for the generated files", serviceFilesDirpath)
+	}
+
+	result := map[string]string{}
+	for fileKey, generateFile := range fileGeneratingFuncs {
+		filepath := path.Join(serviceFilesDirpath, fileKey)
+		fp, err := os.Create(filepath)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred creating generated file '%v' at '%v'", fileKey, filepath)
+		}
+		generateErr := generateFile(fp)
+		if err := fp.Close(); err != nil {
+			logrus.Warnf("An error occurred closing generated file '%v': %v", filepath, err)
+		}
+		if generateErr != nil {
+			return nil, stacktrace.Propagate(generateErr, "An error occurred generating file '%v'", fileKey)
+		}
+		result[fileKey] = filepath
+	}
+	return result, nil
+}
+
+func checkEndpoint(httpClient http.Client, url string, expectedBodyText string) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the request to '%v'", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return stacktrace.NewError("Expected status code %v from '%v' but got %v", http.StatusOK, url, resp.StatusCode)
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the response body from '%v'", url)
+	}
+	if expectedBodyText != "" && string(bodyBytes) != expectedBodyText {
+		return stacktrace.NewError("Expected body '%v' from '%v' but got '%v'", expectedBodyText, url, string(bodyBytes))
+	}
+	return nil
+}
@@ -0,0 +1,419 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fake_network
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+const (
+	standInImage = "test/stand-in:test"
+	plainImage   = "test/plain:test"
+
+	configFileKey      = "config"
+	configFileContents = "some config"
+
+	testStaticFileId services.StaticFileID = "test-static-file"
+
+	servicePort = "1234/tcp"
+)
+
+func TestAddService_GeneratesFilesAndStartsStandIn(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	standIns := map[services.ServiceID]*recordingStandIn{}
+	network.WithStandIn(standInImage, newRecordingStandInFactory(standIns))
+
+	var runConfigFuncIpAddr string
+	runConfig := services.NewContainerRunConfigBuilder().WithCmdOverride([]string{"--some-flag"}).Build()
+	creationConfig := services.NewContainerCreationConfigBuilder(
+		standInImage,
+	).WithUsedPorts(
+		map[string]bool{servicePort: true},
+	).WithGeneratedFiles(
+		map[string]func(*os.File) error{
+			configFileKey: func(fp *os.File) error {
+				_, err := fp.WriteString(configFileContents)
+				return err
+			},
+		},
+	).Build()
+	_, hostPortBindings, err := network.AddService(
+		"service1",
+		creationConfig,
+		func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+			runConfigFuncIpAddr = ipAddr
+			return runConfig, nil
+		},
+	)
+	require.NoError(t, err)
+
+	addServiceCalls := network.GetAddServiceCalls()
+	require.Len(t, addServiceCalls, 1)
+	call := addServiceCalls[0]
+	require.Equal(t, services.ServiceID("service1"), call.ServiceId)
+	require.Equal(t, networks.PartitionID(""), call.PartitionId)
+	require.Equal(t, "127.0.0.2", call.IpAddr)
+	require.Equal(t, call.IpAddr, runConfigFuncIpAddr)
+	require.Equal(t, runConfig, call.RunConfig)
+
+	generatedFilepath, found := call.GeneratedFileFilepaths[configFileKey]
+	require.True(t, found)
+	generatedFileBytes, err := ioutil.ReadFile(generatedFilepath)
+	require.NoError(t, err)
+	require.Equal(t, configFileContents, string(generatedFileBytes))
+
+	require.Equal(
+		t,
+		map[string]*kurtosis_core_rpc_api_bindings.PortBinding{
+			servicePort: {InterfaceIp: call.IpAddr, InterfacePort: "1234"},
+		},
+		hostPortBindings,
+	)
+
+	standIn, found := standIns["service1"]
+	require.True(t, found)
+	require.Equal(t, call.IpAddr, standIn.startedIpAddr)
+	require.Equal(t, call.GeneratedFileFilepaths, standIn.startedGeneratedFileFilepaths)
+	require.Equal(t, runConfig, standIn.startedRunConfig)
+
+	gotStandIn, err := network.GetStandIn("service1")
+	require.NoError(t, err)
+	require.Equal(t, standIn, gotStandIn)
+}
+
+func TestAddService_ServicesWithoutStandInGetNone(t *testing.T) {
+	network := createFakeNetworkContext(t)
+
+	addPlainService(t, network, "service1")
+
+	standIn, err := network.GetStandIn("service1")
+	require.NoError(t, err)
+	require.Nil(t, standIn)
+}
+
+func TestAddService_HandsOutDistinctIps(t *testing.T) {
+	network := createFakeNetworkContext(t)
+
+	addPlainService(t, network, "service1")
+	addPlainService(t, network, "service2")
+
+	service1Ctx, err := network.GetServiceContext("service1")
+	require.NoError(t, err)
+	service2Ctx, err := network.GetServiceContext("service2")
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.2", service1Ctx.GetIPAddress())
+	require.Equal(t, "127.0.0.3", service2Ctx.GetIPAddress())
+}
+
+func TestAddService_ErrorsOnDuplicateServiceId(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	addPlainService(t, network, "service1")
+
+	_, _, err := network.AddService(
+		"service1",
+		services.NewContainerCreationConfigBuilder(plainImage).Build(),
+		emptyRunConfigFunc,
+	)
+	require.Error(t, err)
+	require.Len(t, network.GetAddServiceCalls(), 1)
+}
+
+func TestAddService_PassesStaticFiles(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	network.WithStaticFile(testStaticFileId, "/some/static/file")
+
+	var gotStaticFileFilepaths map[services.StaticFileID]string
+	_, _, err := network.AddService(
+		"service1",
+		services.NewContainerCreationConfigBuilder(
+			plainImage,
+		).WithStaticFiles(
+			map[services.StaticFileID]bool{testStaticFileId: true},
+		).Build(),
+		func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+			gotStaticFileFilepaths = staticFileFilepaths
+			return services.NewContainerRunConfigBuilder().Build(), nil
+		},
+	)
+	require.NoError(t, err)
+	require.Equal(t, map[services.StaticFileID]string{testStaticFileId: "/some/static/file"}, gotStaticFileFilepaths)
+}
+
+func TestAddService_ErrorsOnMissingStaticFile(t *testing.T) {
+	network := createFakeNetworkContext(t)
+
+	_, _, err := network.AddService(
+		"service1",
+		services.NewContainerCreationConfigBuilder(
+			plainImage,
+		).WithStaticFiles(
+			map[services.StaticFileID]bool{testStaticFileId: true},
+		).Build(),
+		emptyRunConfigFunc,
+	)
+	require.Error(t, err)
+	require.Empty(t, network.GetServiceIds())
+}
+
+func TestAddService_ErrorsWhenStandInFailsToStart(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	network.WithStandIn(standInImage, func(serviceId services.ServiceID) StandIn {
+		return &recordingStandIn{startErr: stacktrace.NewError("Test start error")}
+	})
+
+	_, _, err := network.AddService(
+		"service1",
+		services.NewContainerCreationConfigBuilder(standInImage).Build(),
+		emptyRunConfigFunc,
+	)
+	require.Error(t, err)
+	require.Empty(t, network.GetServiceIds())
+}
+
+func TestRemoveService_StopsStandInAndRecordsRemoval(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	standIns := map[services.ServiceID]*recordingStandIn{}
+	network.WithStandIn(standInImage, newRecordingStandInFactory(standIns))
+	_, _, err := network.AddService("service1", services.NewContainerCreationConfigBuilder(standInImage).Build(), emptyRunConfigFunc)
+	require.NoError(t, err)
+	addPlainService(t, network, "service2")
+
+	require.NoError(t, network.RemoveService("service1", 0))
+
+	require.True(t, standIns["service1"].stopped)
+	require.Equal(t, []services.ServiceID{"service1"}, network.GetRemovedServiceIds())
+	require.Equal(t, []services.ServiceID{"service2"}, network.GetServiceIds())
+	_, err = network.GetServiceContext("service1")
+	require.Error(t, err)
+}
+
+func TestRemoveService_ErrorsOnUnknownService(t *testing.T) {
+	network := createFakeNetworkContext(t)
+
+	require.Error(t, network.RemoveService("service1", 0))
+	require.Empty(t, network.GetRemovedServiceIds())
+}
+
+func TestRepartitionNetwork_Validation(t *testing.T) {
+	defaultConnection := &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{IsBlocked: false}
+	testCases := []struct {
+		name              string
+		partitionServices map[networks.PartitionID]map[services.ServiceID]bool
+		defaultConnection *kurtosis_core_rpc_api_bindings.PartitionConnectionInfo
+		expectErr         bool
+	}{
+		{
+			name: "valid",
+			partitionServices: map[networks.PartitionID]map[services.ServiceID]bool{
+				"partition1": {"service1": true},
+				"partition2": {"service2": true},
+			},
+			defaultConnection: defaultConnection,
+			expectErr:         false,
+		},
+		{
+			name:              "nil partition services",
+			partitionServices: nil,
+			defaultConnection: defaultConnection,
+			expectErr:         true,
+		},
+		{
+			name: "nil default connection",
+			partitionServices: map[networks.PartitionID]map[services.ServiceID]bool{
+				"partition1": {"service1": true, "service2": true},
+			},
+			defaultConnection: nil,
+			expectErr:         true,
+		},
+		{
+			name: "unknown service",
+			partitionServices: map[networks.PartitionID]map[services.ServiceID]bool{
+				"partition1": {"service1": true, "service2": true, "service3": true},
+			},
+			defaultConnection: defaultConnection,
+			expectErr:         true,
+		},
+		{
+			name: "service in two partitions",
+			partitionServices: map[networks.PartitionID]map[services.ServiceID]bool{
+				"partition1": {"service1": true, "service2": true},
+				"partition2": {"service2": true},
+			},
+			defaultConnection: defaultConnection,
+			expectErr:         true,
+		},
+		{
+			name: "unassigned service",
+			partitionServices: map[networks.PartitionID]map[services.ServiceID]bool{
+				"partition1": {"service1": true},
+			},
+			defaultConnection: defaultConnection,
+			expectErr:         true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			network := createFakeNetworkContext(t)
+			addPlainService(t, network, "service1")
+			addPlainService(t, network, "service2")
+
+			err := network.RepartitionNetwork(testCase.partitionServices, nil, testCase.defaultConnection)
+			if testCase.expectErr {
+				require.Error(t, err)
+				require.Empty(t, network.GetRepartitionCalls())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, network.GetRepartitionCalls(), 1)
+		})
+	}
+}
+
+func TestRepartitionNetwork_RecordsPartitions(t *testing.T) {
+	network := createFakeNetworkContext(t)
+	addPlainService(t, network, "service1")
+	_, _, err := network.AddServiceToPartition(
+		"service2",
+		"partition1",
+		services.NewContainerCreationConfigBuilder(plainImage).Build(),
+		emptyRunConfigFunc,
+	)
+	require.NoError(t, err)
+
+	partitionId, err := network.GetPartitionId("service2")
+	require.NoError(t, err)
+	require.Equal(t, networks.PartitionID("partition1"), partitionId)
+
+	partitionServices := map[networks.PartitionID]map[services.ServiceID]bool{
+		"partition1": {"service1": true},
+		"partition2": {"service2": true},
+	}
+	partitionConnections := map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{
+		"partition1": {
+			"partition2": {IsBlocked: true},
+		},
+	}
+	defaultConnection := &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{IsBlocked: false}
+	require.NoError(t, network.RepartitionNetwork(partitionServices, partitionConnections, defaultConnection))
+
+	service1PartitionId, err := network.GetPartitionId("service1")
+	require.NoError(t, err)
+	require.Equal(t, networks.PartitionID("partition1"), service1PartitionId)
+	service2PartitionId, err := network.GetPartitionId("service2")
+	require.NoError(t, err)
+	require.Equal(t, networks.PartitionID("partition2"), service2PartitionId)
+
+	require.Equal(
+		t,
+		[]*RepartitionCall{
+			{
+				PartitionServices:    partitionServices,
+				PartitionConnections: partitionConnections,
+				DefaultConnection:    defaultConnection,
+			},
+		},
+		network.GetRepartitionCalls(),
+	)
+}
+
+func TestGetPartitionId_ErrorsOnUnknownService(t *testing.T) {
+	network := createFakeNetworkContext(t)
+
+	_, err := network.GetPartitionId("service1")
+	require.Error(t, err)
+}
+
+func TestDestroy_StopsStandInsAndRemovesFiles(t *testing.T) {
+	network, err := NewFakeNetworkContext()
+	require.NoError(t, err)
+	standIns := map[services.ServiceID]*recordingStandIn{}
+	network.WithStandIn(standInImage, newRecordingStandInFactory(standIns))
+	_, _, err = network.AddService("service1", services.NewContainerCreationConfigBuilder(standInImage).Build(), emptyRunConfigFunc)
+	require.NoError(t, err)
+	_, _, err = network.AddService("service2", services.NewContainerCreationConfigBuilder(standInImage).Build(), emptyRunConfigFunc)
+	require.NoError(t, err)
+
+	require.NoError(t, network.Destroy())
+
+	require.True(t, standIns["service1"].stopped)
+	require.True(t, standIns["service2"].stopped)
+	require.Empty(t, network.GetServiceIds())
+	_, err = os.Stat(network.filesDirpath)
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDestroy_ReportsStandInStopErrors(t *testing.T) {
+	network, err := NewFakeNetworkContext()
+	require.NoError(t, err)
+	network.WithStandIn(standInImage, func(serviceId services.ServiceID) StandIn {
+		return &recordingStandIn{stopErr: stacktrace.NewError("Test stop error")}
+	})
+	_, _, err = network.AddService("service1", services.NewContainerCreationConfigBuilder(standInImage).Build(), emptyRunConfigFunc)
+	require.NoError(t, err)
+
+	require.Error(t, network.Destroy())
+	_, err = os.Stat(network.filesDirpath)
+	require.True(t, os.IsNotExist(err))
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type recordingStandIn struct {
+	startErr error
+	stopErr  error
+
+	startedIpAddr                 string
+	startedGeneratedFileFilepaths map[string]string
+	startedRunConfig              *services.ContainerRunConfig
+	stopped                       bool
+}
+
+func (standIn *recordingStandIn) Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error {
+	standIn.startedIpAddr = ipAddr
+	standIn.startedGeneratedFileFilepaths = generatedFileFilepaths
+	standIn.startedRunConfig = runConfig
+	return standIn.startErr
+}
+
+func (standIn *recordingStandIn) Stop() error {
+	standIn.stopped = true
+	return standIn.stopErr
+}
+
+// Records every stand-in it creates in the given map, keyed by service ID
+func newRecordingStandInFactory(standIns map[services.ServiceID]*recordingStandIn) StandInFactory {
+	return func(serviceId services.ServiceID) StandIn {
+		standIn := &recordingStandIn{}
+		standIns[serviceId] = standIn
+		return standIn
+	}
+}
+
+func createFakeNetworkContext(t *testing.T) *FakeNetworkContext {
+	network, err := NewFakeNetworkContext()
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, network.Destroy())
+	})
+	return network
+}
+
+func addPlainService(t *testing.T, network *FakeNetworkContext, serviceId services.ServiceID) {
+	_, _, err := network.AddService(serviceId, services.NewContainerCreationConfigBuilder(plainImage).Build(), emptyRunConfigFunc)
+	require.NoError(t, err)
+}
+
+func emptyRunConfigFunc(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+	return services.NewContainerRunConfigBuilder().Build(), nil
+}
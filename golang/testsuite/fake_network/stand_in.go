@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fake_network
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+)
+
+/*
+An in-process stand-in for the container that a real network would start for a service
+
+A stand-in is started once the service's files have been generated and its run config built, and should serve on
+ the service's fake IP (a loopback address, so the regular service clients can reach it unchanged).
+*/
+type StandIn interface {
+	Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error
+
+	Stop() error
+}
+
+// Stand-ins that also implement this can serve ServiceContext.ExecCommand calls
+type CommandExecutor interface {
+	ExecCommand(command []string) (exitCode int32, logOutput []byte, err error)
+}
+
+// Creates the stand-in for a newly-added service
+type StandInFactory func(serviceId services.ServiceID) StandIn
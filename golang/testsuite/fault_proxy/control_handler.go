@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy
+
+import (
+	"encoding/json"
+	"github.com/sirupsen/logrus"
+	"net/http"
+)
+
+const (
+	healthUrlPath           = "/health"
+	faultsUrlPath           = "/faults"
+	resetConnectionsUrlPath = "/reset-connections"
+
+	healthyValue = "healthy"
+)
+
+// The response body of the reset-connections endpoint
+type resetConnectionsResponse struct {
+	NumReset int `json:"numReset"`
+}
+
+/*
+Creates the HTTP API that FaultProxyClient talks to:
+ GET /health -> "healthy"
+ GET /faults -> the current faults, as JSON
+ PUT /faults -> replaces the current faults with the JSON body
+ DELETE /faults -> stops injecting faults
+ POST /reset-connections -> resets every open connection
+*/
+func NewControlHandler(proxy *FaultProxy) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthUrlPath, func(writer http.ResponseWriter, request *http.Request) {
+		writer.Write([]byte(healthyValue))
+	})
+	mux.HandleFunc(faultsUrlPath, func(writer http.ResponseWriter, request *http.Request) {
+		switch request.Method {
+		case http.MethodGet:
+			writeJson(writer, proxy.GetFaults())
+		case http.MethodPut:
+			var faults Faults
+			if err := json.NewDecoder(request.Body).Decode(&faults); err != nil {
+				http.Error(writer, "Couldn't deserialize the faults JSON: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := proxy.SetFaults(faults); err != nil {
+				http.Error(writer, err.Error(), http.StatusBadRequest)
+				return
+			}
+			writeJson(writer, faults)
+		case http.MethodDelete:
+			if err := proxy.SetFaults(Faults{}); err != nil {
+				http.Error(writer, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			writeJson(writer, Faults{})
+		default:
+			http.Error(writer, "Unsupported method "+request.Method, http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc(resetConnectionsUrlPath, func(writer http.ResponseWriter, request *http.Request) {
+		if request.Method != http.MethodPost {
+			http.Error(writer, "Unsupported method "+request.Method, http.StatusMethodNotAllowed)
+			return
+		}
+		writeJson(writer, resetConnectionsResponse{NumReset: proxy.ResetConnections()})
+	})
+	return mux
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func writeJson(writer http.ResponseWriter, obj interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(writer).Encode(obj); err != nil {
+		logrus.Warnf("An error occurred writing the JSON response: %v", err)
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io"
+	"math/rand"
+	"net"
+	"sync"
+	"time"
+)
+
+const (
+	maxChunkSizeBytes = 32 * 1024
+
+	// When throttling, chunks are sized so that the bandwidth limit is enforced at roughly this granularity
+	throttleIntervalsPerSecond = 10
+)
+
+/*
+A TCP proxy that forwards connections to a single target while injecting whatever faults it's currently configured
+ with. Faults can be changed at any time and apply immediately to both new and existing connections.
+
+It runs in-process for local testing, or inside a service (see the fault_proxy_server binary) where it's controlled
+ over HTTP via FaultProxyClient.
+*/
+type FaultProxy struct {
+	targetAddr string
+
+	mutex    *sync.Mutex
+	listener net.Listener
+	faults   Faults
+	random   *rand.Rand
+
+	// "Set" of the client-side connections currently being proxied
+	activeConns map[*net.TCPConn]bool
+}
+
+func NewFaultProxy(targetAddr string) *FaultProxy {
+	return &FaultProxy{
+		targetAddr:  targetAddr,
+		mutex:       &sync.Mutex{},
+		listener:    nil,
+		faults:      Faults{},
+		random:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		activeConns: map[*net.TCPConn]bool{},
+	}
+}
+
+// Starts accepting connections on the given address (e.g. ":9000", or "127.0.0.1:0" for a random local port)
+func (proxy *FaultProxy) Start(listenAddr string) error {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	if proxy.listener != nil {
+		return stacktrace.NewError("The proxy has already been started")
+	}
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred listening on '%v'", listenAddr)
+	}
+	proxy.listener = listener
+	go proxy.acceptConnections(listener)
+	logrus.Infof("Proxying '%v' -> '%v'", listener.Addr(), proxy.targetAddr)
+	return nil
+}
+
+// Gets the address the proxy is actually listening on, which is useful when started on port 0
+func (proxy *FaultProxy) GetListenAddr() (string, error) {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	if proxy.listener == nil {
+		return "", stacktrace.NewError("The proxy hasn't been started")
+	}
+	return proxy.listener.Addr().String(), nil
+}
+
+func (proxy *FaultProxy) SetFaults(faults Faults) error {
+	if err := faults.validate(); err != nil {
+		return stacktrace.Propagate(err, "Invalid faults")
+	}
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	proxy.faults = faults
+	logrus.Infof("Now injecting faults: %+v", faults)
+	return nil
+}
+
+func (proxy *FaultProxy) GetFaults() Faults {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	return proxy.faults
+}
+
+// Abruptly resets (rather than gracefully closes) every connection currently being proxied, returning how many were reset
+func (proxy *FaultProxy) ResetConnections() int {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	numReset := 0
+	for conn := range proxy.activeConns {
+		// A linger of 0 makes closing send a RST rather than a FIN
+		if err := conn.SetLinger(0); err != nil {
+			logrus.Warnf("An error occurred setting linger on connection from '%v'; it will be closed gracefully instead: %v", conn.RemoteAddr(), err)
+		}
+		if err := conn.Close(); err != nil {
+			logrus.Warnf("An error occurred resetting connection from '%v': %v", conn.RemoteAddr(), err)
+			continue
+		}
+		numReset++
+	}
+	logrus.Infof("Reset %v connection(s)", numReset)
+	return numReset
+}
+
+// Stops accepting connections and resets all existing ones; the proxy can then be started again
+func (proxy *FaultProxy) Stop() error {
+	proxy.mutex.Lock()
+	listener := proxy.listener
+	proxy.listener = nil
+	proxy.mutex.Unlock()
+	if listener == nil {
+		return stacktrace.NewError("The proxy hasn't been started")
+	}
+	if err := listener.Close(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred closing the proxy's listener")
+	}
+	proxy.ResetConnections()
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (proxy *FaultProxy) acceptConnections(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			// Happens when the listener is closed by Stop
+			logrus.Debugf("Stopped accepting connections: %v", err)
+			return
+		}
+		go proxy.handleConnection(conn.(*net.TCPConn))
+	}
+}
+
+func (proxy *FaultProxy) handleConnection(clientConn *net.TCPConn) {
+	if proxy.shouldDrop() {
+		logrus.Debugf("Dropping connection from '%v'", clientConn.RemoteAddr())
+		clientConn.Close()
+		return
+	}
+
+	targetConn, err := net.Dial("tcp", proxy.targetAddr)
+	if err != nil {
+		logrus.Warnf("An error occurred connecting to target '%v'; closing connection from '%v': %v", proxy.targetAddr, clientConn.RemoteAddr(), err)
+		clientConn.Close()
+		return
+	}
+
+	proxy.mutex.Lock()
+	proxy.activeConns[clientConn] = true
+	proxy.mutex.Unlock()
+
+	doneChan := make(chan struct{}, 2)
+	go proxy.forward(clientConn, targetConn, doneChan)
+	go proxy.forward(targetConn, clientConn, doneChan)
+
+	// Once either direction finishes, tear down both so the other direction doesn't hang
+	<-doneChan
+	clientConn.Close()
+	targetConn.Close()
+	<-doneChan
+
+	proxy.mutex.Lock()
+	delete(proxy.activeConns, clientConn)
+	proxy.mutex.Unlock()
+}
+
+func (proxy *FaultProxy) forward(src net.Conn, dest net.Conn, doneChan chan struct{}) {
+	defer func() { doneChan <- struct{}{} }()
+	buffer := make([]byte, maxChunkSizeBytes)
+	for {
+		chunkSize := getChunkSize(proxy.GetFaults())
+		numBytesRead, readErr := src.Read(buffer[:chunkSize])
+
+		// Faults are re-read after every (possibly long-blocking) read, so changes apply to connections that are
+		//  already open
+		faults := proxy.GetFaults()
+		if numBytesRead > 0 && !faults.Blackhole {
+			if faults.LatencyMillis > 0 {
+				time.Sleep(time.Duration(faults.LatencyMillis) * time.Millisecond)
+			}
+			if faults.BandwidthBytesPerSecond > 0 {
+				time.Sleep(time.Duration(numBytesRead) * time.Second / time.Duration(faults.BandwidthBytesPerSecond))
+			}
+			if _, err := dest.Write(buffer[:numBytesRead]); err != nil {
+				logrus.Debugf("Stopped forwarding '%v' -> '%v' due to a write error: %v", src.RemoteAddr(), dest.RemoteAddr(), err)
+				return
+			}
+		}
+		if readErr != nil {
+			if readErr != io.EOF {
+				logrus.Debugf("Stopped forwarding '%v' -> '%v' due to a read error: %v", src.RemoteAddr(), dest.RemoteAddr(), readErr)
+			}
+			return
+		}
+	}
+}
+
+func getChunkSize(faults Faults) int {
+	if faults.BandwidthBytesPerSecond == 0 {
+		return maxChunkSizeBytes
+	}
+	result := faults.BandwidthBytesPerSecond / throttleIntervalsPerSecond
+	if result < 1 {
+		return 1
+	}
+	if result > maxChunkSizeBytes {
+		return maxChunkSizeBytes
+	}
+	return result
+}
+
+func (proxy *FaultProxy) shouldDrop() bool {
+	proxy.mutex.Lock()
+	defer proxy.mutex.Unlock()
+	return proxy.faults.DropProbability > 0 && proxy.random.Float64() < proxy.faults.DropProbability
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	requestTimeout = 2 * time.Second
+)
+
+// Controls a fault proxy over its HTTP API (see NewControlHandler), e.g. from a test's Run
+type FaultProxyClient struct {
+	ipAddr     string
+	port       int
+	httpClient http.Client
+}
+
+// The client's requests go through the given transport, or http.DefaultTransport if nil
+func NewFaultProxyClient(ipAddr string, port int, transport http.RoundTripper) *FaultProxyClient {
+	return &FaultProxyClient{
+		ipAddr: ipAddr,
+		port:   port,
+		httpClient: http.Client{
+			Transport: transport,
+			Timeout:   requestTimeout,
+		},
+	}
+}
+
+func (client *FaultProxyClient) SetFaults(faults Faults) error {
+	faultsBytes, err := json.Marshal(faults)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred serializing faults '%+v'", faults)
+	}
+	if _, err := client.makeRequest(http.MethodPut, faultsUrlPath, faultsBytes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting faults '%+v'", faults)
+	}
+	return nil
+}
+
+func (client *FaultProxyClient) GetFaults() (Faults, error) {
+	respBytes, err := client.makeRequest(http.MethodGet, faultsUrlPath, nil)
+	if err != nil {
+		return Faults{}, stacktrace.Propagate(err, "An error occurred getting the faults")
+	}
+	var faults Faults
+	if err := json.Unmarshal(respBytes, &faults); err != nil {
+		return Faults{}, stacktrace.Propagate(err, "An error occurred deserializing the faults")
+	}
+	return faults, nil
+}
+
+func (client *FaultProxyClient) ClearFaults() error {
+	if _, err := client.makeRequest(http.MethodDelete, faultsUrlPath, nil); err != nil {
+		return stacktrace.Propagate(err, "An error occurred clearing the faults")
+	}
+	return nil
+}
+
+// Resets every connection the proxy currently has open, returning how many were reset
+func (client *FaultProxyClient) ResetConnections() (int, error) {
+	respBytes, err := client.makeRequest(http.MethodPost, resetConnectionsUrlPath, nil)
+	if err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred resetting the proxy's connections")
+	}
+	var resp resetConnectionsResponse
+	if err := json.Unmarshal(respBytes, &resp); err != nil {
+		return 0, stacktrace.Propagate(err, "An error occurred deserializing the reset connections response")
+	}
+	return resp.NumReset, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (client *FaultProxyClient) makeRequest(method string, urlPath string, body []byte) ([]byte, error) {
+	url := fmt.Sprintf("http://%v:%v%v", client.ipAddr, client.port, urlPath)
+	request, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred building the %v request to '%v'", method, url)
+	}
+	resp, err := client.httpClient.Do(request)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred making the %v request to '%v'", method, url)
+	}
+	defer resp.Body.Close()
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the response body from '%v'", url)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, stacktrace.NewError("%v request to '%v' returned non-OK status code %v: %v", method, url, resp.StatusCode, string(respBytes))
+	}
+	return respBytes, nil
+}
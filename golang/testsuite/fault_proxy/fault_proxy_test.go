@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy
+
+import (
+	"github.com/stretchr/testify/require"
+	"io"
+	"net"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const (
+	localListenAddr = "127.0.0.1:0"
+
+	testMessage = "hello, proxy"
+
+	// Long enough that a proxy forwarding traffic never hits it
+	readTimeout = 2 * time.Second
+
+	// Short, as a blackholed proxy always hits it
+	blackholeReadTimeout = 300 * time.Millisecond
+
+	testLatencyMillis = 200
+)
+
+func TestFaultProxy_ForwardsTrafficUntouchedByDefault(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+
+	conn := dialProxy(t, proxy)
+	require.Equal(t, testMessage, roundTrip(t, conn, readTimeout))
+}
+
+func TestFaultProxy_AddsLatency(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+	require.NoError(t, proxy.SetFaults(Faults{LatencyMillis: testLatencyMillis}))
+
+	conn := dialProxy(t, proxy)
+	startTime := time.Now()
+	require.Equal(t, testMessage, roundTrip(t, conn, readTimeout))
+	// The latency is added in each direction
+	require.True(t, time.Since(startTime) >= 2*testLatencyMillis*time.Millisecond)
+}
+
+func TestFaultProxy_BlackholeAppliesToOpenConnections(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+	conn := dialProxy(t, proxy)
+	require.Equal(t, testMessage, roundTrip(t, conn, readTimeout))
+
+	require.NoError(t, proxy.SetFaults(Faults{Blackhole: true}))
+	_, err := conn.Write([]byte(testMessage))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(blackholeReadTimeout)))
+	_, err = conn.Read(make([]byte, len(testMessage)))
+	netErr, ok := err.(net.Error)
+	require.True(t, ok && netErr.Timeout(), "Expected the read to time out, but got: %v", err)
+}
+
+func TestFaultProxy_ResetConnections(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+	conn := dialProxy(t, proxy)
+	require.Equal(t, testMessage, roundTrip(t, conn, readTimeout))
+
+	require.Equal(t, 1, proxy.ResetConnections())
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(readTimeout)))
+	_, err := conn.Read(make([]byte, len(testMessage)))
+	require.Error(t, err)
+}
+
+func TestFaultProxy_CanBeRestartedAfterStopping(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+
+	require.NoError(t, proxy.Stop())
+	require.Error(t, proxy.Stop())
+	_, err := proxy.GetListenAddr()
+	require.Error(t, err)
+
+	require.NoError(t, proxy.Start(localListenAddr))
+	conn := dialProxy(t, proxy)
+	require.Equal(t, testMessage, roundTrip(t, conn, readTimeout))
+}
+
+func TestFaultProxy_RejectsInvalidFaults(t *testing.T) {
+	invalidFaults := map[string]Faults{
+		"negative latency":          {LatencyMillis: -1},
+		"negative drop probability": {DropProbability: -0.1},
+		"drop probability over 1":   {DropProbability: 1.1},
+		"negative bandwidth":        {BandwidthBytesPerSecond: -1},
+	}
+	proxy := NewFaultProxy(localListenAddr)
+	for name, faults := range invalidFaults {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, proxy.SetFaults(faults))
+		})
+	}
+	require.Equal(t, Faults{}, proxy.GetFaults())
+}
+
+func TestFaultProxyClient_ControlsProxy(t *testing.T) {
+	proxy := startProxyToEchoServer(t)
+	controlServer := httptest.NewServer(NewControlHandler(proxy))
+	defer controlServer.Close()
+	controlHost, controlPortStr, err := net.SplitHostPort(controlServer.Listener.Addr().String())
+	require.NoError(t, err)
+	controlPort, err := strconv.Atoi(controlPortStr)
+	require.NoError(t, err)
+	client := NewFaultProxyClient(controlHost, controlPort, nil)
+
+	faults := Faults{LatencyMillis: testLatencyMillis, BandwidthBytesPerSecond: 1024}
+	require.NoError(t, client.SetFaults(faults))
+	require.Equal(t, faults, proxy.GetFaults())
+	gotFaults, err := client.GetFaults()
+	require.NoError(t, err)
+	require.Equal(t, faults, gotFaults)
+
+	require.Error(t, client.SetFaults(Faults{LatencyMillis: -1}))
+
+	require.NoError(t, client.ClearFaults())
+	require.Equal(t, Faults{}, proxy.GetFaults())
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Starts a proxy in front of a TCP server that writes back whatever it reads; both are stopped when the test ends
+func startProxyToEchoServer(t *testing.T) *FaultProxy {
+	echoListener, err := net.Listen("tcp", localListenAddr)
+	require.NoError(t, err)
+	t.Cleanup(func() { echoListener.Close() })
+	go func() {
+		for {
+			conn, err := echoListener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	proxy := NewFaultProxy(echoListener.Addr().String())
+	require.NoError(t, proxy.Start(localListenAddr))
+	t.Cleanup(func() { proxy.Stop() })
+	return proxy
+}
+
+func dialProxy(t *testing.T, proxy *FaultProxy) net.Conn {
+	listenAddr, err := proxy.GetListenAddr()
+	require.NoError(t, err)
+	conn, err := net.Dial("tcp", listenAddr)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func roundTrip(t *testing.T, conn net.Conn, timeout time.Duration) string {
+	_, err := conn.Write([]byte(testMessage))
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(timeout)))
+	response := make([]byte, len(testMessage))
+	_, err = io.ReadFull(conn, response)
+	require.NoError(t, err)
+	return string(response)
+}
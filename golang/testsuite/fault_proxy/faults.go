@@ -0,0 +1,38 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy
+
+import (
+	"github.com/palantir/stacktrace"
+)
+
+// The faults a proxy is currently injecting; the zero value forwards traffic untouched
+type Faults struct {
+	// Delay added before forwarding each chunk of data, in either direction
+	LatencyMillis int `json:"latencyMillis"`
+
+	// Probability in [0, 1] that a new connection is closed as soon as it's accepted
+	DropProbability float64 `json:"dropProbability"`
+
+	// Maximum throughput of each connection in each direction; 0 means unlimited
+	BandwidthBytesPerSecond int `json:"bandwidthBytesPerSecond"`
+
+	// If true, connections are accepted and read from, but nothing is forwarded in either direction
+	Blackhole bool `json:"blackhole"`
+}
+
+func (faults Faults) validate() error {
+	if faults.LatencyMillis < 0 {
+		return stacktrace.NewError("Latency must be non-negative, but was %v", faults.LatencyMillis)
+	}
+	if faults.DropProbability < 0 || faults.DropProbability > 1 {
+		return stacktrace.NewError("Drop probability must be in [0, 1], but was %v", faults.DropProbability)
+	}
+	if faults.BandwidthBytesPerSecond < 0 {
+		return stacktrace.NewError("Bandwidth must be non-negative, but was %v", faults.BandwidthBytesPerSecond)
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+import (
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+const (
+	// Checking is exponential in the worst case, so we give up rather than hang the test
+	defaultMaxSearchSteps = 1000000
+)
+
+// A part of a history that couldn't be linearized
+type Violation struct {
+	// Every operation in the partition that couldn't be linearized, in invocation order
+	Operations []*Operation
+
+	// The longest sequence of the partition's operations that the checker could linearize, in linearization order; the
+	// operations missing from it are the ones that could never be placed
+	LongestLinearization []*Operation
+}
+
+/*
+Checks histories for linearizability against a model, via a depth-first search over the orders that the operations'
+ real-time constraints allow (Wing & Gong's algorithm), memoizing the (linearized operations, state) pairs explored
+*/
+type Checker struct {
+	model          Model
+	maxSearchSteps int
+}
+
+func NewChecker(model Model) *Checker {
+	return &Checker{
+		model:          model,
+		maxSearchSteps: defaultMaxSearchSteps,
+	}
+}
+
+// Sets how many search steps each partition may take before the checker gives up
+func (checker *Checker) WithMaxSearchSteps(maxSearchSteps int) *Checker {
+	checker.maxSearchSteps = maxSearchSteps
+	return checker
+}
+
+/*
+Checks the history, returning nil if it's linearizable or the first partition that isn't. An error is returned if the
+ checker gave up before reaching a verdict.
+*/
+func (checker Checker) Check(history []*Operation) (*Violation, error) {
+	for _, partition := range checker.model.Partition(history) {
+		isLinearizable, longestLinearization, err := checker.checkPartition(partition)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred checking a partition of %v operations", len(partition))
+		}
+		if !isLinearizable {
+			return &Violation{
+				Operations:           partition,
+				LongestLinearization: longestLinearization,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type searchCacheKey struct {
+	linearizedOperations string
+	state                interface{}
+}
+
+type partitionSearch struct {
+	model          Model
+	maxSearchSteps int
+
+	operations []*Operation
+
+	// Indexed the same as operations
+	isLinearized []bool
+
+	numCompletedRemaining int
+	numSteps              int
+	explored              map[searchCacheKey]bool
+	currentLinearization  []*Operation
+	longestLinearization  []*Operation
+}
+
+// Returns whether the partition is linearizable, along with the longest linearization found
+func (checker Checker) checkPartition(operations []*Operation) (bool, []*Operation, error) {
+	numCompleted := 0
+	for _, operation := range operations {
+		if operation.Completed {
+			numCompleted++
+		}
+	}
+	search := &partitionSearch{
+		model:                 checker.model,
+		maxSearchSteps:        checker.maxSearchSteps,
+		operations:            operations,
+		isLinearized:          make([]bool, len(operations)),
+		numCompletedRemaining: numCompleted,
+		numSteps:              0,
+		explored:              map[searchCacheKey]bool{},
+		currentLinearization:  []*Operation{},
+		longestLinearization:  []*Operation{},
+	}
+	isLinearizable, err := search.search(checker.model.GetInitialState())
+	if err != nil {
+		return false, nil, stacktrace.Propagate(err, "An error occurred searching for a linearization")
+	}
+	return isLinearizable, search.longestLinearization, nil
+}
+
+/*
+Tries to linearize the remaining operations from the given state. Operations that didn't complete may be left out, as
+ they may never have taken effect.
+*/
+func (search *partitionSearch) search(state interface{}) (bool, error) {
+	if search.numCompletedRemaining == 0 {
+		return true, nil
+	}
+
+	cacheKey := searchCacheKey{linearizedOperations: search.getLinearizedOperationsKey(), state: state}
+	if _, found := search.explored[cacheKey]; found {
+		return false, nil
+	}
+	search.explored[cacheKey] = true
+
+	search.numSteps++
+	if search.numSteps > search.maxSearchSteps {
+		return false, stacktrace.NewError("Gave up after %v search steps without reaching a verdict", search.maxSearchSteps)
+	}
+
+	// An operation can only come next if it was invoked before every remaining completed operation responded
+	var earliestResponseTime time.Time
+	for idx, operation := range search.operations {
+		if search.isLinearized[idx] || !operation.Completed {
+			continue
+		}
+		if earliestResponseTime.IsZero() || operation.ResponseTime.Before(earliestResponseTime) {
+			earliestResponseTime = operation.ResponseTime
+		}
+	}
+
+	for idx, operation := range search.operations {
+		if search.isLinearized[idx] || operation.InvokeTime.After(earliestResponseTime) {
+			continue
+		}
+		isLegal, nextState := search.model.Step(state, operation)
+		if !isLegal {
+			continue
+		}
+
+		search.markLinearized(idx)
+		isLinearizable, err := search.search(nextState)
+		if err != nil {
+			return false, err
+		}
+		if isLinearizable {
+			return true, nil
+		}
+		search.unmarkLinearized(idx)
+	}
+	return false, nil
+}
+
+func (search *partitionSearch) markLinearized(idx int) {
+	operation := search.operations[idx]
+	search.isLinearized[idx] = true
+	if operation.Completed {
+		search.numCompletedRemaining--
+	}
+	search.currentLinearization = append(search.currentLinearization, operation)
+	if len(search.currentLinearization) > len(search.longestLinearization) {
+		search.longestLinearization = append([]*Operation{}, search.currentLinearization...)
+	}
+}
+
+func (search *partitionSearch) unmarkLinearized(idx int) {
+	operation := search.operations[idx]
+	search.isLinearized[idx] = false
+	if operation.Completed {
+		search.numCompletedRemaining++
+	}
+	search.currentLinearization = search.currentLinearization[:len(search.currentLinearization)-1]
+}
+
+func (search *partitionSearch) getLinearizedOperationsKey() string {
+	keyBytes := make([]byte, len(search.isLinearized))
+	for idx, isLinearized := range search.isLinearized {
+		if isLinearized {
+			keyBytes[idx] = '1'
+		} else {
+			keyBytes[idx] = '0'
+		}
+	}
+	return string(keyBytes)
+}
@@ -0,0 +1,208 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	keyA = "a"
+	keyB = "b"
+
+	// The response time given to operations that didn't complete
+	notCompleted = -1
+)
+
+var historyStartTime = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// A write of the value to the key, or a read of the key if it isn't a write
+type registerInput struct {
+	key     string
+	isWrite bool
+	value   int
+}
+
+// A set of integer registers, one per key, which all start at 0
+type registerModel struct{}
+
+func (model registerModel) GetInitialState() interface{} {
+	return 0
+}
+
+func (model registerModel) Step(state interface{}, operation *Operation) (bool, interface{}) {
+	input := operation.Input.(registerInput)
+	if input.isWrite {
+		return true, input.value
+	}
+	if !operation.Completed {
+		return true, state
+	}
+	return operation.Output.(int) == state.(int), state
+}
+
+func (model registerModel) Partition(history []*Operation) [][]*Operation {
+	keyOrder := []string{}
+	operationsByKey := map[string][]*Operation{}
+	for _, operation := range history {
+		key := operation.Input.(registerInput).key
+		if _, found := operationsByKey[key]; !found {
+			keyOrder = append(keyOrder, key)
+		}
+		operationsByKey[key] = append(operationsByKey[key], operation)
+	}
+	result := [][]*Operation{}
+	for _, key := range keyOrder {
+		result = append(result, operationsByKey[key])
+	}
+	return result
+}
+
+func (model registerModel) DescribeOperation(operation *Operation) string {
+	input := operation.Input.(registerInput)
+	if input.isWrite {
+		return fmt.Sprintf("Write(%v, %v)", input.key, input.value)
+	}
+	return fmt.Sprintf("Read(%v) -> %v", input.key, operation.Output)
+}
+
+func TestChecker_Check(t *testing.T) {
+	testCases := []struct {
+		name    string
+		history []*Operation
+
+		// The IDs of the operations in the partition that isn't linearizable; nil if the history is linearizable
+		expectedViolationIds []int
+	}{
+		{
+			name:    "empty history",
+			history: []*Operation{},
+		},
+		{
+			name: "read sees earlier write",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 10),
+				read(2, keyA, 1, 20, 30),
+			},
+		},
+		{
+			name: "stale read after write",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 10),
+				read(2, keyA, 0, 20, 30),
+			},
+			expectedViolationIds: []int{1, 2},
+		},
+		{
+			name: "read concurrent with write sees old value",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 30),
+				read(2, keyA, 0, 10, 20),
+			},
+		},
+		{
+			name: "read concurrent with write sees new value",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 30),
+				read(2, keyA, 1, 10, 20),
+			},
+		},
+		{
+			name: "value goes back in time during write",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 50),
+				read(2, keyA, 1, 10, 20),
+				read(3, keyA, 0, 30, 40),
+			},
+			expectedViolationIds: []int{1, 2, 3},
+		},
+		{
+			name: "failed write may have taken effect",
+			history: []*Operation{
+				write(1, keyA, 1, 0, notCompleted),
+				read(2, keyA, 1, 20, 30),
+			},
+		},
+		{
+			name: "failed write may not have taken effect",
+			history: []*Operation{
+				write(1, keyA, 1, 0, notCompleted),
+				read(2, keyA, 0, 20, 30),
+			},
+		},
+		{
+			name: "only the violating key's partition is reported",
+			history: []*Operation{
+				write(1, keyA, 1, 0, 10),
+				write(2, keyB, 2, 0, 10),
+				read(3, keyA, 1, 20, 30),
+				read(4, keyB, 0, 20, 30),
+			},
+			expectedViolationIds: []int{2, 4},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			violation, err := NewChecker(registerModel{}).Check(testCase.history)
+			require.NoError(t, err)
+			if testCase.expectedViolationIds == nil {
+				require.Nil(t, violation)
+				return
+			}
+			require.NotNil(t, violation)
+			violationIds := []int{}
+			for _, operation := range violation.Operations {
+				violationIds = append(violationIds, operation.Id)
+			}
+			require.Equal(t, testCase.expectedViolationIds, violationIds)
+			require.True(t, len(violation.LongestLinearization) < len(violation.Operations))
+		})
+	}
+}
+
+func TestChecker_GivesUpAfterMaxSearchSteps(t *testing.T) {
+	history := []*Operation{
+		write(1, keyA, 1, 0, 50),
+		write(2, keyA, 2, 0, 50),
+		read(3, keyA, 3, 0, 50),
+	}
+	_, err := NewChecker(registerModel{}).WithMaxSearchSteps(1).Check(history)
+	require.Error(t, err)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func write(id int, key string, value int, invokeMillis int, responseMillis int) *Operation {
+	return newOperation(id, registerInput{key: key, isWrite: true, value: value}, nil, invokeMillis, responseMillis)
+}
+
+func read(id int, key string, output int, invokeMillis int, responseMillis int) *Operation {
+	return newOperation(id, registerInput{key: key, isWrite: false, value: 0}, output, invokeMillis, responseMillis)
+}
+
+// Each operation gets its own client, as no client's operations overlap in these histories anyway
+func newOperation(id int, input registerInput, output interface{}, invokeMillis int, responseMillis int) *Operation {
+	operation := &Operation{
+		Id:         id,
+		ClientId:   id,
+		Input:      input,
+		Output:     output,
+		Completed:  responseMillis != notCompleted,
+		Error:      "",
+		InvokeTime: historyStartTime.Add(time.Duration(invokeMillis) * time.Millisecond),
+	}
+	if operation.Completed {
+		operation.ResponseTime = historyStartTime.Add(time.Duration(responseMillis) * time.Millisecond)
+	} else {
+		operation.Output = nil
+		operation.Error = "timed out"
+	}
+	return operation
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// A single call that a client made against the system under test, from invocation to response
+type Operation struct {
+	// Unique within a history, in invocation order
+	Id int
+
+	// Each client makes one call at a time, so a client's operations never overlap
+	ClientId int
+
+	// What the call was, in whatever form the Model understands
+	Input interface{}
+
+	// What the call returned, in whatever form the Model understands; nil if the call didn't complete
+	Output interface{}
+
+	// False if the call returned an error, in which case we can't know whether it took effect: it's treated as having
+	// possibly taken effect at any point after it was invoked, or not at all
+	Completed bool
+
+	// The error the call returned, if it didn't complete
+	Error string
+
+	InvokeTime time.Time
+
+	// Zero if the call didn't complete
+	ResponseTime time.Time
+}
+
+// Records the operations that concurrent clients make, for checking afterwards
+type HistoryRecorder struct {
+	mutex      *sync.Mutex
+	operations []*Operation
+}
+
+func NewHistoryRecorder() *HistoryRecorder {
+	return &HistoryRecorder{
+		mutex:      &sync.Mutex{},
+		operations: []*Operation{},
+	}
+}
+
+// Records that the client is invoking a call, which the client must then finish via the returned PendingOperation
+func (recorder *HistoryRecorder) Invoke(clientId int, input interface{}) *PendingOperation {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	operation := &Operation{
+		Id:         len(recorder.operations) + 1,
+		ClientId:   clientId,
+		Input:      input,
+		Output:     nil,
+		Completed:  false,
+		Error:      "",
+		InvokeTime: time.Now(),
+	}
+	recorder.operations = append(recorder.operations, operation)
+	return &PendingOperation{recorder: recorder, operation: operation}
+}
+
+// Gets a copy of the recorded operations, in invocation order
+func (recorder *HistoryRecorder) GetHistory() []*Operation {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	result := []*Operation{}
+	for _, operation := range recorder.operations {
+		operationCopy := *operation
+		result = append(result, &operationCopy)
+	}
+	return result
+}
+
+// An operation that's been invoked but hasn't yet returned
+type PendingOperation struct {
+	recorder  *HistoryRecorder
+	operation *Operation
+}
+
+// Records that the call returned the given output
+func (pending *PendingOperation) Complete(output interface{}) {
+	pending.recorder.mutex.Lock()
+	defer pending.recorder.mutex.Unlock()
+	pending.operation.ResponseTime = time.Now()
+	pending.operation.Output = output
+	pending.operation.Completed = true
+}
+
+// Records that the call returned an error, so whether it took effect is unknown
+func (pending *PendingOperation) Fail(err error) {
+	pending.recorder.mutex.Lock()
+	defer pending.recorder.mutex.Unlock()
+	pending.operation.Error = fmt.Sprintf("%v", err)
+}
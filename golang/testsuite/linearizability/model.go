@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+/*
+A sequential specification of the system under test, which a history is linearizable with respect to if its operations
+ can be put in an order that respects real time (an operation that responded before another was invoked comes first)
+ and in which every operation's output is what the model says it should be
+
+States must be comparable (usable as map keys), as the checker memoizes the states it has already explored.
+*/
+type Model interface {
+	GetInitialState() interface{}
+
+	// Applies the operation to the state, returning whether the operation's output is legal in that state along with the
+	// state after the operation. Operations that didn't complete have no output, and should be treated as having taken
+	// whatever effect they would have had.
+	Step(state interface{}, operation *Operation) (bool, interface{})
+
+	// Splits a history into parts that don't affect each other (e.g. one per key), which are checked separately; this
+	// is what keeps checking tractable, so models should partition as finely as they can
+	Partition(history []*Operation) [][]*Operation
+
+	// Gets a short human-readable form of the operation's input & output, e.g. "Get(23) -> 4"
+	DescribeOperation(operation *Operation) string
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	timelineWidth = 100
+
+	operationStartChar      = '['
+	operationEndChar        = ']'
+	operationNoResponseChar = '>'
+	operationFillChar       = '-'
+	notLinearizedMarker     = "!"
+)
+
+/*
+Renders a violation as a timeline with one row per client, followed by a table of the operations, so that it can be
+ seen which operations were concurrent and which ones the checker could never place:
+
+          0s                                         12.5ms
+ client 0 [#1---]   [#3-------]
+ client 1      [#2!----]
+*/
+func RenderViolation(model Model, violation *Violation) string {
+	linearizationPositions := map[int]int{}
+	for idx, operation := range violation.LongestLinearization {
+		linearizationPositions[operation.Id] = idx + 1
+	}
+
+	lines := []string{
+		fmt.Sprintf(
+			"Only %v of these %v operations could be linearized; the ones marked '%v' could never be placed",
+			len(violation.LongestLinearization),
+			len(violation.Operations),
+			notLinearizedMarker,
+		),
+		"",
+	}
+	lines = append(lines, renderTimeline(violation.Operations, linearizationPositions)...)
+	lines = append(lines, "")
+	lines = append(lines, renderOperationsTable(model, violation.Operations, linearizationPositions)...)
+	return strings.Join(lines, "\n")
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func renderTimeline(operations []*Operation, linearizationPositions map[int]int) []string {
+	if len(operations) == 0 {
+		return []string{}
+	}
+	startTime, endTime := getTimeBounds(operations)
+	duration := endTime.Sub(startTime)
+	getColumn := func(timestamp time.Time) int {
+		if duration <= 0 {
+			return 0
+		}
+		return int(int64(timelineWidth-1) * int64(timestamp.Sub(startTime)) / int64(duration))
+	}
+
+	operationsByClient := map[int][]*Operation{}
+	for _, operation := range operations {
+		operationsByClient[operation.ClientId] = append(operationsByClient[operation.ClientId], operation)
+	}
+	clientIds := []int{}
+	for clientId := range operationsByClient {
+		clientIds = append(clientIds, clientId)
+	}
+	sort.Ints(clientIds)
+
+	rowLabelFormat := fmt.Sprintf("%%-%vv", len(fmt.Sprintf("client %v", clientIds[len(clientIds)-1]))+1)
+	durationLabel := duration.String()
+	lines := []string{
+		fmt.Sprintf(rowLabelFormat, "") + "0s" + strings.Repeat(" ", timelineWidth-len("0s")-len(durationLabel)) + durationLabel,
+	}
+	for _, clientId := range clientIds {
+		row := []byte(strings.Repeat(" ", timelineWidth))
+		for _, operation := range operationsByClient[clientId] {
+			startCol := getColumn(operation.InvokeTime)
+			endCol := timelineWidth - 1
+			endChar := byte(operationNoResponseChar)
+			if operation.Completed {
+				endCol = getColumn(operation.ResponseTime)
+				endChar = operationEndChar
+			}
+			if endCol <= startCol && startCol < timelineWidth-1 {
+				endCol = startCol + 1
+			}
+			for col := startCol; col <= endCol; col++ {
+				row[col] = operationFillChar
+			}
+			row[startCol] = operationStartChar
+			row[endCol] = endChar
+
+			label := fmt.Sprintf("#%v", operation.Id)
+			if _, found := linearizationPositions[operation.Id]; !found && operation.Completed {
+				label += notLinearizedMarker
+			}
+			if startCol+len(label) < endCol {
+				copy(row[startCol+1:], label)
+			}
+		}
+		lines = append(lines, fmt.Sprintf(rowLabelFormat, fmt.Sprintf("client %v", clientId))+string(row))
+	}
+	return lines
+}
+
+func renderOperationsTable(model Model, operations []*Operation, linearizationPositions map[int]int) []string {
+	startTime, _ := getTimeBounds(operations)
+	lines := []string{}
+	for _, operation := range operations {
+		timing := fmt.Sprintf("%v - ", operation.InvokeTime.Sub(startTime))
+		if operation.Completed {
+			timing += operation.ResponseTime.Sub(startTime).String()
+		} else {
+			timing += "(no response: " + operation.Error + ")"
+		}
+
+		status := "not linearized"
+		if position, found := linearizationPositions[operation.Id]; found {
+			status = fmt.Sprintf("linearized at position %v", position)
+		} else if operation.Completed {
+			status = "NOT LINEARIZABLE " + notLinearizedMarker
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"  #%-4v client %-3v %-30v %v    %v",
+			operation.Id,
+			operation.ClientId,
+			model.DescribeOperation(operation),
+			timing,
+			status,
+		))
+	}
+	return lines
+}
+
+// Gets the earliest invocation & the latest invocation or response of the operations
+func getTimeBounds(operations []*Operation) (time.Time, time.Time) {
+	var startTime, endTime time.Time
+	for _, operation := range operations {
+		if startTime.IsZero() || operation.InvokeTime.Before(startTime) {
+			startTime = operation.InvokeTime
+		}
+		latestTime := operation.InvokeTime
+		if operation.Completed {
+			latestTime = operation.ResponseTime
+		}
+		if latestTime.After(endTime) {
+			endTime = latestTime
+		}
+	}
+	return startTime, endTime
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package linearizability
+
+import (
+	"github.com/palantir/stacktrace"
+	"sync"
+)
+
+/*
+Makes a single call on behalf of a client, recording it via the recorder
+
+Calls that fail should be recorded as failed operations rather than returned; an error should only be returned for
+ problems that mean the workload can't continue.
+*/
+type ClientOperation func(clientId int, operationIdx int, recorder *HistoryRecorder) error
+
+/*
+Drives the given number of clients concurrently, each making its calls one after another, and returns the recorded
+ history once every client has finished
+*/
+func RunConcurrentWorkload(numClients int, numOperationsPerClient int, doOperation ClientOperation) ([]*Operation, error) {
+	recorder := NewHistoryRecorder()
+
+	// Held until every client is ready, so that the clients' calls overlap as much as possible
+	startGate := &sync.WaitGroup{}
+	startGate.Add(1)
+
+	clientErrs := make([]error, numClients)
+	clientsDone := &sync.WaitGroup{}
+	for clientId := 0; clientId < numClients; clientId++ {
+		clientsDone.Add(1)
+		go func(clientId int) {
+			defer clientsDone.Done()
+			startGate.Wait()
+			for operationIdx := 0; operationIdx < numOperationsPerClient; operationIdx++ {
+				if err := doOperation(clientId, operationIdx, recorder); err != nil {
+					clientErrs[clientId] = stacktrace.Propagate(err, "An error occurred making call %v for client %v", operationIdx, clientId)
+					return
+				}
+			}
+		}(clientId)
+	}
+	startGate.Done()
+	clientsDone.Wait()
+
+	for _, err := range clientErrs {
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "A workload client couldn't continue")
+		}
+	}
+	return recorder.GetHistory(), nil
+}
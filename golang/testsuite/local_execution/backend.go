@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package local_execution
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fake_network"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/palantir/stacktrace"
+)
+
+// A network that a test is set up against when running locally, which is destroyed once the test finishes
+type BackendNetwork interface {
+	network_context.NetworkContext
+
+	Destroy() error
+}
+
+// Provides the networks that tests run against when the suite is run locally, rather than by Kurtosis
+type Backend interface {
+	CreateNetwork(testName string) (BackendNetwork, error)
+
+	// Whether the backend's networks actually block traffic between partitions; tests that enable partitioning are
+	//  skipped against a backend that doesn't, as they'd be checking isolation that isn't there
+	SupportsPartitioning() bool
+}
+
+// Runs each test against its own FakeNetworkContext, with services backed by in-process stand-ins; as the fake
+//  doesn't enforce partitions, tests that need them are skipped
+type FakeBackend struct {
+	// Mapping of image -> factory for the stand-ins of services started with that image
+	standInFactories map[string]fake_network.StandInFactory
+
+	// Mapping of static file ID -> filepath on the local filesystem
+	staticFiles map[services.StaticFileID]string
+}
+
+func NewFakeBackend(standInFactories map[string]fake_network.StandInFactory, staticFiles map[services.StaticFileID]string) *FakeBackend {
+	return &FakeBackend{standInFactories: standInFactories, staticFiles: staticFiles}
+}
+
+func (backend FakeBackend) CreateNetwork(testName string) (BackendNetwork, error) {
+	network, err := fake_network.NewFakeNetworkContext()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred creating a fake network for test '%v'", testName)
+	}
+	for image, factory := range backend.standInFactories {
+		network.WithStandIn(image, factory)
+	}
+	for staticFileId, filepath := range backend.staticFiles {
+		network.WithStaticFile(staticFileId, filepath)
+	}
+	return network, nil
+}
+
+func (backend FakeBackend) SupportsPartitioning() bool {
+	return false
+}
@@ -0,0 +1,256 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package local_execution
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"time"
+)
+
+const (
+	// How long teardown waits for a timed-out phase that's still running to exit, before giving up on tearing down
+	phaseExitGracePeriod = 10 * time.Second
+)
+
+// A suite whose tests can be set up against any network context, which is what running locally needs
+type NetworkContextTestSuite interface {
+	GetNetworkContextTests() map[string]network_context.Test
+}
+
+// What a phase returns, which comes back over a channel so that a phase that's given up on can't change the caller's
+//  variables after the fact
+type phaseResult struct {
+	// Only set by setup
+	network networks.Network
+
+	err error
+}
+
+// The outcome of running a single test locally
+type TestResult struct {
+	TestName string
+	Duration time.Duration
+
+	// Empty if the test passed
+	FailedPhase string
+
+	// Nil if the test passed
+	Error error
+
+	// Empty unless the test was skipped because the backend can't give it what it needs, in which case it's neither
+	//  passed nor failed
+	SkipReason string
+}
+
+/*
+Runs a suite's tests in-process against networks from a Backend, the way Kurtosis would run them in containers:
+ each test is configured, set up, and run, with setup & run each subject to the timeouts the test configures
+*/
+type LocalRunner struct {
+	suite   NetworkContextTestSuite
+	backend Backend
+}
+
+func NewLocalRunner(suite NetworkContextTestSuite, backend Backend) *LocalRunner {
+	return &LocalRunner{suite: suite, backend: backend}
+}
+
+// Gets the names of all the suite's tests, sorted
+func (runner LocalRunner) GetTestNames() []string {
+	result := []string{}
+	for testName := range runner.suite.GetNetworkContextTests() {
+		result = append(result, testName)
+	}
+	sort.Strings(result)
+	return result
+}
+
+/*
+Runs the given tests one after another, in the order given, returning one result per test
+
+An error is returned only if a test name doesn't exist, in which case no tests are run. Tests that need something the
+ backend doesn't support (e.g. partitioning) are skipped rather than run.
+*/
+func (runner LocalRunner) RunTests(testNames []string) ([]*TestResult, error) {
+	allTests := runner.suite.GetNetworkContextTests()
+	for _, testName := range testNames {
+		if _, found := allTests[testName]; !found {
+			return nil, stacktrace.NewError("No test with name '%v' exists in the testsuite", testName)
+		}
+	}
+
+	results := []*TestResult{}
+	for _, testName := range testNames {
+		// Like Kurtosis, we get a fresh copy of the test for each test
+		test := runner.suite.GetNetworkContextTests()[testName]
+		if skipReason := runner.getSkipReason(test); skipReason != "" {
+			logrus.Warnf("Skipping test '%v' because %v", testName, skipReason)
+			results = append(results, &TestResult{TestName: testName, SkipReason: skipReason})
+			continue
+		}
+		startTime := time.Now()
+		failedPhase, err := runner.runTest(testName, test)
+		result := &TestResult{
+			TestName:    testName,
+			Duration:    time.Since(startTime),
+			FailedPhase: failedPhase,
+			Error:       err,
+		}
+		if err != nil {
+			logrus.Errorf("Test '%v' failed during %v:\n%v", testName, failedPhase, err)
+		} else {
+			logrus.Infof("Test '%v' passed in %v", testName, result.Duration)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// Gets a one-line-per-test summary of the results
+func GetResultsSummary(results []*TestResult) string {
+	summary := ""
+	for _, result := range results {
+		status := "PASSED"
+		if result.SkipReason != "" {
+			summary += fmt.Sprintf("%v: SKIPPED (%v)\n", result.TestName, result.SkipReason)
+			continue
+		}
+		if result.Error != nil {
+			status = fmt.Sprintf("FAILED (%v)", result.FailedPhase)
+		}
+		summary += fmt.Sprintf("%v: %v in %v\n", result.TestName, status, result.Duration)
+	}
+	return summary
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Gets why the test can't be run against the backend, or "" if it can
+func (runner LocalRunner) getSkipReason(test network_context.Test) string {
+	testConfigBuilder := testsuite.NewTestConfigurationBuilder()
+	test.Configure(testConfigBuilder)
+	if testConfigBuilder.Build().IsPartitioningEnabled && !runner.backend.SupportsPartitioning() {
+		return "it enables partitioning, which the backend doesn't enforce"
+	}
+	return ""
+}
+
+// Returns the phase the test failed in along with the error, or ("", nil) if the test passed
+func (runner LocalRunner) runTest(testName string, test network_context.Test) (string, error) {
+	testConfigBuilder := testsuite.NewTestConfigurationBuilder()
+	test.Configure(testConfigBuilder)
+	testConfig := testConfigBuilder.Build()
+
+	network, err := runner.backend.CreateNetwork(testName)
+	if err != nil {
+		return test_lifecycle.SetupPhase, stacktrace.Propagate(err, "An error occurred creating the network for test '%v'", testName)
+	}
+	defer func() {
+		if err := network.Destroy(); err != nil {
+			logrus.Errorf("An error occurred destroying the network for test '%v':\n%v", testName, err)
+		}
+	}()
+
+	// Cancelled if setup or run times out, so that the abandoned phase can't keep adding services to the network
+	ctx, cancelFunc := context.WithCancel(context.Background())
+
+	// Only set once setup has returned a network, so that teardown never races with a setup that timed out
+	var networkToTearDown networks.Network
+	// Closed once the goroutine running the latest phase has exited, which may be well after a timed-out phase returned
+	var lastPhaseDoneChan <-chan struct{}
+	defer func() {
+		// A timed-out phase may still be using the network, so it's told to stop and given a chance to before teardown
+		cancelFunc()
+		if err := waitForPhaseExit(lastPhaseDoneChan, phaseExitGracePeriod); err != nil {
+			logrus.Errorf("Not tearing down test '%v', as its last phase is still running:\n%v", testName, err)
+			return
+		}
+		if err := test_lifecycle.TeardownTestAndNetwork(test, networkToTearDown); err != nil {
+			logrus.Errorf("An error occurred tearing down test '%v':\n%v", testName, err)
+		}
+	}()
+
+	logrus.Infof("Setting up network for test '%v'...", testName)
+	userNetwork, setupDoneChan, setupErr := runWithTimeout(time.Duration(testConfig.SetupTimeoutSeconds)*time.Second, cancelFunc, func() (networks.Network, error) {
+		return test.Setup(network_context.NewCancellableNetworkContext(ctx, network))
+	})
+	lastPhaseDoneChan = setupDoneChan
+	if setupErr != nil {
+		return test_lifecycle.SetupPhase, stacktrace.Propagate(setupErr, "An error occurred during test setup")
+	}
+	if userNetwork == nil {
+		return test_lifecycle.SetupPhase, stacktrace.NewError("The test setup method returned successfully, but yielded a nil network object")
+	}
+	networkToTearDown = userNetwork
+
+	logrus.Infof("Running test logic for test '%v'...", testName)
+	_, runDoneChan, runErr := runWithTimeout(time.Duration(testConfig.RunTimeoutSeconds)*time.Second, cancelFunc, func() (networks.Network, error) {
+		return nil, test_lifecycle.RunTest(ctx, test, userNetwork)
+	})
+	lastPhaseDoneChan = runDoneChan
+	if runErr != nil {
+		return test_lifecycle.RunPhase, stacktrace.Propagate(runErr, "An error occurred running test '%v'", testName)
+	}
+	return "", nil
+}
+
+/*
+Runs a phase's function, converting panics to errors, and gives up on it once the timeout elapses; the network is
+ whatever the function returned, so is only set for setup, and the channel is closed once the function has exited
+
+A function that times out is abandoned rather than stopped, as Go has no way to kill a goroutine; it will keep running
+ until it finishes or the process exits, but its result goes nowhere. The cancel function is called when it times
+ out, so that a function that respects its context can stop early.
+*/
+func runWithTimeout(
+	timeout time.Duration,
+	cancelFunc context.CancelFunc,
+	function func() (networks.Network, error),
+) (networks.Network, <-chan struct{}, error) {
+	// Buffered, so an abandoned function can still send its result and exit
+	resultChan := make(chan phaseResult, 1)
+	doneChan := make(chan struct{})
+	go func() {
+		defer close(doneChan)
+		defer func() {
+			if recoverResult := recover(); recoverResult != nil {
+				resultChan <- phaseResult{err: stacktrace.NewError("A panic occurred: %v", recoverResult)}
+			}
+		}()
+		network, err := function()
+		resultChan <- phaseResult{network: network, err: err}
+	}()
+
+	select {
+	case result := <-resultChan:
+		return result.network, doneChan, result.err
+	case <-time.After(timeout):
+		cancelFunc()
+		return nil, doneChan, stacktrace.NewError("Timed out after %v", timeout)
+	}
+}
+
+// Waits for the phase whose goroutine closes the channel to exit, which is immediate if no phase has run
+func waitForPhaseExit(phaseDoneChan <-chan struct{}, timeout time.Duration) error {
+	if phaseDoneChan == nil {
+		return nil
+	}
+	select {
+	case <-phaseDoneChan:
+		return nil
+	case <-time.After(timeout):
+		return stacktrace.NewError("The phase still hadn't exited %v after it was cancelled", timeout)
+	}
+}
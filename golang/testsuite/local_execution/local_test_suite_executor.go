@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package local_execution
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"path/filepath"
+)
+
+const (
+	// The prefix of the temp directory that output goes in when no output directory is given
+	tempOutputDirnamePrefix = "testsuite-output-"
+)
+
+/*
+A configurator that can create the suite, and the backend that the suite's tests run against, for running locally
+
+Every configurator for the executor that serves the suite to Kurtosis already has SetLogLevel, so a configurator can
+ implement both; the executor's configurator interface isn't embedded, so that only main depends on the executor.
+*/
+type LocalTestSuiteConfigurator interface {
+	SetLogLevel(logLevelStr string) error
+
+	// Like ParseParamsAndCreateSuite, except that the suite's static files are in the given local directory rather than
+	//  where the testsuite container has them, and the suite writes its output (e.g. reports) to the given local
+	//  directory rather than the suite execution volume
+	ParseParamsAndCreateLocalSuite(paramsJsonStr string, staticFilesDirpath string, outputDirpath string) (testsuite.TestSuite, error)
+
+	// The static files are the suite's, for the backend to give to the services that use them
+	CreateLocalBackend(paramsJsonStr string, staticFiles map[services.StaticFileID]string) (Backend, error)
+}
+
+/*
+The local counterpart to execution.TestSuiteExecutor, which runs the suite in-process without Kurtosis Core
+
+Rather than reading its inputs from the environment, it takes them directly so they can come from command-line flags.
+*/
+type LocalTestSuiteExecutor struct {
+	configurator  LocalTestSuiteConfigurator
+	logLevelStr   string
+	paramsJsonStr string
+
+	// The local copy of the suite's static_files directory
+	staticFilesDirpath string
+
+	// Where the suite writes its output; if empty, a new temp directory is used
+	outputDirpath string
+
+	// If empty, all tests are run
+	testNames []string
+
+	// If true, the suite's test names are printed rather than any tests being run
+	listOnly bool
+}
+
+func NewLocalTestSuiteExecutor(
+	configurator LocalTestSuiteConfigurator,
+	logLevelStr string,
+	paramsJsonStr string,
+	staticFilesDirpath string,
+	outputDirpath string,
+	testNames []string,
+	listOnly bool,
+) *LocalTestSuiteExecutor {
+	return &LocalTestSuiteExecutor{
+		configurator:       configurator,
+		logLevelStr:        logLevelStr,
+		paramsJsonStr:      paramsJsonStr,
+		staticFilesDirpath: staticFilesDirpath,
+		outputDirpath:      outputDirpath,
+		testNames:          testNames,
+		listOnly:           listOnly,
+	}
+}
+
+func (executor LocalTestSuiteExecutor) Run() error {
+	if err := executor.configurator.SetLogLevel(executor.logLevelStr); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the loglevel before running the testsuite locally")
+	}
+
+	// Absolute, so that services given the static files can find them wherever they're started from
+	staticFilesDirpath, err := filepath.Abs(executor.staticFilesDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the absolute path of static files directory '%v'", executor.staticFilesDirpath)
+	}
+	// Listing tests writes nothing, so doesn't need anywhere to write to
+	outputDirpath := executor.outputDirpath
+	if outputDirpath == "" && !executor.listOnly {
+		outputDirpath, err = ioutil.TempDir("", tempOutputDirnamePrefix)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred creating a temp directory for the testsuite's output")
+		}
+	}
+	suite, err := executor.configurator.ParseParamsAndCreateLocalSuite(executor.paramsJsonStr, staticFilesDirpath, outputDirpath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred parsing the suite params JSON and creating the testsuite")
+	}
+	networkContextSuite, ok := suite.(NetworkContextTestSuite)
+	if !ok {
+		return stacktrace.NewError("The testsuite can't be run locally because it doesn't provide tests that accept any network context")
+	}
+	backend, err := executor.configurator.CreateLocalBackend(executor.paramsJsonStr, suite.GetStaticFiles())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the backend to run the tests against")
+	}
+	runner := NewLocalRunner(networkContextSuite, backend)
+
+	if executor.listOnly {
+		for _, testName := range runner.GetTestNames() {
+			fmt.Fprintln(logrus.StandardLogger().Out, testName)
+		}
+		return nil
+	}
+
+	testNames := executor.testNames
+	if len(testNames) == 0 {
+		testNames = runner.GetTestNames()
+	}
+	logrus.Infof("Diagnostics bundles & reports will be written to '%v'", outputDirpath)
+	results, err := runner.RunTests(testNames)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred running the tests")
+	}
+	fmt.Fprint(logrus.StandardLogger().Out, GetResultsSummary(results))
+
+	numFailed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			numFailed++
+		}
+	}
+	if numFailed > 0 {
+		return stacktrace.NewError("%v of %v tests failed", numFailed, len(results))
+	}
+	return nil
+}
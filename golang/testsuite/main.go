@@ -6,23 +6,76 @@
 package main
 
 import (
+	"flag"
 	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/execution"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/execution_impl"
-	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/execution"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/local_execution"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/kurtosis_testsuite_docker_api"
 	"github.com/sirupsen/logrus"
 	"os"
+	"strings"
 )
 
 const (
 	successExitCode = 0
 	failureExitCode = 1
+
+	testNamesSeparator = ","
+
+	// Relative to the golang directory, which is where the testsuite is usually run from
+	defaultLocalStaticFilesDirpath = "testsuite/static_files"
 )
 
 func main() {
+	// Kurtosis runs the testsuite without any flags; these are only for running it locally
+	isLocalFlag := flag.Bool("local", false, "Run the testsuite in-process against a fake network, rather than serving it to Kurtosis Core")
+	paramsJsonFlag := flag.String(
+		"params",
+		os.Getenv(kurtosis_testsuite_docker_api.CustomParamsJsonEnvVar),
+		"With --local, the custom params JSON to create the testsuite with (defaults to the "+kurtosis_testsuite_docker_api.CustomParamsJsonEnvVar+" env var)",
+	)
+	staticFilesDirFlag := flag.String(
+		"static-files-dir",
+		defaultLocalStaticFilesDirpath,
+		"With --local, the directory holding the testsuite's static files (the contents of testsuite/static_files)",
+	)
+	outputDirFlag := flag.String(
+		"output-dir",
+		"",
+		"With --local, the directory to write diagnostics bundles & reports to (defaults to a new temp directory)",
+	)
+	testNamesFlag := flag.String("tests", "", "With --local, a comma-separated list of the tests to run (defaults to all tests)")
+	listFlag := flag.Bool("list", false, "With --local, print the testsuite's test names rather than running any tests")
+	logLevelFlag := flag.String("log-level", logrus.InfoLevel.String(), "With --local, the loglevel to run the testsuite at")
+	flag.Parse()
+
 	// >>>>>>>>>>>>>>>>>>> REPLACE WITH YOUR OWN CONFIGURATOR <<<<<<<<<<<<<<<<<<<<<<<<
 	configurator := execution_impl.NewExampleTestsuiteConfigurator()
 	// >>>>>>>>>>>>>>>>>>> REPLACE WITH YOUR OWN CONFIGURATOR <<<<<<<<<<<<<<<<<<<<<<<<
 
+	if *isLocalFlag {
+		testNames := []string{}
+		if *testNamesFlag != "" {
+			testNames = strings.Split(*testNamesFlag, testNamesSeparator)
+		}
+		localExecutor := local_execution.NewLocalTestSuiteExecutor(
+			configurator,
+			*logLevelFlag,
+			*paramsJsonFlag,
+			*staticFilesDirFlag,
+			*outputDirFlag,
+			testNames,
+			*listFlag,
+		)
+		if err := localExecutor.Run(); err != nil {
+			logrus.Errorf("An error occurred running the test suite locally:")
+			fmt.Fprintln(logrus.StandardLogger().Out, err)
+			os.Exit(failureExitCode)
+		}
+		os.Exit(successExitCode)
+	}
+
 	suiteExecutor := execution.NewTestSuiteExecutor(configurator)
 	if err := suiteExecutor.Run(); err != nil {
 		logrus.Errorf("An error occurred running the test suite executor:")
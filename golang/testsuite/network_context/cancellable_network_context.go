@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_context
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+)
+
+/*
+Wraps a network context so that, once the context is cancelled, the operations that would do more work (adding
+ services, repartitioning, and waiting for endpoints) fail immediately
+
+Lookups & removing services still go through after cancellation, so that a cancelled test can still be torn down.
+*/
+func NewCancellableNetworkContext(ctx context.Context, underlying NetworkContext) NetworkContext {
+	return &cancellableNetworkContext{ctx: ctx, underlying: underlying}
+}
+
+/*
+Gets the context that the network context was wrapped with by NewCancellableNetworkContext, or a context that's never
+ cancelled if it wasn't, for network operations that wait (e.g. for a service to become ready) to stop waiting on
+*/
+func GetContext(networkCtx NetworkContext) context.Context {
+	if cancellableNetworkCtx, ok := networkCtx.(*cancellableNetworkContext); ok {
+		return cancellableNetworkCtx.ctx
+	}
+	return context.Background()
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type cancellableNetworkContext struct {
+	ctx        context.Context
+	underlying NetworkContext
+}
+
+func (networkCtx cancellableNetworkContext) AddService(
+	serviceId services.ServiceID,
+	containerCreationConfig *services.ContainerCreationConfig,
+	generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	if err := networkCtx.ctx.Err(); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Couldn't add service '%v', as the network's context is done", serviceId)
+	}
+	return networkCtx.underlying.AddService(serviceId, containerCreationConfig, generateRunConfigFunc)
+}
+
+func (networkCtx cancellableNetworkContext) AddServiceToPartition(
+	serviceId services.ServiceID,
+	partitionId networks.PartitionID,
+	containerCreationConfig *services.ContainerCreationConfig,
+	generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	if err := networkCtx.ctx.Err(); err != nil {
+		return nil, nil, stacktrace.Propagate(err, "Couldn't add service '%v' to partition '%v', as the network's context is done", serviceId, partitionId)
+	}
+	return networkCtx.underlying.AddServiceToPartition(serviceId, partitionId, containerCreationConfig, generateRunConfigFunc)
+}
+
+func (networkCtx cancellableNetworkContext) GetServiceContext(serviceId services.ServiceID) (*services.ServiceContext, error) {
+	return networkCtx.underlying.GetServiceContext(serviceId)
+}
+
+func (networkCtx cancellableNetworkContext) RemoveService(serviceId services.ServiceID, containerStopTimeoutSeconds uint64) error {
+	return networkCtx.underlying.RemoveService(serviceId, containerStopTimeoutSeconds)
+}
+
+func (networkCtx cancellableNetworkContext) RepartitionNetwork(
+	partitionServices map[networks.PartitionID]map[services.ServiceID]bool,
+	partitionConnections map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+	defaultConnection *kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+) error {
+	if err := networkCtx.ctx.Err(); err != nil {
+		return stacktrace.Propagate(err, "Couldn't repartition the network, as the network's context is done")
+	}
+	return networkCtx.underlying.RepartitionNetwork(partitionServices, partitionConnections, defaultConnection)
+}
+
+func (networkCtx cancellableNetworkContext) WaitForEndpointAvailability(
+	serviceId services.ServiceID,
+	port uint32,
+	path string,
+	initialDelaySeconds uint32,
+	retries uint32,
+	retriesDelayMilliseconds uint32,
+	bodyText string,
+) error {
+	if err := networkCtx.ctx.Err(); err != nil {
+		return stacktrace.Propagate(err, "Couldn't wait for service '%v' to become available, as the network's context is done", serviceId)
+	}
+	return networkCtx.underlying.WaitForEndpointAvailability(serviceId, port, path, initialDelaySeconds, retries, retriesDelayMilliseconds, bodyText)
+}
@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_context
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+)
+
+/*
+The subset of Kurtosis' *networks.NetworkContext that this testsuite's networks & tests use, so that they can be run
+ against other implementations (e.g. the in-memory one in the fake_network package) without Docker or Kurtosis
+
+*networks.NetworkContext implements this as-is.
+*/
+type NetworkContext interface {
+	AddService(
+		serviceId services.ServiceID,
+		containerCreationConfig *services.ContainerCreationConfig,
+		generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+	) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error)
+
+	AddServiceToPartition(
+		serviceId services.ServiceID,
+		partitionId networks.PartitionID,
+		containerCreationConfig *services.ContainerCreationConfig,
+		generateRunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error),
+	) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error)
+
+	GetServiceContext(serviceId services.ServiceID) (*services.ServiceContext, error)
+
+	RemoveService(serviceId services.ServiceID, containerStopTimeoutSeconds uint64) error
+
+	RepartitionNetwork(
+		partitionServices map[networks.PartitionID]map[services.ServiceID]bool,
+		partitionConnections map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+		defaultConnection *kurtosis_core_rpc_api_bindings.PartitionConnectionInfo,
+	) error
+
+	WaitForEndpointAvailability(
+		serviceId services.ServiceID,
+		port uint32,
+		path string,
+		initialDelaySeconds uint32,
+		retries uint32,
+		retriesDelayMilliseconds uint32,
+		bodyText string,
+	) error
+}
+
+// Compile-time check that the real Kurtosis network context still satisfies the interface
+var _ NetworkContext = &networks.NetworkContext{}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package network_context
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+)
+
+/*
+The same as testsuite.Test, except that Setup takes the NetworkContext interface so that the test can also be set up
+ against a fake network
+
+Use NewKurtosisTest to get a testsuite.Test that Kurtosis can run.
+*/
+type Test interface {
+	Configure(builder *testsuite.TestConfigurationBuilder)
+
+	Setup(networkCtx NetworkContext) (networks.Network, error)
+
+	Run(network networks.Network) error
+}
+
+/*
+Adapts a Test to the testsuite.Test interface that Kurtosis runs, which hands Setup the real network context
+
+The adapted test also passes teardown on to the test (see test_lifecycle.TeardownableTest), and can be set up with a
+ context that, once cancelled, stops the test's network from adding services (see NewCancellableNetworkContext). The
+ context is passed on to the test's run too, if the test is a test_lifecycle.ContextRunnableTest.
+*/
+func NewKurtosisTest(test Test) testsuite.Test {
+	return &kurtosisTest{underlying: test}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type kurtosisTest struct {
+	underlying Test
+}
+
+func (test kurtosisTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	test.underlying.Configure(builder)
+}
+
+func (test kurtosisTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
+	return test.underlying.Setup(networkCtx)
+}
+
+func (test kurtosisTest) SetupWithContext(ctx context.Context, networkCtx *networks.NetworkContext) (networks.Network, error) {
+	return test.underlying.Setup(NewCancellableNetworkContext(ctx, networkCtx))
+}
+
+func (test kurtosisTest) Run(network networks.Network) error {
+	return test.underlying.Run(network)
+}
+
+func (test kurtosisTest) RunWithContext(ctx context.Context, network networks.Network) error {
+	return test_lifecycle.RunTest(ctx, test.underlying, network)
+}
+
+func (test kurtosisTest) Teardown(network networks.Network) error {
+	return test_lifecycle.TeardownTest(test.underlying, network)
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+type LoadBalancingStrategy int
+
+const (
+	RoundRobin LoadBalancingStrategy = iota
+	Random
+)
+
+const (
+	// A single health poll, because an instance that isn't healthy right now shouldn't be sent traffic right now
+	healthCheckMaxPolls         = 1
+	healthCheckDelayMillisecond = 0
+)
+
+type apiInstance struct {
+	serviceId services.ServiceID
+	client    *services_impl.ApiClient
+}
+
+/*
+Exposes the same calls as the API client, but spreads them across many API services so that tests can exercise
+ horizontal scaling. Instances whose calls fail and whose healthcheck then also fails are taken out of rotation
+ until RefreshHealth is called.
+*/
+type LoadBalancingApiClient struct {
+	// Called on every request, so that API services added or removed at runtime are picked up; must be safe to call
+	//  from any goroutine, as the client is
+	getInstances func() []*apiInstance
+
+	strategy LoadBalancingStrategy
+
+	mutex *sync.Mutex
+
+	random *rand.Rand
+
+	nextRoundRobinIdx int
+
+	unhealthyServiceIds map[services.ServiceID]bool
+
+	// Mapping of service ID -> number of calls sent to that service
+	callCounts map[services.ServiceID]int
+}
+
+func newLoadBalancingApiClient(getInstances func() []*apiInstance, strategy LoadBalancingStrategy) *LoadBalancingApiClient {
+	return &LoadBalancingApiClient{
+		getInstances:        getInstances,
+		strategy:            strategy,
+		mutex:               &sync.Mutex{},
+		random:              rand.New(rand.NewSource(time.Now().UnixNano())),
+		nextRoundRobinIdx:   0,
+		unhealthyServiceIds: map[services.ServiceID]bool{},
+		callCounts:          map[services.ServiceID]int{},
+	}
+}
+
+func (client *LoadBalancingApiClient) AddPerson(id int) error {
+	instance, err := client.pickInstance()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred picking an API service to add person with ID '%v'", id)
+	}
+	if err := instance.client.AddPerson(id); err != nil {
+		client.checkHealthAfterFailure(instance)
+		return stacktrace.Propagate(err, "An error occurred adding person with ID '%v' via API service '%v'", id, instance.serviceId)
+	}
+	return nil
+}
+
+func (client *LoadBalancingApiClient) GetPerson(id int) (services_impl.Person, error) {
+	instance, err := client.pickInstance()
+	if err != nil {
+		return services_impl.Person{}, stacktrace.Propagate(err, "An error occurred picking an API service to get person with ID '%v'", id)
+	}
+	person, err := instance.client.GetPerson(id)
+	if err != nil {
+		client.checkHealthAfterFailure(instance)
+		return services_impl.Person{}, stacktrace.Propagate(err, "An error occurred getting person with ID '%v' via API service '%v'", id, instance.serviceId)
+	}
+	return person, nil
+}
+
+func (client *LoadBalancingApiClient) IncrementBooksRead(id int) error {
+	instance, err := client.pickInstance()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred picking an API service to increment the books read of person with ID '%v'", id)
+	}
+	if err := instance.client.IncrementBooksRead(id); err != nil {
+		client.checkHealthAfterFailure(instance)
+		return stacktrace.Propagate(err, "An error occurred incrementing the books read of person with ID '%v' via API service '%v'", id, instance.serviceId)
+	}
+	return nil
+}
+
+// Re-checks the health of every API service, putting recovered ones back into rotation
+func (client *LoadBalancingApiClient) RefreshHealth() {
+	unhealthyServiceIds := map[services.ServiceID]bool{}
+	for _, instance := range client.getInstances() {
+		if err := instance.client.WaitForHealthy(healthCheckMaxPolls, healthCheckDelayMillisecond); err != nil {
+			logrus.Debugf("API service '%v' is unhealthy: %v", instance.serviceId, err)
+			unhealthyServiceIds[instance.serviceId] = true
+		}
+	}
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+	client.unhealthyServiceIds = unhealthyServiceIds
+}
+
+// Gets how many calls have been sent to each API service, for verifying that load is actually being spread
+func (client *LoadBalancingApiClient) GetCallCounts() map[services.ServiceID]int {
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	result := map[services.ServiceID]int{}
+	for serviceId, count := range client.callCounts {
+		result[serviceId] = count
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (client *LoadBalancingApiClient) pickInstance() (*apiInstance, error) {
+	// Taken before locking, so the client's lock is never held while waiting on the network's
+	instances := client.getInstances()
+
+	client.mutex.Lock()
+	defer client.mutex.Unlock()
+
+	healthyInstances := []*apiInstance{}
+	for _, instance := range instances {
+		if !client.unhealthyServiceIds[instance.serviceId] {
+			healthyInstances = append(healthyInstances, instance)
+		}
+	}
+	if len(healthyInstances) == 0 {
+		return nil, stacktrace.NewError("No healthy API services are available")
+	}
+
+	var result *apiInstance
+	switch client.strategy {
+	case RoundRobin:
+		result = healthyInstances[client.nextRoundRobinIdx%len(healthyInstances)]
+		client.nextRoundRobinIdx = client.nextRoundRobinIdx + 1
+	case Random:
+		result = healthyInstances[client.random.Intn(len(healthyInstances))]
+	default:
+		return nil, stacktrace.NewError("Unrecognized load-balancing strategy '%v'", client.strategy)
+	}
+	client.callCounts[result.serviceId] = client.callCounts[result.serviceId] + 1
+	return result, nil
+}
+
+func (client *LoadBalancingApiClient) checkHealthAfterFailure(instance *apiInstance) {
+	if err := instance.client.WaitForHealthy(healthCheckMaxPolls, healthCheckDelayMillisecond); err != nil {
+		logrus.Warnf("Taking API service '%v' out of rotation because it failed its healthcheck: %v", instance.serviceId, err)
+		client.mutex.Lock()
+		defer client.mutex.Unlock()
+		client.unhealthyServiceIds[instance.serviceId] = true
+	}
+}
@@ -6,231 +6,734 @@
 package networks_impl
 
 import (
-	"encoding/json"
-	"fmt"
-	"github.com/kurtosis-tech/example-microservice/api/api_service_client"
-	"github.com/kurtosis-tech/example-microservice/datastore/datastore_service_client"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fault_proxy"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
 	"github.com/palantir/stacktrace"
 	"github.com/sirupsen/logrus"
-	"os"
+	"net/http"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
-	datastoreImage                        = "kurtosistech/example-microservices_datastore"
-	datastoreServiceId services.ServiceID = "datastore"
-	datastorePort                         = 1323
+	// The ID of the first datastore; datastores that replace it get IDs of the form 'datastore-N'
+	initialDatastoreServiceId services.ServiceID = "datastore"
+	datastoreServiceIdPrefix                     = "datastore-"
 
-	apiServiceImage    = "kurtosistech/example-microservices_api"
 	apiServiceIdPrefix = "api-"
-	apiServicePort     = 2434
 
-	waitForStartupDelayMilliseconds = 1000
-	waitForStartupMaxNumPolls       = 15
-	configFileKey                   = "config-file"
-)
+	faultProxyServiceIdPrefix = "fault-proxy-"
 
-type datastoreConfig struct {
-	DatastoreIp   string `json:"datastoreIp"`
-	DatastorePort int    `json:"datastorePort"`
-}
+	containerStopTimeoutSeconds = 10
+)
 
 //  A custom Network implementation is intended to make test-writing easier by wrapping low-level
 //    NetworkContext calls with custom higher-level business logic
 type TestNetwork struct {
-	networkCtx                *networks.NetworkContext
-	datastoreServiceImage     string
-	apiServiceImage           string
-	datastoreClient           *datastore_service_client.DatastoreClient
-	personModifyingApiClient  *api_service_client.APIClient
-	personRetrievingApiClient *api_service_client.APIClient
-	nextApiServiceId          int
+	networkCtx network_context.NetworkContext
+	images     *service_images.ServiceImages
+
+	// What the clients the network creates send their requests through (the default transport if nil)
+	httpTransport http.RoundTripper
+
+	// Services started while the network is being set up share this waiter, and so the deadline of the test's setup
+	setupReadinessWaiter *readiness.Waiter
+
+	// How long a service-starting operation during the test (e.g. replacing a service) gets for all its services to
+	//  become ready
+	operationTimeout time.Duration
+
+	datastoreServiceId services.ServiceID
+	datastoreImage     string
+	datastoreClient    *services_impl.DatastoreClient
+
+	// IDs of the API services currently in the network, in the order they were added (replacements take the
+	//  place of the service they replaced)
+	apiServiceIds []services.ServiceID
+	apiClients    map[services.ServiceID]*services_impl.ApiClient
+	apiImages     map[services.ServiceID]string
+
+	/*
+	Guards apiServiceIds, apiClients, apiImages, isolatedApiServiceIds, and faultProxies: load-balancing clients read
+	 from the goroutines they're called on, and a test that's torn down may have left its run still going on another
+	 goroutine. The lock is never held while calling Kurtosis, so each operation takes it only around the reads &
+	 writes it makes, and an operation that's interleaved with another sees a consistent (if possibly stale) network.
+	*/
+	apiServicesMutex *sync.RWMutex
+
+	// Only set by SetupDatastoreAndTwoApis
+	personModifyingApiServiceId  services.ServiceID
+	personRetrievingApiServiceId services.ServiceID
+
+	// Service IDs are never reused, so that logs & recorded images can't be confused between a service and its
+	//  replacement
+	nextApiServiceId       int
+	nextDatastoreServiceId int
+
+	// The partition holding the datastore, which new API services join; changes once the network has been repartitioned
+	mainPartitionId networks.PartitionID
+
+	// "Set" of API services currently cut off from the datastore by IsolateApisFromDatastore
+	isolatedApiServiceIds map[services.ServiceID]bool
+
+	// Mapping of upstream service ID -> the fault proxy sitting between that service and the one it calls, which for
+	//  now is always an API service and the datastore
+	faultProxies            map[services.ServiceID]*faultProxyInstance
+	nextFaultProxyServiceId int
+}
+
+type faultProxyInstance struct {
+	serviceId services.ServiceID
+	ipAddr    string
+	client    *fault_proxy.FaultProxyClient
 }
 
-func NewTestNetwork(networkCtx *networks.NetworkContext, datastoreServiceImage string, apiServiceImage string) *TestNetwork {
+/*
+Should be called as the test's setup starts, as the setup timeout is counted from here; later operations that start
+ services get the same amount of time
+*/
+func NewTestNetwork(
+	networkCtx network_context.NetworkContext,
+	images *service_images.ServiceImages,
+	httpTransport http.RoundTripper,
+	setupTimeout time.Duration,
+) *TestNetwork {
 	return &TestNetwork{
-		networkCtx:                networkCtx,
-		datastoreServiceImage:     datastoreServiceImage,
-		apiServiceImage:           apiServiceImage,
-		datastoreClient:           nil,
-		personModifyingApiClient:  nil,
-		personRetrievingApiClient: nil,
-		nextApiServiceId:          0,
+		networkCtx:                   networkCtx,
+		images:                       images,
+		httpTransport:                httpTransport,
+		setupReadinessWaiter:         readiness.NewWaiter(time.Now().Add(setupTimeout)),
+		operationTimeout:             setupTimeout,
+		datastoreServiceId:           "",
+		datastoreImage:               "",
+		datastoreClient:              nil,
+		apiServiceIds:                []services.ServiceID{},
+		apiClients:                   map[services.ServiceID]*services_impl.ApiClient{},
+		apiImages:                    map[services.ServiceID]string{},
+		apiServicesMutex:             &sync.RWMutex{},
+		personModifyingApiServiceId:  "",
+		personRetrievingApiServiceId: "",
+		nextApiServiceId:             0,
+		nextDatastoreServiceId:       0,
+		mainPartitionId:              services_impl.DefaultPartitionId,
+		isolatedApiServiceIds:        map[services.ServiceID]bool{},
+		faultProxies:                 map[services.ServiceID]*faultProxyInstance{},
+		nextFaultProxyServiceId:      0,
 	}
 }
 
 //  Custom network implementations usually have a "setup" method (possibly parameterized) that is used
 //   in the Test.Setup function of each test
 func (network *TestNetwork) SetupDatastoreAndTwoApis() error {
+	if err := network.SetupDatastoreAndNApis(2); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting up the datastore and API services")
+	}
+	apiServiceIds := network.GetApiServiceIds()
+	network.personModifyingApiServiceId = apiServiceIds[0]
+	network.personRetrievingApiServiceId = apiServiceIds[1]
+	return nil
+}
 
+func (network *TestNetwork) SetupDatastoreAndNApis(numApiServices int) error {
 	if network.datastoreClient != nil {
 		return stacktrace.NewError("Cannot add datastore client to network; datastore client already exists!")
 	}
 
-	if network.personModifyingApiClient != nil || network.personRetrievingApiClient != nil {
+	if len(network.GetApiServiceIds()) > 0 {
 		return stacktrace.NewError("Cannot add API services to network; one or more API services already exists")
 	}
 
-	datastoreContainerCreationConfig, datastoreRunConfigFunc := getDatastoreServiceConfigurations()
+	// The API services only depend on the datastore, so they all get started in parallel once it's ready
+	serviceGraph := services_impl.NewServiceGraph()
+	datastoreServiceId := network.getNextDatastoreServiceId()
+	datastoreImage := network.images.GetDatastoreImage()
+	getDatastoreDefinition := func(dependencyContexts map[services.ServiceID]*services.ServiceContext) (services_impl.ServiceDefinition, error) {
+		return services_impl.NewDatastoreServiceDefinition(datastoreImage), nil
+	}
+	if err := serviceGraph.AddService(datastoreServiceId, []services.ServiceID{}, getDatastoreDefinition); err != nil {
+		return stacktrace.Propagate(err, "An error occurred adding the datastore service to the service graph")
+	}
+
+	apiImage := network.images.GetApiImage()
+	getApiDefinition := func(dependencyContexts map[services.ServiceID]*services.ServiceContext) (services_impl.ServiceDefinition, error) {
+		datastoreCtx := dependencyContexts[datastoreServiceId]
+		return services_impl.NewApiServiceDefinition(apiImage, datastoreCtx.GetIPAddress(), services_impl.DatastorePort), nil
+	}
+	apiServiceIds := []services.ServiceID{}
+	for i := 0; i < numApiServices; i++ {
+		serviceId := network.getNextApiServiceId()
+		if err := serviceGraph.AddService(serviceId, []services.ServiceID{datastoreServiceId}, getApiDefinition); err != nil {
+			return stacktrace.Propagate(err, "An error occurred adding API service '%v' to the service graph", serviceId)
+		}
+		apiServiceIds = append(apiServiceIds, serviceId)
+	}
 
-	datastoreServiceContext, hostPortBindings, err := network.networkCtx.AddService(datastoreServiceId, datastoreContainerCreationConfig, datastoreRunConfigFunc)
+	serviceContexts, err := serviceGraph.Start(network.networkCtx, network.images, network.setupReadinessWaiter)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the datastore service")
+		return stacktrace.Propagate(err, "An error occurred starting the datastore and API services")
 	}
 
-	datastoreClient := datastore_service_client.NewDatastoreClient(datastoreServiceContext.GetIPAddress(), datastorePort)
+	network.datastoreServiceId = datastoreServiceId
+	network.datastoreImage = datastoreImage
+	network.datastoreClient = services_impl.NewDatastoreClient(serviceContexts[datastoreServiceId].GetIPAddress(), network.httpTransport)
+	network.apiServicesMutex.Lock()
+	defer network.apiServicesMutex.Unlock()
+	for _, serviceId := range apiServiceIds {
+		network.apiServiceIds = append(network.apiServiceIds, serviceId)
+		network.apiClients[serviceId] = services_impl.NewApiClient(serviceContexts[serviceId].GetIPAddress(), network.httpTransport)
+		network.apiImages[serviceId] = apiImage
+	}
+	return nil
+}
 
-	err = datastoreClient.WaitForHealthy(waitForStartupMaxNumPolls, waitForStartupDelayMilliseconds)
+// Adds a new API service backed by the network's datastore, returning the new service's ID
+func (network *TestNetwork) AddApiService() (services.ServiceID, error) {
+	if network.datastoreClient == nil {
+		return "", stacktrace.NewError("Cannot add API service to network; no datastore client exists")
+	}
+
+	serviceId, err := network.addApiServiceToPartition(
+		network.newOperationReadinessWaiter(),
+		network.images.GetApiImage(),
+		network.mainPartitionId,
+		network.datastoreClient.IpAddr(),
+		network.datastoreClient.Port(),
+	)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred waiting for the datastore service to become available")
+		return "", stacktrace.Propagate(err, "An error occurred adding the API service")
 	}
+	network.apiServicesMutex.Lock()
+	network.apiServiceIds = append(network.apiServiceIds, serviceId)
+	network.apiServicesMutex.Unlock()
+	return serviceId, nil
+}
 
-	logrus.Infof("Added datastore service with host port bindings: %+v", hostPortBindings)
+// Stops an API service and forgets its cached client; its ID is never handed out again
+func (network *TestNetwork) RemoveApiService(serviceId services.ServiceID) error {
+	if _, err := network.GetApiClient(serviceId); err != nil {
+		return stacktrace.NewError("Cannot remove API service '%v'; no API service with that ID exists", serviceId)
+	}
 
-	network.datastoreClient = datastoreClient
+	if err := network.networkCtx.RemoveService(serviceId, containerStopTimeoutSeconds); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing API service '%v'", serviceId)
+	}
+	if proxy, found := network.getFaultProxy(serviceId); found {
+		if err := network.networkCtx.RemoveService(proxy.serviceId, containerStopTimeoutSeconds); err != nil {
+			return stacktrace.Propagate(err, "An error occurred removing fault proxy '%v' of API service '%v'", proxy.serviceId, serviceId)
+		}
+		network.apiServicesMutex.Lock()
+		delete(network.faultProxies, serviceId)
+		network.apiServicesMutex.Unlock()
+	}
+
+	network.apiServicesMutex.Lock()
+	remainingServiceIds := []services.ServiceID{}
+	for _, apiServiceId := range network.apiServiceIds {
+		if apiServiceId != serviceId {
+			remainingServiceIds = append(remainingServiceIds, apiServiceId)
+		}
+	}
+	delete(network.apiClients, serviceId)
+	network.apiServiceIds = remainingServiceIds
+	delete(network.apiImages, serviceId)
+	delete(network.isolatedApiServiceIds, serviceId)
+	network.apiServicesMutex.Unlock()
+
+	if network.personModifyingApiServiceId == serviceId {
+		network.personModifyingApiServiceId = ""
+	}
+	if network.personRetrievingApiServiceId == serviceId {
+		network.personRetrievingApiServiceId = ""
+	}
+	return nil
+}
 
-	personModifyingApiClient, err := network.addApiService()
+/*
+Removes every service in the network (API services & their fault proxies first, then the datastore), so that nothing
+ the test started outlives it; a service that can't be removed doesn't stop the rest from being removed
+
+The network can't be used afterwards.
+*/
+func (network *TestNetwork) Teardown() error {
+	failureDescriptions := []string{}
+	for _, serviceId := range network.GetApiServiceIds() {
+		if err := network.RemoveApiService(serviceId); err != nil {
+			failureDescriptions = append(failureDescriptions, "   "+string(serviceId)+": "+err.Error())
+		}
+	}
+	if network.datastoreServiceId != "" {
+		if err := network.networkCtx.RemoveService(network.datastoreServiceId, containerStopTimeoutSeconds); err != nil {
+			failureDescriptions = append(failureDescriptions, "   "+string(network.datastoreServiceId)+": "+err.Error())
+		} else {
+			network.datastoreServiceId = ""
+			network.datastoreClient = nil
+		}
+	}
+	if len(failureDescriptions) > 0 {
+		return stacktrace.NewError(
+			"%v service(s) couldn't be removed while tearing down the network:\n%v",
+			len(failureDescriptions),
+			strings.Join(failureDescriptions, "\n"),
+		)
+	}
+	return nil
+}
+
+/*
+Simulates the datastore crashing & recovering: a fresh datastore is started and the old one is stopped, leaving the
+ old one in place if the fresh one fails to start. The datastore only keeps data in memory, so the new one starts
+ empty.
+
+The new datastore has a new IP, so every API service is also replaced with one pointing at it (see
+ ReplaceServiceImage for how replacements are tracked). Returns a mapping of old API service ID -> replacement's ID.
+*/
+func (network *TestNetwork) RestartDatastore() (map[services.ServiceID]services.ServiceID, error) {
+	replacementApiServiceIds, err := network.replaceDatastore(network.newOperationReadinessWaiter(), network.datastoreImage)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred restarting the datastore")
+	}
+	return replacementApiServiceIds, nil
+}
+
+/*
+Replaces a service with one running the given image, for rolling upgrades, returning the replacement's ID
+
+Replacements always get a new ID, but take the place of the service they replace everywhere the network tracks it:
+ the order of GetApiServiceIds, the person-modifying/retrieving roles, and which side of a partition it's on. API
+ services are replaced by starting the replacement before stopping the original, so the fleet never shrinks; the
+ datastore is replaced as in RestartDatastore, so its data is lost and every API service is replaced too.
+*/
+func (network *TestNetwork) ReplaceServiceImage(serviceId services.ServiceID, image string) (services.ServiceID, error) {
+	readinessWaiter := network.newOperationReadinessWaiter()
+	if network.datastoreClient != nil && serviceId == network.datastoreServiceId {
+		if _, err := network.replaceDatastore(readinessWaiter, image); err != nil {
+			return "", stacktrace.Propagate(err, "An error occurred replacing the datastore with image '%v'", image)
+		}
+		return network.datastoreServiceId, nil
+	}
+
+	if _, err := network.GetApiClient(serviceId); err != nil {
+		return "", stacktrace.NewError("Cannot replace service '%v'; no datastore or API service with that ID exists", serviceId)
+	}
+	replacementServiceId, err := network.replaceApiService(readinessWaiter, serviceId, image)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred replacing API service '%v' with image '%v'", serviceId, image)
+	}
+	return replacementServiceId, nil
+}
+
+/*
+Puts a fault proxy between an upstream service and the downstream service it calls, returning a client for injecting
+ faults into the traffic between the two. Requires the faultProxyServiceImage param.
+
+The upstream service's config is fixed at startup, so it's replaced (as in ReplaceServiceImage) with one pointing at
+ the proxy; the replacement's ID is returned too. The only calls between services in this network are API services
+ calling the datastore, so those are the only links that can be proxied. If the datastore is later restarted or
+ replaced, the proxy is replaced along with it and its faults are cleared.
+*/
+func (network *TestNetwork) InsertFaultProxy(
+	upstreamServiceId services.ServiceID,
+	downstreamServiceId services.ServiceID,
+) (services.ServiceID, *fault_proxy.FaultProxyClient, error) {
+	if err := network.validateProxiableLink(upstreamServiceId, downstreamServiceId); err != nil {
+		return "", nil, stacktrace.Propagate(err, "Cannot insert a fault proxy between '%v' and '%v'", upstreamServiceId, downstreamServiceId)
+	}
+	if _, found := network.getFaultProxy(upstreamServiceId); found {
+		return "", nil, stacktrace.NewError("Service '%v' already calls '%v' through a fault proxy", upstreamServiceId, downstreamServiceId)
+	}
+
+	readinessWaiter := network.newOperationReadinessWaiter()
+	proxy, err := network.addFaultProxy(readinessWaiter, network.datastoreClient.IpAddr(), network.datastoreClient.Port())
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the person-modifying API client")
+		return "", nil, stacktrace.Propagate(err, "An error occurred adding a fault proxy between '%v' and '%v'", upstreamServiceId, downstreamServiceId)
+	}
+	network.apiServicesMutex.Lock()
+	network.faultProxies[upstreamServiceId] = proxy
+	network.apiServicesMutex.Unlock()
+
+	replacementServiceId, err := network.replaceApiService(readinessWaiter, upstreamServiceId, network.getApiImage(upstreamServiceId))
+	if err != nil {
+		network.apiServicesMutex.Lock()
+		delete(network.faultProxies, upstreamServiceId)
+		network.apiServicesMutex.Unlock()
+		if removeErr := network.networkCtx.RemoveService(proxy.serviceId, containerStopTimeoutSeconds); removeErr != nil {
+			logrus.Errorf("An error occurred removing fault proxy '%v' after failing to use it; it will be left running: %v", proxy.serviceId, removeErr)
+		}
+		return "", nil, stacktrace.Propagate(err, "An error occurred pointing '%v' at fault proxy '%v'", upstreamServiceId, proxy.serviceId)
 	}
-	network.personModifyingApiClient = personModifyingApiClient
+	return replacementServiceId, proxy.client, nil
+}
 
-	personRetrievingApiClient, err := network.addApiService()
+// Shorthand for InsertFaultProxy between an API service and the datastore
+func (network *TestNetwork) InsertFaultProxyBeforeDatastore(apiServiceId services.ServiceID) (services.ServiceID, *fault_proxy.FaultProxyClient, error) {
+	replacementServiceId, proxyClient, err := network.InsertFaultProxy(apiServiceId, network.datastoreServiceId)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding the person-retrieving API client")
+		return "", nil, stacktrace.Propagate(err, "An error occurred inserting a fault proxy before the datastore for API service '%v'", apiServiceId)
+	}
+	return replacementServiceId, proxyClient, nil
+}
+
+/*
+Removes every fault proxy in the network, along with the API services calling through them (which can't reach the
+ datastore without their proxy); each proxy's faults are cleared and its connections reset first, so no request is
+ left stuck behind an injected fault while the services are being stopped
+
+A proxy that can't be released doesn't stop the rest from being released.
+*/
+func (network *TestNetwork) RemoveFaultProxies() error {
+	network.apiServicesMutex.RLock()
+	proxies := map[services.ServiceID]*faultProxyInstance{}
+	for upstreamServiceId, proxy := range network.faultProxies {
+		proxies[upstreamServiceId] = proxy
 	}
-	network.personRetrievingApiClient = personRetrievingApiClient
+	network.apiServicesMutex.RUnlock()
 
+	failureDescriptions := []string{}
+	for upstreamServiceId, proxy := range proxies {
+		if err := releaseFaultProxy(proxy); err != nil {
+			logrus.Warnf("Couldn't clear the faults of fault proxy '%v' before removing it: %v", proxy.serviceId, err)
+		}
+		if err := network.RemoveApiService(upstreamServiceId); err != nil {
+			failureDescriptions = append(failureDescriptions, "   "+string(proxy.serviceId)+": "+err.Error())
+		}
+	}
+	if len(failureDescriptions) > 0 {
+		return stacktrace.NewError(
+			"%v fault proxies couldn't be removed:\n%v",
+			len(failureDescriptions),
+			strings.Join(failureDescriptions, "\n"),
+		)
+	}
 	return nil
 }
 
+func (network *TestNetwork) GetFaultProxyClient(
+	upstreamServiceId services.ServiceID,
+	downstreamServiceId services.ServiceID,
+) (*fault_proxy.FaultProxyClient, error) {
+	proxy, found := network.getFaultProxy(upstreamServiceId)
+	if !found || downstreamServiceId != network.datastoreServiceId {
+		return nil, stacktrace.NewError("Service '%v' doesn't call '%v' through a fault proxy", upstreamServiceId, downstreamServiceId)
+	}
+	return proxy.client, nil
+}
+
 //  Custom network implementations will also usually have getters, to retrieve information about the
 //   services created during setup
-func (network *TestNetwork) GetPersonModifyingApiClient() (*api_service_client.APIClient, error) {
-	if network.personModifyingApiClient == nil {
+func (network *TestNetwork) GetPersonModifyingApiClient() (*services_impl.ApiClient, error) {
+	if network.personModifyingApiServiceId == "" {
 		return nil, stacktrace.NewError("No person-modifying API client exists")
 	}
-	return network.personModifyingApiClient, nil
+	return network.GetApiClient(network.personModifyingApiServiceId)
 }
-func (network *TestNetwork) GetPersonRetrievingApiClient() (*api_service_client.APIClient, error) {
-	if network.personRetrievingApiClient == nil {
+func (network *TestNetwork) GetPersonRetrievingApiClient() (*services_impl.ApiClient, error) {
+	if network.personRetrievingApiServiceId == "" {
 		return nil, stacktrace.NewError("No person-retrieving API client exists")
 	}
-	return network.personRetrievingApiClient, nil
+	return network.GetApiClient(network.personRetrievingApiServiceId)
 }
 
-// ====================================================================================================
-//                                       Private helper functions
-// ====================================================================================================
-func (network *TestNetwork) addApiService() (*api_service_client.APIClient, error) {
+func (network *TestNetwork) GetDatastoreServiceId() services.ServiceID {
+	return network.datastoreServiceId
+}
 
+func (network *TestNetwork) GetDatastoreClient() (*services_impl.DatastoreClient, error) {
 	if network.datastoreClient == nil {
-		return nil, stacktrace.NewError("Cannot add API service to network; no datastore client exists")
-	}
-
-	serviceIdStr := apiServiceIdPrefix + strconv.Itoa(network.nextApiServiceId)
-	network.nextApiServiceId = network.nextApiServiceId + 1
-	serviceId := services.ServiceID(serviceIdStr)
-
-	apiServiceContainerCreationConfig, apiServiceGenerateRunConfigFunc := getApiServiceConfigurations(network)
-
-	apiServiceContext, hostPortBindings, err := network.networkCtx.AddService(serviceId, apiServiceContainerCreationConfig, apiServiceGenerateRunConfigFunc)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the API service")
+		return nil, stacktrace.NewError("No datastore client exists")
 	}
+	return network.datastoreClient, nil
+}
 
-	apiClient := api_service_client.NewAPIClient(apiServiceContext.GetIPAddress(), apiServicePort)
+// Gets the IDs of the API services currently in the network, in the order they were added
+func (network *TestNetwork) GetApiServiceIds() []services.ServiceID {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	return append([]services.ServiceID{}, network.apiServiceIds...)
+}
 
-	err = apiClient.WaitForHealthy(waitForStartupMaxNumPolls, waitForStartupDelayMilliseconds)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred waiting for the api service to become available")
+func (network *TestNetwork) GetApiClient(serviceId services.ServiceID) (*services_impl.ApiClient, error) {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	client, found := network.apiClients[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No API service with ID '%v' exists", serviceId)
 	}
+	return client, nil
+}
 
-	logrus.Infof("Added API service with host port bindings: %+v", hostPortBindings)
-	return apiClient, nil
+/*
+Gets a client that spreads calls across all the healthy API services in the network, including ones added after
+ the client was created
+*/
+func (network *TestNetwork) GetLoadBalancingApiClient(strategy LoadBalancingStrategy) *LoadBalancingApiClient {
+	return newLoadBalancingApiClient(network.getApiInstances, strategy)
 }
 
-func getDatastoreServiceConfigurations() (*services.ContainerCreationConfig, func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)) {
-	datastoreContainerCreationConfig := getDataStoreContainerCreationConfig()
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Every service that an operation starts shares the one deadline, as they do during setup
+func (network *TestNetwork) newOperationReadinessWaiter() *readiness.Waiter {
+	return readiness.NewWaiter(time.Now().Add(network.operationTimeout))
+}
 
-	datastoreRunConfigFunc := getDataStoreRunConfigFunc()
-	return datastoreContainerCreationConfig, datastoreRunConfigFunc
+func (network *TestNetwork) getNextApiServiceId() services.ServiceID {
+	serviceIdStr := apiServiceIdPrefix + strconv.Itoa(network.nextApiServiceId)
+	network.nextApiServiceId = network.nextApiServiceId + 1
+	return services.ServiceID(serviceIdStr)
 }
 
-func getDataStoreContainerCreationConfig() *services.ContainerCreationConfig {
-	containerCreationConfig := services.NewContainerCreationConfigBuilder(
-		datastoreImage,
-	).WithUsedPorts(
-		map[string]bool{fmt.Sprintf("%v/tcp", datastorePort): true},
-	).Build()
-	return containerCreationConfig
+func (network *TestNetwork) getNextDatastoreServiceId() services.ServiceID {
+	result := initialDatastoreServiceId
+	if network.nextDatastoreServiceId > 0 {
+		result = services.ServiceID(datastoreServiceIdPrefix + strconv.Itoa(network.nextDatastoreServiceId))
+	}
+	network.nextDatastoreServiceId = network.nextDatastoreServiceId + 1
+	return result
 }
 
-func getDataStoreRunConfigFunc() func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-	runConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-		return services.NewContainerRunConfigBuilder().Build(), nil
+// Starts an API service pointing at the current datastore and caches its client, but leaves it to the caller to
+//  decide where the new service goes in apiServiceIds
+func (network *TestNetwork) addApiServiceToPartition(
+	readinessWaiter *readiness.Waiter,
+	image string,
+	partitionId networks.PartitionID,
+	datastoreIp string,
+	datastorePort int,
+) (services.ServiceID, error) {
+	serviceId := network.getNextApiServiceId()
+	apiDefinition := services_impl.NewApiServiceDefinition(image, datastoreIp, datastorePort)
+	apiServiceContext, _, err := services_impl.AddServiceToPartition(
+		network.networkCtx,
+		serviceId,
+		partitionId,
+		apiDefinition,
+		network.images,
+		readinessWaiter,
+	)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred adding API service '%v' to partition '%v'", serviceId, partitionId)
 	}
-	return runConfigFunc
+	network.apiServicesMutex.Lock()
+	network.apiClients[serviceId] = services_impl.NewApiClient(apiServiceContext.GetIPAddress(), network.httpTransport)
+	network.apiImages[serviceId] = image
+	network.apiServicesMutex.Unlock()
+	return serviceId, nil
 }
 
-func getApiServiceConfigurations(network *TestNetwork) (*services.ContainerCreationConfig, func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)) {
-	configInitializingFunc := getApiServiceConfigInitializingFunc(network.datastoreClient)
+func (network *TestNetwork) replaceApiService(readinessWaiter *readiness.Waiter, serviceId services.ServiceID, image string) (services.ServiceID, error) {
+	partitionId := network.mainPartitionId
+	network.apiServicesMutex.RLock()
+	_, isIsolated := network.isolatedApiServiceIds[serviceId]
+	network.apiServicesMutex.RUnlock()
+	if isIsolated {
+		partitionId = isolatedPartitionId
+	}
+	// API services with a fault proxy reach the datastore through it
+	datastoreIp := network.datastoreClient.IpAddr()
+	datastorePort := network.datastoreClient.Port()
+	proxy, hasProxy := network.getFaultProxy(serviceId)
+	if hasProxy {
+		datastoreIp = proxy.ipAddr
+		datastorePort = services_impl.FaultProxyListenPort
+	}
+	replacementServiceId, err := network.addApiServiceToPartition(readinessWaiter, image, partitionId, datastoreIp, datastorePort)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred starting the replacement for API service '%v'", serviceId)
+	}
 
-	apiServiceContainerCreationConfig := getApiServiceContainerCreationConfig(configInitializingFunc)
+	network.apiServicesMutex.Lock()
+	for idx, apiServiceId := range network.apiServiceIds {
+		if apiServiceId == serviceId {
+			network.apiServiceIds[idx] = replacementServiceId
+		}
+	}
+	delete(network.apiClients, serviceId)
+	if isIsolated {
+		delete(network.isolatedApiServiceIds, serviceId)
+		network.isolatedApiServiceIds[replacementServiceId] = true
+	}
+	if hasProxy {
+		delete(network.faultProxies, serviceId)
+		network.faultProxies[replacementServiceId] = proxy
+	}
+	delete(network.apiImages, serviceId)
+	network.apiServicesMutex.Unlock()
+	if network.personModifyingApiServiceId == serviceId {
+		network.personModifyingApiServiceId = replacementServiceId
+	}
+	if network.personRetrievingApiServiceId == serviceId {
+		network.personRetrievingApiServiceId = replacementServiceId
+	}
 
-	apiServiceGenerateRunConfigFunc := getApiServiceRunConfigFunc()
-	return apiServiceContainerCreationConfig, apiServiceGenerateRunConfigFunc
+	// The replacement is already fully tracked, so a failure here only leaves a stray container behind
+	if err := network.networkCtx.RemoveService(serviceId, containerStopTimeoutSeconds); err != nil {
+		return "", stacktrace.Propagate(err, "Started replacement '%v' but an error occurred removing API service '%v'", replacementServiceId, serviceId)
+	}
+	logrus.Infof("Replaced API service '%v' with '%v' running image '%v'", serviceId, replacementServiceId, image)
+	return replacementServiceId, nil
 }
 
-func getApiServiceConfigInitializingFunc(datastoreClient *datastore_service_client.DatastoreClient) func(fp *os.File) error {
-	configInitializingFunc := func(fp *os.File) error {
-		logrus.Debugf("Datastore IP: %v , port: %v", datastoreClient.IpAddr(), datastoreClient.Port())
-		configObj := datastoreConfig{
-			DatastoreIp:   datastoreClient.IpAddr(),
-			DatastorePort: datastoreClient.Port(),
+// Replaces the datastore, then every API service (which must be pointed at the new datastore's IP)
+func (network *TestNetwork) replaceDatastore(readinessWaiter *readiness.Waiter, image string) (map[services.ServiceID]services.ServiceID, error) {
+	if network.datastoreClient == nil {
+		return nil, stacktrace.NewError("Cannot replace the datastore; no datastore exists")
+	}
+
+	// The replacement is started before the old datastore is removed, so that if it fails to start the network still
+	//  has a working datastore, which it keeps tracking
+	oldServiceId := network.datastoreServiceId
+	serviceId := network.getNextDatastoreServiceId()
+	datastoreDefinition := services_impl.NewDatastoreServiceDefinition(image)
+	serviceCtx, _, err := services_impl.AddServiceToPartition(
+		network.networkCtx,
+		serviceId,
+		network.mainPartitionId,
+		datastoreDefinition,
+		network.images,
+		readinessWaiter,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred starting datastore '%v' to replace '%v'", serviceId, oldServiceId)
+	}
+	network.datastoreServiceId = serviceId
+	network.datastoreImage = image
+	network.datastoreClient = services_impl.NewDatastoreClient(serviceCtx.GetIPAddress(), network.httpTransport)
+
+	// The replacement is already fully tracked, so a failure here only leaves a stray container behind
+	if err := network.networkCtx.RemoveService(oldServiceId, containerStopTimeoutSeconds); err != nil {
+		return nil, stacktrace.Propagate(err, "Started replacement '%v' but an error occurred removing datastore '%v'", serviceId, oldServiceId)
+	}
+	logrus.Infof("Replaced datastore '%v' with '%v' running image '%v'", oldServiceId, serviceId, image)
+
+	// Fault proxies are pointed at the datastore on startup, so they need replacing too
+	network.apiServicesMutex.RLock()
+	oldProxies := map[services.ServiceID]*faultProxyInstance{}
+	for apiServiceId, oldProxy := range network.faultProxies {
+		oldProxies[apiServiceId] = oldProxy
+	}
+	network.apiServicesMutex.RUnlock()
+	for apiServiceId, oldProxy := range oldProxies {
+		if err := network.networkCtx.RemoveService(oldProxy.serviceId, containerStopTimeoutSeconds); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred removing fault proxy '%v'", oldProxy.serviceId)
 		}
-		configBytes, err := json.Marshal(configObj)
+		newProxy, err := network.addFaultProxy(readinessWaiter, network.datastoreClient.IpAddr(), network.datastoreClient.Port())
 		if err != nil {
-			return stacktrace.Propagate(err, "An error occurred serializing the config to JSON")
+			return nil, stacktrace.Propagate(err, "An error occurred replacing fault proxy '%v' of API service '%v'", oldProxy.serviceId, apiServiceId)
 		}
+		network.apiServicesMutex.Lock()
+		network.faultProxies[apiServiceId] = newProxy
+		network.apiServicesMutex.Unlock()
+	}
 
-		logrus.Debugf("API config JSON: %v", string(configBytes))
-
-		if _, err := fp.Write(configBytes); err != nil {
-			return stacktrace.Propagate(err, "An error occurred writing the serialized config JSON to file")
+	replacementApiServiceIds := map[services.ServiceID]services.ServiceID{}
+	for _, apiServiceId := range network.GetApiServiceIds() {
+		replacementServiceId, err := network.replaceApiService(readinessWaiter, apiServiceId, network.getApiImage(apiServiceId))
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred pointing API service '%v' at the new datastore", apiServiceId)
 		}
+		replacementApiServiceIds[apiServiceId] = replacementServiceId
+	}
+	return replacementApiServiceIds, nil
+}
+
+// Starts a fault proxy pointing at the given downstream service, in the datastore's partition
+func (network *TestNetwork) addFaultProxy(
+	readinessWaiter *readiness.Waiter,
+	downstreamIp string,
+	downstreamPort int,
+) (*faultProxyInstance, error) {
+	image, err := network.images.GetFaultProxyImage()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the fault proxy image")
+	}
+	serviceId := services.ServiceID(faultProxyServiceIdPrefix + strconv.Itoa(network.nextFaultProxyServiceId))
+	network.nextFaultProxyServiceId = network.nextFaultProxyServiceId + 1
+
+	proxyDefinition := services_impl.NewFaultProxyServiceDefinition(image, downstreamIp, downstreamPort)
+	serviceCtx, _, err := services_impl.AddServiceToPartition(
+		network.networkCtx,
+		serviceId,
+		network.mainPartitionId,
+		proxyDefinition,
+		network.images,
+		readinessWaiter,
+	)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred adding fault proxy '%v'", serviceId)
+	}
+	return &faultProxyInstance{
+		serviceId: serviceId,
+		ipAddr:    serviceCtx.GetIPAddress(),
+		client:    services_impl.NewFaultProxyClient(serviceCtx.GetIPAddress(), network.httpTransport),
+	}, nil
+}
 
-		return nil
+// Clears the proxy's faults and resets the connections it's holding (e.g. in a blackhole)
+func releaseFaultProxy(proxy *faultProxyInstance) error {
+	if err := proxy.client.ClearFaults(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred clearing the faults")
 	}
-	return configInitializingFunc
+	if _, err := proxy.client.ResetConnections(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred resetting the connections")
+	}
+	return nil
 }
 
-func getApiServiceContainerCreationConfig(configInitializingFunc func(fp *os.File) error) *services.ContainerCreationConfig {
-	apiServiceContainerCreationConfig := services.NewContainerCreationConfigBuilder(
-		apiServiceImage,
-	).WithUsedPorts(
-		map[string]bool{fmt.Sprintf("%v/tcp", apiServicePort): true},
-	).WithGeneratedFiles(map[string]func(*os.File) error{
-		configFileKey: configInitializingFunc,
-	}).Build()
-	return apiServiceContainerCreationConfig
+// Checks that the upstream service calls the downstream one, so a fault proxy can be put between them
+func (network *TestNetwork) validateProxiableLink(upstreamServiceId services.ServiceID, downstreamServiceId services.ServiceID) error {
+	if network.datastoreClient == nil || downstreamServiceId != network.datastoreServiceId {
+		return stacktrace.NewError("Downstream service '%v' isn't the datastore, which is the only service that other services in the network call", downstreamServiceId)
+	}
+	if _, err := network.GetApiClient(upstreamServiceId); err != nil {
+		return stacktrace.NewError("Upstream service '%v' isn't an API service, which are the only services that call the datastore", upstreamServiceId)
+	}
+	return nil
 }
 
-func getApiServiceRunConfigFunc() func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-	apiServiceRunConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-		configFilepath, found := generatedFileFilepaths[configFileKey]
-		if !found {
-			return nil, stacktrace.NewError("No filepath found for config file key '%v'", configFileKey)
-		}
-		startCmd := []string{
-			"./api.bin",
-			"--config",
-			configFilepath,
-		}
-		result := services.NewContainerRunConfigBuilder().WithCmdOverride(startCmd).Build()
-		return result, nil
+// Gets the IDs of the services that always live in the datastore's partition: the datastore and any fault proxies
+func (network *TestNetwork) getDatastoreSideServiceIds() map[services.ServiceID]bool {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	result := map[services.ServiceID]bool{
+		network.datastoreServiceId: true,
+	}
+	for _, proxy := range network.faultProxies {
+		result[proxy.serviceId] = true
+	}
+	return result
+}
+
+func (network *TestNetwork) getFaultProxy(upstreamServiceId services.ServiceID) (*faultProxyInstance, bool) {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	proxy, found := network.faultProxies[upstreamServiceId]
+	return proxy, found
+}
+
+func (network *TestNetwork) getApiImage(apiServiceId services.ServiceID) string {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	return network.apiImages[apiServiceId]
+}
+
+// Called by load-balancing clients, so works from a snapshot taken under the lock
+func (network *TestNetwork) getApiInstances() []*apiInstance {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	result := []*apiInstance{}
+	for _, serviceId := range network.apiServiceIds {
+		result = append(result, &apiInstance{
+			serviceId: serviceId,
+			client:    network.apiClients[serviceId],
+		})
 	}
-	return apiServiceRunConfigFunc
+	return result
 }
@@ -0,0 +1,118 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+// The outcome of firing concurrent IncrementBooksRead calls at a person
+type ConcurrentIncrementsResult struct {
+	NumIncrements int
+
+	// How many of the calls returned successfully
+	NumSucceeded int
+
+	// Calls that errored may or may not have taken effect
+	NumFailed int
+
+	InitialBooksRead int
+	FinalBooksRead   int
+}
+
+// Gets how many successful increments are missing from the final books read
+func (result ConcurrentIncrementsResult) GetNumLostUpdates() int {
+	return result.InitialBooksRead + result.NumSucceeded - result.FinalBooksRead
+}
+
+// Gets the fraction of successful increments that were lost, between 0 and 1
+func (result ConcurrentIncrementsResult) GetLossRate() float64 {
+	if result.NumSucceeded == 0 {
+		return 0
+	}
+	return float64(result.GetNumLostUpdates()) / float64(result.NumSucceeded)
+}
+
+/*
+Fires the given number of IncrementBooksRead calls for the person all at once, alternating between the
+ person-modifying & person-retrieving API clients, then gets the person's final books read. The person must already
+ exist.
+
+Any service that increments by reading the current value and writing back an incremented one will lose updates here,
+ which shows up as a final books read lower than the initial books read plus the number of successful increments.
+*/
+func (network *TestNetwork) RunConcurrentIncrements(personId int, numIncrements int) (*ConcurrentIncrementsResult, error) {
+	modifyingClient, err := network.GetPersonModifyingApiClient()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the person-modifying API client")
+	}
+	retrievingClient, err := network.GetPersonRetrievingApiClient()
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the person-retrieving API client")
+	}
+	apiClients := []*services_impl.ApiClient{modifyingClient, retrievingClient}
+
+	initialPerson, err := retrievingClient.GetPerson(personId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting person '%v' before incrementing", personId)
+	}
+
+	// Every goroutine reports in as ready, then blocks until the start channel is closed once they all have, so that
+	//  the calls overlap as much as possible
+	goroutinesReady := &sync.WaitGroup{}
+	startChan := make(chan struct{})
+
+	mutex := &sync.Mutex{}
+	numSucceeded := 0
+	incrementsDone := &sync.WaitGroup{}
+	for i := 0; i < numIncrements; i++ {
+		incrementsDone.Add(1)
+		goroutinesReady.Add(1)
+		go func(apiClient *services_impl.ApiClient) {
+			defer incrementsDone.Done()
+			goroutinesReady.Done()
+			<-startChan
+			if err := apiClient.IncrementBooksRead(personId); err != nil {
+				logrus.Debugf("A concurrent increment for person '%v' failed: %v", personId, err)
+				return
+			}
+			mutex.Lock()
+			numSucceeded++
+			mutex.Unlock()
+		}(apiClients[i%len(apiClients)])
+	}
+	goroutinesReady.Wait()
+	logrus.Infof("Firing %v concurrent increments for person '%v' across %v API services...", numIncrements, personId, len(apiClients))
+	close(startChan)
+	incrementsDone.Wait()
+
+	finalPerson, err := retrievingClient.GetPerson(personId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting person '%v' after incrementing", personId)
+	}
+
+	result := &ConcurrentIncrementsResult{
+		NumIncrements:    numIncrements,
+		NumSucceeded:     numSucceeded,
+		NumFailed:        numIncrements - numSucceeded,
+		InitialBooksRead: initialPerson.BooksRead,
+		FinalBooksRead:   finalPerson.BooksRead,
+	}
+	logrus.Infof(
+		"Of %v concurrent increments, %v succeeded & %v failed; books read went from %v to %v, so %v updates (%.1f%%) were lost",
+		result.NumIncrements,
+		result.NumSucceeded,
+		result.NumFailed,
+		result.InitialBooksRead,
+		result.FinalBooksRead,
+		result.GetNumLostUpdates(),
+		result.GetLossRate()*100,
+	)
+	return result, nil
+}
@@ -0,0 +1,183 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks_impl
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"sort"
+	"strings"
+)
+
+// NOTE: These helpers only work in tests that have partitioning enabled in their configuration
+const (
+	// The datastore, plus every API service that can still reach it
+	datastorePartitionId networks.PartitionID = "datastore"
+
+	// The API services cut off from the datastore
+	isolatedPartitionId networks.PartitionID = "isolated"
+)
+
+/*
+Splits the network in two, cutting the given API services off from the datastore while the rest of the API services
+ stay connected to it. API services added while the network is split join the datastore's side.
+*/
+func (network *TestNetwork) IsolateApisFromDatastore(apiServiceIds []services.ServiceID) error {
+	if network.datastoreClient == nil {
+		return stacktrace.NewError("Cannot isolate API services from the datastore; no datastore exists")
+	}
+	if len(apiServiceIds) == 0 {
+		return stacktrace.NewError("Cannot isolate API services from the datastore; no API services were specified")
+	}
+
+	isolatedServiceIds := map[services.ServiceID]bool{}
+	for _, serviceId := range apiServiceIds {
+		if _, err := network.GetApiClient(serviceId); err != nil {
+			return stacktrace.NewError("Cannot isolate API service '%v'; no API service with that ID exists", serviceId)
+		}
+		isolatedServiceIds[serviceId] = true
+	}
+
+	datastorePartitionServiceIds := network.getDatastoreSideServiceIds()
+	for _, serviceId := range network.GetApiServiceIds() {
+		if _, found := isolatedServiceIds[serviceId]; !found {
+			datastorePartitionServiceIds[serviceId] = true
+		}
+	}
+	partitionServices := map[networks.PartitionID]map[services.ServiceID]bool{
+		datastorePartitionId: datastorePartitionServiceIds,
+		isolatedPartitionId:  isolatedServiceIds,
+	}
+	partitionConnections := map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{}
+	// There are only two partitions, so blocking by default is what cuts them off from each other
+	defaultConnection := &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{
+		IsBlocked: true,
+	}
+	if err := network.networkCtx.RepartitionNetwork(partitionServices, partitionConnections, defaultConnection); err != nil {
+		return stacktrace.Propagate(err, "An error occurred repartitioning the network to isolate API services %v", apiServiceIds)
+	}
+
+	network.mainPartitionId = datastorePartitionId
+	network.apiServicesMutex.Lock()
+	network.isolatedApiServiceIds = isolatedServiceIds
+	network.apiServicesMutex.Unlock()
+	logrus.Infof("Isolated API services %v from the datastore", apiServiceIds)
+	return nil
+}
+
+// Reconnects every service in the network, undoing IsolateApisFromDatastore
+func (network *TestNetwork) HealPartition() error {
+	allServiceIds := network.getDatastoreSideServiceIds()
+	for _, serviceId := range network.GetApiServiceIds() {
+		allServiceIds[serviceId] = true
+	}
+	partitionServices := map[networks.PartitionID]map[services.ServiceID]bool{
+		datastorePartitionId: allServiceIds,
+	}
+	partitionConnections := map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{}
+	defaultConnection := &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{
+		IsBlocked: false,
+	}
+	if err := network.networkCtx.RepartitionNetwork(partitionServices, partitionConnections, defaultConnection); err != nil {
+		return stacktrace.Propagate(err, "An error occurred repartitioning the network to heal the partition")
+	}
+
+	network.mainPartitionId = datastorePartitionId
+	network.apiServicesMutex.Lock()
+	network.isolatedApiServiceIds = map[services.ServiceID]bool{}
+	network.apiServicesMutex.Unlock()
+	logrus.Info("Healed the network partition")
+	return nil
+}
+
+// Gets the IDs of the API services currently cut off from the datastore, sorted
+func (network *TestNetwork) GetIsolatedApiServiceIds() []services.ServiceID {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	result := []services.ServiceID{}
+	for serviceId := range network.isolatedApiServiceIds {
+		result = append(result, serviceId)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i] < result[j]
+	})
+	return result
+}
+
+// Gets the IDs of the API services that can currently reach the datastore, in the order they were added
+func (network *TestNetwork) GetConnectedApiServiceIds() []services.ServiceID {
+	network.apiServicesMutex.RLock()
+	defer network.apiServicesMutex.RUnlock()
+	result := []services.ServiceID{}
+	for _, serviceId := range network.apiServiceIds {
+		if _, found := network.isolatedApiServiceIds[serviceId]; !found {
+			result = append(result, serviceId)
+		}
+	}
+	return result
+}
+
+/*
+Verifies that each of the given API services can read the given person from the datastore and sees the expected
+ number of books read, returning an error describing every API service that doesn't
+*/
+func (network *TestNetwork) AssertApisSeeBooksRead(apiServiceIds []services.ServiceID, personId int, expectedBooksRead int) error {
+	failures := []string{}
+	for _, serviceId := range apiServiceIds {
+		client, err := network.GetApiClient(serviceId)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting the client for API service '%v'", serviceId)
+		}
+		person, err := client.GetPerson(personId)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("API service '%v' couldn't get the person: %v", serviceId, err))
+			continue
+		}
+		if person.BooksRead != expectedBooksRead {
+			failures = append(failures, fmt.Sprintf("API service '%v' saw %v books read", serviceId, person.BooksRead))
+		}
+	}
+	if len(failures) > 0 {
+		return stacktrace.NewError(
+			"Expected API services %v to see %v books read for person %v, but:\n%v",
+			apiServiceIds,
+			expectedBooksRead,
+			personId,
+			strings.Join(failures, "\n"),
+		)
+	}
+	return nil
+}
+
+/*
+Verifies that none of the given API services can read the given person (which must exist in the datastore), as is
+ expected of API services that are cut off from the datastore
+*/
+func (network *TestNetwork) AssertApisCannotReachDatastore(apiServiceIds []services.ServiceID, personId int) error {
+	reachingServiceIds := []services.ServiceID{}
+	for _, serviceId := range apiServiceIds {
+		client, err := network.GetApiClient(serviceId)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting the client for API service '%v'", serviceId)
+		}
+		if _, err := client.GetPerson(personId); err == nil {
+			reachingServiceIds = append(reachingServiceIds, serviceId)
+		}
+	}
+	if len(reachingServiceIds) > 0 {
+		return stacktrace.NewError(
+			"Expected API services %v to be cut off from the datastore, but %v could still read person %v",
+			apiServiceIds,
+			reachingServiceIds,
+			personId,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package networks_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fake_network"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/stand_ins"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+const (
+	testName = "testNetworkTest"
+
+	// The stand-ins start in-process, so they're ready long before this
+	setupTimeout = 30 * time.Second
+
+	testPersonId = 23
+
+	// Backed by a stand-in that fails to start
+	unstartableImage = "kurtosistech/unstartable:test"
+)
+
+func TestTestNetwork_SetupDatastoreAndTwoApis(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+
+	require.NoError(t, network.SetupDatastoreAndTwoApis())
+
+	addServiceCalls := networkCtx.GetAddServiceCalls()
+	require.Len(t, addServiceCalls, 3)
+	// The datastore goes first, as both API services depend on it
+	require.Equal(t, initialDatastoreServiceId, addServiceCalls[0].ServiceId)
+	require.Equal(t, stand_ins.FakeDatastoreImage, addServiceCalls[0].ContainerCreationConfig.GetImage())
+	for _, call := range addServiceCalls[1:] {
+		require.Equal(t, stand_ins.FakeApiImage, call.ContainerCreationConfig.GetImage())
+	}
+	require.Equal(t, []services.ServiceID{"api-0", "api-1"}, network.GetApiServiceIds())
+	require.Equal(t, []services.ServiceID{"api-0", "api-1", initialDatastoreServiceId}, networkCtx.GetServiceIds())
+
+	// The clients the network hands out talk to the stand-ins
+	modifyingClient, err := network.GetPersonModifyingApiClient()
+	require.NoError(t, err)
+	require.NoError(t, modifyingClient.AddPerson(testPersonId))
+	retrievingClient, err := network.GetPersonRetrievingApiClient()
+	require.NoError(t, err)
+	_, err = retrievingClient.GetPerson(testPersonId)
+	require.NoError(t, err)
+
+	require.Error(t, network.SetupDatastoreAndTwoApis())
+}
+
+func TestTestNetwork_AddAndRemoveApiServiceAreRecorded(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+	require.NoError(t, network.SetupDatastoreAndNApis(1))
+
+	serviceId, err := network.AddApiService()
+	require.NoError(t, err)
+	addServiceCalls := networkCtx.GetAddServiceCalls()
+	lastCall := addServiceCalls[len(addServiceCalls)-1]
+	require.Equal(t, serviceId, lastCall.ServiceId)
+	require.NotEmpty(t, lastCall.IpAddr)
+
+	require.NoError(t, network.RemoveApiService(serviceId))
+	require.Equal(t, []services.ServiceID{serviceId}, networkCtx.GetRemovedServiceIds())
+	require.Equal(t, []services.ServiceID{"api-0"}, network.GetApiServiceIds())
+}
+
+func TestTestNetwork_PartitionCallsAreRecorded(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+	require.NoError(t, network.SetupDatastoreAndTwoApis())
+	isolatedServiceId := services.ServiceID("api-1")
+
+	require.NoError(t, network.IsolateApisFromDatastore([]services.ServiceID{isolatedServiceId}))
+	repartitionCalls := networkCtx.GetRepartitionCalls()
+	require.Len(t, repartitionCalls, 1)
+	isolateCall := repartitionCalls[0]
+	require.Equal(
+		t,
+		map[networks.PartitionID]map[services.ServiceID]bool{
+			datastorePartitionId: {initialDatastoreServiceId: true, "api-0": true},
+			isolatedPartitionId:  {isolatedServiceId: true},
+		},
+		isolateCall.PartitionServices,
+	)
+	require.True(t, isolateCall.DefaultConnection.IsBlocked)
+	partitionId, err := networkCtx.GetPartitionId(isolatedServiceId)
+	require.NoError(t, err)
+	require.Equal(t, isolatedPartitionId, partitionId)
+	require.Equal(t, []services.ServiceID{isolatedServiceId}, network.GetIsolatedApiServiceIds())
+
+	// API services added while the network is split join the datastore's side
+	newServiceId, err := network.AddApiService()
+	require.NoError(t, err)
+	partitionId, err = networkCtx.GetPartitionId(newServiceId)
+	require.NoError(t, err)
+	require.Equal(t, datastorePartitionId, partitionId)
+
+	require.NoError(t, network.HealPartition())
+	repartitionCalls = networkCtx.GetRepartitionCalls()
+	require.Len(t, repartitionCalls, 2)
+	healCall := repartitionCalls[1]
+	require.Len(t, healCall.PartitionServices, 1)
+	require.Len(t, healCall.PartitionServices[datastorePartitionId], 4)
+	require.False(t, healCall.DefaultConnection.IsBlocked)
+	require.Empty(t, network.GetIsolatedApiServiceIds())
+}
+
+func TestTestNetwork_RejectsInvalidIsolation(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+	require.Error(t, network.IsolateApisFromDatastore([]services.ServiceID{"api-0"}))
+
+	require.NoError(t, network.SetupDatastoreAndTwoApis())
+	require.Error(t, network.IsolateApisFromDatastore([]services.ServiceID{}))
+	require.Error(t, network.IsolateApisFromDatastore([]services.ServiceID{"nonexistent-api"}))
+	require.Empty(t, networkCtx.GetRepartitionCalls())
+}
+
+func TestTestNetwork_RestartDatastoreReplacesEveryService(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+	require.NoError(t, network.SetupDatastoreAndTwoApis())
+
+	replacementApiServiceIds, err := network.RestartDatastore()
+	require.NoError(t, err)
+
+	require.Len(t, replacementApiServiceIds, 2)
+	require.ElementsMatch(t, []services.ServiceID{"datastore-1", "api-2", "api-3"}, networkCtx.GetServiceIds())
+	// The new datastore starts empty, but is reachable through the new API services
+	modifyingClient, err := network.GetPersonModifyingApiClient()
+	require.NoError(t, err)
+	require.NoError(t, modifyingClient.AddPerson(testPersonId))
+	require.NoError(t, network.Teardown())
+}
+
+func TestTestNetwork_FailedDatastoreReplacementKeepsOldDatastore(t *testing.T) {
+	networkCtx, serviceImages := createFakeExampleNetwork(t)
+	networkCtx.WithStandIn(unstartableImage, func(serviceId services.ServiceID) fake_network.StandIn {
+		return &unstartableStandIn{}
+	})
+	network := NewTestNetwork(networkCtx, serviceImages, nil, setupTimeout)
+	require.NoError(t, network.SetupDatastoreAndTwoApis())
+
+	_, err := network.ReplaceServiceImage(initialDatastoreServiceId, unstartableImage)
+	require.Error(t, err)
+
+	require.Empty(t, networkCtx.GetRemovedServiceIds())
+	datastoreClient, err := network.GetDatastoreClient()
+	require.NoError(t, err)
+	require.NoError(t, datastoreClient.Upsert("key", "value"))
+	require.NoError(t, network.Teardown())
+	require.Empty(t, networkCtx.GetServiceIds())
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Creates a fake network whose datastore & API services are backed by stand-ins; it's destroyed when the test ends
+func createFakeExampleNetwork(t *testing.T) (*fake_network.FakeNetworkContext, *service_images.ServiceImages) {
+	networkCtx, serviceImages, err := stand_ins.NewFakeExampleNetwork(test_metadata.NewTestMetadata(testName))
+	require.NoError(t, err)
+	t.Cleanup(func() { networkCtx.Destroy() })
+	return networkCtx, serviceImages
+}
+
+type unstartableStandIn struct{}
+
+func (standIn *unstartableStandIn) Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error {
+	return stacktrace.NewError("Unstartable stand-in")
+}
+
+func (standIn *unstartableStandIn) Stop() error {
+	return nil
+}
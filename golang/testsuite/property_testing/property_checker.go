@@ -0,0 +1,192 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package property_testing
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"strings"
+)
+
+const (
+	defaultNumSequences      = 50
+	defaultMaxSequenceLength = 30
+
+	// Each shrink attempt runs a whole sequence against the system under test, so we cap how long shrinking can take
+	defaultMaxShrinkRuns = 500
+)
+
+// A single operation against the system under test, which must print well enough to replay by hand
+type Operation interface {
+	fmt.Stringer
+}
+
+type OperationGenerator func(random *rand.Rand) Operation
+
+/*
+Runs the operations in order against both the system under test and a reference model, returning an error describing
+ the first point at which the two disagree
+
+The checker calls this many times (once per generated sequence, and again for every shrink attempt), so each call must
+ start from a clean system & model, e.g. by using a fresh keyspace.
+*/
+type SequenceRunner func(operations []Operation) error
+
+/*
+Checks a property of a system by generating random sequences of operations, running each one, and, when one fails,
+ shrinking it to a minimal failing sequence
+
+The sequences are generated deterministically from the seed, so a failure can be replayed by checking again with the
+ seed that the failure reports.
+*/
+type PropertyChecker struct {
+	generateOperation OperationGenerator
+	runSequence       SequenceRunner
+	seed              int64
+	numSequences      int
+	maxSequenceLength int
+	maxShrinkRuns     int
+}
+
+func NewPropertyChecker(generateOperation OperationGenerator, runSequence SequenceRunner, seed int64) *PropertyChecker {
+	return &PropertyChecker{
+		generateOperation: generateOperation,
+		runSequence:       runSequence,
+		seed:              seed,
+		numSequences:      defaultNumSequences,
+		maxSequenceLength: defaultMaxSequenceLength,
+		maxShrinkRuns:     defaultMaxShrinkRuns,
+	}
+}
+
+func (checker *PropertyChecker) WithNumSequences(numSequences int) *PropertyChecker {
+	checker.numSequences = numSequences
+	return checker
+}
+
+func (checker *PropertyChecker) WithMaxSequenceLength(maxSequenceLength int) *PropertyChecker {
+	checker.maxSequenceLength = maxSequenceLength
+	return checker
+}
+
+func (checker *PropertyChecker) WithMaxShrinkRuns(maxShrinkRuns int) *PropertyChecker {
+	checker.maxShrinkRuns = maxShrinkRuns
+	return checker
+}
+
+/*
+Generates & runs the sequences, stopping at the first failure
+
+The returned error reports the seed to replay with and the minimal failing sequence that shrinking found.
+*/
+func (checker PropertyChecker) Check() error {
+	if checker.numSequences < 1 {
+		return stacktrace.NewError("The number of sequences to check must be at least 1, but was %v", checker.numSequences)
+	}
+	if checker.maxSequenceLength < 1 {
+		return stacktrace.NewError("The max sequence length must be at least 1, but was %v", checker.maxSequenceLength)
+	}
+
+	logrus.Infof(
+		"Checking %v random operation sequences of up to %v operations each, with seed %v...",
+		checker.numSequences,
+		checker.maxSequenceLength,
+		checker.seed,
+	)
+	random := rand.New(rand.NewSource(checker.seed))
+	for sequenceIdx := 0; sequenceIdx < checker.numSequences; sequenceIdx++ {
+		sequenceLength := 1 + random.Intn(checker.maxSequenceLength)
+		sequence := []Operation{}
+		for i := 0; i < sequenceLength; i++ {
+			sequence = append(sequence, checker.generateOperation(random))
+		}
+
+		sequenceErr := checker.runSequence(sequence)
+		if sequenceErr == nil {
+			continue
+		}
+
+		logrus.Infof(
+			"Sequence %v of %v failed with %v operations; shrinking it...",
+			sequenceIdx+1,
+			checker.numSequences,
+			len(sequence),
+		)
+		minimalSequence, minimalSequenceErr, numShrinkRuns := checker.shrink(sequence, sequenceErr)
+		logrus.Infof("Shrank the failing sequence to %v operations in %v runs", len(minimalSequence), numShrinkRuns)
+		return stacktrace.Propagate(
+			minimalSequenceErr,
+			"The property failed on sequence %v of %v generated with seed %v (replay by checking with the same "+
+				"seed); shrunk from %v to %v operations, the minimal failing sequence is:\n%v",
+			sequenceIdx+1,
+			checker.numSequences,
+			checker.seed,
+			len(sequence),
+			len(minimalSequence),
+			GetSequenceString(minimalSequence),
+		)
+	}
+	logrus.Infof("All %v sequences passed", checker.numSequences)
+	return nil
+}
+
+// Gets a human-readable form of the sequence, one numbered operation per line
+func GetSequenceString(operations []Operation) string {
+	lines := []string{}
+	for idx, operation := range operations {
+		lines = append(lines, fmt.Sprintf("  %v: %v", idx+1, operation))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+/*
+Shrinks a failing sequence by repeatedly removing chunks of operations, starting with halves and going down to single
+ operations, keeping every removal after which the sequence still fails. Returns the smallest failing sequence found,
+ the error it failed with, and how many runs shrinking took.
+*/
+func (checker PropertyChecker) shrink(sequence []Operation, sequenceErr error) ([]Operation, error, int) {
+	current := sequence
+	currentErr := sequenceErr
+	numRuns := 0
+	chunkSize := len(current) / 2
+	for chunkSize >= 1 && numRuns < checker.maxShrinkRuns {
+		removedAny := false
+		startIdx := 0
+		for startIdx < len(current) && numRuns < checker.maxShrinkRuns {
+			endIdx := startIdx + chunkSize
+			if endIdx > len(current) {
+				endIdx = len(current)
+			}
+			candidate := append(append([]Operation{}, current[:startIdx]...), current[endIdx:]...)
+			if len(candidate) == 0 {
+				startIdx = endIdx
+				continue
+			}
+
+			numRuns++
+			if candidateErr := checker.runSequence(candidate); candidateErr != nil {
+				// The removed chunk wasn't needed for the failure, so the next chunk has slid into its place
+				current = candidate
+				currentErr = candidateErr
+				removedAny = true
+			} else {
+				startIdx = endIdx
+			}
+		}
+		if !removedAny {
+			chunkSize = chunkSize / 2
+		}
+		if chunkSize > len(current)/2 {
+			chunkSize = len(current) / 2
+		}
+	}
+	return current, currentErr, numRuns
+}
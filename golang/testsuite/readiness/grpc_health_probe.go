@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"net"
+	"strconv"
+)
+
+/*
+Considers a service ready once it reports SERVING via the standard gRPC health checking protocol
+ (grpc.health.v1.Health/Check)
+*/
+type GrpcHealthProbe struct {
+	port int
+
+	// The service name to ask about; empty asks about the server as a whole
+	serviceName string
+}
+
+func NewGrpcHealthProbe(port int, serviceName string) *GrpcHealthProbe {
+	return &GrpcHealthProbe{
+		port:        port,
+		serviceName: serviceName,
+	}
+}
+
+func (probe GrpcHealthProbe) Check(ctx context.Context, ipAddr string) error {
+	address := net.JoinHostPort(ipAddr, strconv.Itoa(probe.port))
+	conn, err := grpc.DialContext(ctx, address, grpc.WithInsecure(), grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred dialling the gRPC server at '%v'", address)
+	}
+	defer conn.Close()
+
+	client := grpc_health_v1.NewHealthClient(conn)
+	resp, err := client.Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: probe.serviceName})
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred calling the gRPC health check at '%v'", address)
+	}
+	if resp.GetStatus() != grpc_health_v1.HealthCheckResponse_SERVING {
+		return stacktrace.NewError("gRPC server at '%v' reported status '%v' for service '%v'", address, resp.GetStatus(), probe.serviceName)
+	}
+	return nil
+}
+
+func (probe GrpcHealthProbe) String() string {
+	return fmt.Sprintf("gRPC health check :%v (service '%v')", probe.port, probe.serviceName)
+}
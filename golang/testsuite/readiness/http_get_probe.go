@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+)
+
+// Considers a service ready once an HTTP GET against it returns the expected status code (and, optionally, body)
+type HttpGetProbe struct {
+	port           int
+	path           string
+	expectedStatus int
+	expectedBody   string
+}
+
+func NewHttpGetProbe(port int, path string) *HttpGetProbe {
+	return &HttpGetProbe{
+		port:           port,
+		path:           path,
+		expectedStatus: http.StatusOK,
+		expectedBody:   "",
+	}
+}
+
+func (probe *HttpGetProbe) WithExpectedStatus(expectedStatus int) *HttpGetProbe {
+	probe.expectedStatus = expectedStatus
+	return probe
+}
+
+// If non-empty, the response body must match this exactly for the service to be considered ready
+func (probe *HttpGetProbe) WithExpectedBody(expectedBody string) *HttpGetProbe {
+	probe.expectedBody = expectedBody
+	return probe
+}
+
+func (probe HttpGetProbe) Check(ctx context.Context, ipAddr string) error {
+	url := probe.getUrl(ipAddr)
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred building the request to '%v'", url)
+	}
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the request to '%v'", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != probe.expectedStatus {
+		return stacktrace.NewError("Expected status code %v from '%v' but got %v", probe.expectedStatus, url, resp.StatusCode)
+	}
+	if probe.expectedBody == "" {
+		return nil
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the response body from '%v'", url)
+	}
+	if string(bodyBytes) != probe.expectedBody {
+		return stacktrace.NewError("Expected response body '%v' from '%v' but got '%v'", probe.expectedBody, url, string(bodyBytes))
+	}
+	return nil
+}
+
+func (probe HttpGetProbe) String() string {
+	return fmt.Sprintf("HTTP GET :%v%v (expecting status %v)", probe.port, probe.path, probe.expectedStatus)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (probe HttpGetProbe) getUrl(ipAddr string) string {
+	return fmt.Sprintf("http://%v:%v%v", ipAddr, probe.port, probe.path)
+}
@@ -0,0 +1,43 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package readiness
+
+import (
+	"context"
+)
+
+/*
+A single check of whether a service is ready to receive requests; the Waiter takes care of retrying it, so
+ implementations should make exactly one attempt and respect the context's deadline
+*/
+type Probe interface {
+	// Returns nil if the service at the given IP is ready, or an error describing why it isn't
+	Check(ctx context.Context, ipAddr string) error
+
+	// Human-readable description of what's being checked, used in logs & errors
+	String() string
+}
+
+// Adapts an arbitrary Go function into a Probe, for services whose readiness can't be expressed any other way
+type FuncProbe struct {
+	description string
+	checkFunc   func(ctx context.Context, ipAddr string) error
+}
+
+func NewFuncProbe(description string, checkFunc func(ctx context.Context, ipAddr string) error) *FuncProbe {
+	return &FuncProbe{
+		description: description,
+		checkFunc:   checkFunc,
+	}
+}
+
+func (probe FuncProbe) Check(ctx context.Context, ipAddr string) error {
+	return probe.checkFunc(ctx, ipAddr)
+}
+
+func (probe FuncProbe) String() string {
+	return probe.description
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"net"
+	"strconv"
+)
+
+// Considers a service ready once a TCP connection can be opened to the given port
+type TcpProbe struct {
+	port int
+}
+
+func NewTcpProbe(port int) *TcpProbe {
+	return &TcpProbe{port: port}
+}
+
+func (probe TcpProbe) Check(ctx context.Context, ipAddr string) error {
+	address := net.JoinHostPort(ipAddr, strconv.Itoa(probe.port))
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", address)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening a TCP connection to '%v'", address)
+	}
+	if err := conn.Close(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred closing the TCP connection to '%v'", address)
+	}
+	return nil
+}
+
+func (probe TcpProbe) String() string {
+	return fmt.Sprintf("TCP connect :%v", probe.port)
+}
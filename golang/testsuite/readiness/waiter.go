@@ -0,0 +1,170 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package readiness
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const (
+	defaultInitialDelay      = 100 * time.Millisecond
+	defaultMaxDelay          = 2 * time.Second
+	defaultBackoffMultiplier = 2.0
+
+	// Each delay is randomly shifted by up to this fraction in either direction, so that services started together
+	//  aren't all polled in lockstep
+	defaultJitterFraction = 0.2
+
+	// No single attempt is allowed to eat up the time that retries would otherwise get
+	maxAttemptTimeout = 5 * time.Second
+)
+
+// The outcome of a single probe attempt, kept so that failures can be explained after the fact
+type ProbeAttempt struct {
+	StartTime time.Time     `json:"startTime"`
+	Duration  time.Duration `json:"duration"`
+
+	// Empty if the attempt succeeded
+	Error string `json:"error"`
+}
+
+/*
+Retries probes with exponential backoff & jitter until they pass or the deadline is hit
+
+A single Waiter is shared by everything that starts services for a test's setup, and every wait shares its one
+ absolute deadline, computed from the test's configured setup timeout when setup starts: services started one after
+ another mustn't each get the whole of the setup timeout, or setup could take many times longer than it's allowed to
+*/
+type Waiter struct {
+	deadline          time.Time
+	initialDelay      time.Duration
+	maxDelay          time.Duration
+	backoffMultiplier float64
+	jitterFraction    float64
+
+	mutex   *sync.Mutex
+	random  *rand.Rand
+	history map[services.ServiceID][]ProbeAttempt
+}
+
+func NewWaiter(deadline time.Time) *Waiter {
+	return &Waiter{
+		deadline:          deadline,
+		initialDelay:      defaultInitialDelay,
+		maxDelay:          defaultMaxDelay,
+		backoffMultiplier: defaultBackoffMultiplier,
+		jitterFraction:    defaultJitterFraction,
+		mutex:             &sync.Mutex{},
+		random:            rand.New(rand.NewSource(time.Now().UnixNano())),
+		history:           map[services.ServiceID][]ProbeAttempt{},
+	}
+}
+
+func (waiter *Waiter) WithBackoff(initialDelay time.Duration, maxDelay time.Duration, backoffMultiplier float64) *Waiter {
+	waiter.initialDelay = initialDelay
+	waiter.maxDelay = maxDelay
+	waiter.backoffMultiplier = backoffMultiplier
+	return waiter
+}
+
+// Fraction in [0, 1) by which each delay is randomly shifted
+func (waiter *Waiter) WithJitterFraction(jitterFraction float64) *Waiter {
+	waiter.jitterFraction = jitterFraction
+	return waiter
+}
+
+/*
+Blocks until the probe passes against the given service, or returns the last probe error once the deadline is hit
+
+Waiting stops early with an error if the context is done (e.g. because the test was cancelled).
+*/
+func (waiter *Waiter) WaitForReady(ctx context.Context, serviceId services.ServiceID, ipAddr string, probe Probe) error {
+	delay := waiter.initialDelay
+	numAttempts := 0
+	for {
+		numAttempts++
+		lastErr := waiter.attempt(ctx, serviceId, ipAddr, probe)
+		if lastErr == nil {
+			logrus.Debugf("Service '%v' passed probe '%v' after %v attempt(s)", serviceId, probe, numAttempts)
+			return nil
+		}
+		logrus.Debugf("Service '%v' failed probe '%v' on attempt %v: %v", serviceId, probe, numAttempts, lastErr)
+
+		sleepDuration := waiter.addJitter(delay)
+		if time.Now().Add(sleepDuration).After(waiter.deadline) {
+			return stacktrace.Propagate(
+				lastErr,
+				"Service '%v' didn't pass probe '%v' by the deadline of %v, even after %v attempts",
+				serviceId,
+				probe,
+				waiter.deadline.Format(time.RFC3339),
+				numAttempts,
+			)
+		}
+		select {
+		case <-time.After(sleepDuration):
+		case <-ctx.Done():
+			return stacktrace.Propagate(ctx.Err(), "Stopped waiting for service '%v' to pass probe '%v' after %v attempts", serviceId, probe, numAttempts)
+		}
+
+		delay = time.Duration(float64(delay) * waiter.backoffMultiplier)
+		if delay > waiter.maxDelay {
+			delay = waiter.maxDelay
+		}
+	}
+}
+
+// Gets a copy of every probe attempt made so far, keyed by service ID
+func (waiter *Waiter) GetHistory() map[services.ServiceID][]ProbeAttempt {
+	waiter.mutex.Lock()
+	defer waiter.mutex.Unlock()
+	result := map[services.ServiceID][]ProbeAttempt{}
+	for serviceId, attempts := range waiter.history {
+		result[serviceId] = append([]ProbeAttempt{}, attempts...)
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (waiter *Waiter) attempt(ctx context.Context, serviceId services.ServiceID, ipAddr string, probe Probe) error {
+	attemptDeadline := time.Now().Add(maxAttemptTimeout)
+	if attemptDeadline.After(waiter.deadline) {
+		attemptDeadline = waiter.deadline
+	}
+	attemptCtx, cancelFunc := context.WithDeadline(ctx, attemptDeadline)
+	defer cancelFunc()
+
+	startTime := time.Now()
+	err := probe.Check(attemptCtx, ipAddr)
+	attempt := ProbeAttempt{
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	}
+
+	waiter.mutex.Lock()
+	defer waiter.mutex.Unlock()
+	waiter.history[serviceId] = append(waiter.history[serviceId], attempt)
+	return err
+}
+
+func (waiter *Waiter) addJitter(delay time.Duration) time.Duration {
+	waiter.mutex.Lock()
+	defer waiter.mutex.Unlock()
+	// Uniformly distributed in [-jitterFraction, jitterFraction)
+	shift := (waiter.random.Float64()*2 - 1) * waiter.jitterFraction
+	return time.Duration(float64(delay) * (1 + shift))
+}
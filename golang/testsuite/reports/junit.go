@@ -0,0 +1,176 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package reports
+
+import (
+	"encoding/xml"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/palantir/stacktrace"
+	"sort"
+	"strings"
+)
+
+const (
+	junitTimeFormat = "2006-01-02T15:04:05"
+
+	ownerPropertyName       = "owner"
+	tagPropertyName         = "tag"
+	paramPropertyNamePrefix = "param."
+	imagePropertyNamePrefix = "image."
+
+	stepTreeIndent = "  "
+)
+
+// The JUnit XML schema, as understood by the common CI test report ingesters
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitFailure    `xml:"failure,omitempty"`
+
+	// The test's steps as an indented tree, as JUnit has no notion of steps
+	SystemOut string `xml:"system-out,omitempty"`
+}
+
+type junitProperties struct {
+	Properties []*junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Stack   string `xml:",chardata"`
+}
+
+/*
+Renders the suite report as JUnit XML, with each test's owner, tags, params, and resolved images as testcase
+ properties, a failed test's phase as its failure type, and the test's steps as its system-out
+*/
+func RenderJUnitXml(report *SuiteReport) ([]byte, error) {
+	totalDurationSeconds := 0.0
+	testCases := []*junitTestCase{}
+	for _, testReport := range report.Tests {
+		durationSeconds := testReport.GetDurationSeconds()
+		totalDurationSeconds += durationSeconds
+
+		testCase := &junitTestCase{
+			Name:       testReport.Name,
+			ClassName:  report.SuiteName,
+			Time:       formatJUnitSeconds(durationSeconds),
+			Properties: getJUnitProperties(testReport),
+			Failure:    nil,
+			SystemOut:  getJUnitStepTree(testReport.Steps, 0),
+		}
+		if testReport.Failure != nil {
+			testCase.Failure = &junitFailure{
+				Message: testReport.Failure.Message,
+				Type:    testReport.Failure.Phase,
+				Stack:   testReport.Failure.Stack,
+			}
+		}
+		testCases = append(testCases, testCase)
+	}
+
+	suites := &junitTestSuites{
+		Tests:    report.NumTests,
+		Failures: report.NumFailed,
+		Time:     formatJUnitSeconds(totalDurationSeconds),
+		Suites: []*junitTestSuite{
+			{
+				Name:      report.SuiteName,
+				Tests:     report.NumTests,
+				Failures:  report.NumFailed,
+				Time:      formatJUnitSeconds(totalDurationSeconds),
+				Timestamp: report.GeneratedTime.UTC().Format(junitTimeFormat),
+				TestCases: testCases,
+			},
+		},
+	}
+	xmlBytes, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred serializing the JUnit XML report")
+	}
+	return append([]byte(xml.Header), xmlBytes...), nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func getJUnitProperties(testReport *TestReport) *junitProperties {
+	properties := []*junitProperty{}
+	if testReport.Owner != "" {
+		properties = append(properties, &junitProperty{Name: ownerPropertyName, Value: testReport.Owner})
+	}
+	for _, tag := range testReport.Tags {
+		properties = append(properties, &junitProperty{Name: tagPropertyName, Value: string(tag)})
+	}
+	for _, param := range testReport.Params {
+		properties = append(properties, &junitProperty{Name: paramPropertyNamePrefix + param.Name, Value: param.Value})
+	}
+	serviceIds := []services.ServiceID{}
+	for serviceId := range testReport.ResolvedImages {
+		serviceIds = append(serviceIds, serviceId)
+	}
+	sort.Slice(serviceIds, func(i, j int) bool {
+		return serviceIds[i] < serviceIds[j]
+	})
+	for _, serviceId := range serviceIds {
+		properties = append(properties, &junitProperty{
+			Name:  imagePropertyNamePrefix + string(serviceId),
+			Value: testReport.ResolvedImages[serviceId],
+		})
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return &junitProperties{Properties: properties}
+}
+
+func getJUnitStepTree(steps []*StepReport, depth int) string {
+	result := ""
+	for _, step := range steps {
+		line := fmt.Sprintf(
+			"%v%v (%v in %vs)",
+			strings.Repeat(stepTreeIndent, depth),
+			step.Name,
+			step.Status,
+			formatJUnitSeconds(step.DurationSeconds),
+		)
+		if step.ErrorMessage != "" {
+			line += ": " + step.ErrorMessage
+		}
+		result += line + "\n" + getJUnitStepTree(step.SubSteps, depth+1)
+	}
+	return result
+}
+
+func formatJUnitSeconds(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
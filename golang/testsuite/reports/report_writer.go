@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package reports
+
+import (
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+	"syscall"
+)
+
+const (
+	testReportsDirname  = "tests"
+	suiteReportFilename = "report.json"
+	junitReportFilename = "junit.xml"
+	lockFilename        = ".lock"
+
+	testReportFileExtension = ".json"
+	tempFileSuffix          = ".tmp"
+
+	reportDirPerms  = 0755
+	reportFilePerms = 0644
+)
+
+/*
+Writes the reports for a suite run to a directory:
+ tests/<test name>.json -> each finished test's report
+ report.json -> the suite report, covering every test in tests/
+ junit.xml -> the suite report as JUnit XML
+
+Kurtosis runs each test in its own testsuite container, possibly in parallel, so there's no single process that sees
+ every test finish. Instead, every test writes its own report and then regenerates the suite-wide reports from all the
+ test reports written so far, under a lock on the shared directory; once the last test finishes, the suite-wide reports
+ cover every test. Tests that Kurtosis kills (e.g. for timing out) never get to write a report.
+*/
+type ReportWriter struct {
+	dirpath   string
+	suiteName string
+}
+
+func NewReportWriter(dirpath string, suiteName string) *ReportWriter {
+	return &ReportWriter{dirpath: dirpath, suiteName: suiteName}
+}
+
+// Writes the test's report, then regenerates the suite-wide reports
+func (writer ReportWriter) WriteTestReport(testReport *TestReport) error {
+	testReportsDirpath := path.Join(writer.dirpath, testReportsDirname)
+	if err := os.MkdirAll(testReportsDirpath, reportDirPerms); err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating test reports directory '%v'", testReportsDirpath)
+	}
+
+	testReportBytes, err := json.MarshalIndent(testReport, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred serializing the report for test '%v'", testReport.Name)
+	}
+	testReportFilepath := writer.GetTestReportFilepath(testReport.Name)
+	if err := writeFileAtomically(testReportFilepath, testReportBytes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the report for test '%v'", testReport.Name)
+	}
+
+	if err := writer.regenerateSuiteReports(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred regenerating the suite reports")
+	}
+	return nil
+}
+
+// Gets where the given test's report is written to, whether or not it's been written yet
+func (writer ReportWriter) GetTestReportFilepath(testName string) string {
+	return path.Join(writer.dirpath, testReportsDirname, testName+testReportFileExtension)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (writer ReportWriter) regenerateSuiteReports() error {
+	lockFilepath := path.Join(writer.dirpath, lockFilename)
+	lockFile, err := os.OpenFile(lockFilepath, os.O_CREATE|os.O_RDWR, reportFilePerms)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred opening report lock file '%v'", lockFilepath)
+	}
+	defer lockFile.Close()
+	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
+		return stacktrace.Propagate(err, "An error occurred locking report lock file '%v'", lockFilepath)
+	}
+	defer func() {
+		if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN); err != nil {
+			logrus.Errorf("An error occurred unlocking report lock file '%v':\n%v", lockFilepath, err)
+		}
+	}()
+
+	testReports, err := writer.readTestReports()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the test reports")
+	}
+	suiteReport := NewSuiteReport(writer.suiteName, testReports)
+
+	suiteReportBytes, err := json.MarshalIndent(suiteReport, "", "  ")
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred serializing the suite report")
+	}
+	suiteReportFilepath := path.Join(writer.dirpath, suiteReportFilename)
+	if err := writeFileAtomically(suiteReportFilepath, suiteReportBytes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the suite report")
+	}
+
+	junitBytes, err := RenderJUnitXml(suiteReport)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred rendering the JUnit XML report")
+	}
+	junitFilepath := path.Join(writer.dirpath, junitReportFilename)
+	if err := writeFileAtomically(junitFilepath, junitBytes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the JUnit XML report")
+	}
+	return nil
+}
+
+func (writer ReportWriter) readTestReports() ([]*TestReport, error) {
+	testReportsDirpath := path.Join(writer.dirpath, testReportsDirname)
+	fileInfos, err := ioutil.ReadDir(testReportsDirpath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred listing test reports directory '%v'", testReportsDirpath)
+	}
+	result := []*TestReport{}
+	for _, fileInfo := range fileInfos {
+		if fileInfo.IsDir() || !strings.HasSuffix(fileInfo.Name(), testReportFileExtension) {
+			continue
+		}
+		testReportFilepath := path.Join(testReportsDirpath, fileInfo.Name())
+		testReportBytes, err := ioutil.ReadFile(testReportFilepath)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred reading test report '%v'", testReportFilepath)
+		}
+		testReport := &TestReport{}
+		if err := json.Unmarshal(testReportBytes, testReport); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred deserializing test report '%v'", testReportFilepath)
+		}
+		result = append(result, testReport)
+	}
+	return result, nil
+}
+
+// Writes to a temp file and renames it into place, so that readers never see a partially-written file
+func writeFileAtomically(filepath string, contents []byte) error {
+	tempFilepath := filepath + tempFileSuffix
+	if err := ioutil.WriteFile(tempFilepath, contents, reportFilePerms); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing temp file '%v'", tempFilepath)
+	}
+	if err := os.Rename(tempFilepath, filepath); err != nil {
+		return stacktrace.Propagate(err, "An error occurred renaming temp file '%v' to '%v'", tempFilepath, filepath)
+	}
+	return nil
+}
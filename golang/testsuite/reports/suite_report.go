@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package reports
+
+import (
+	"sort"
+	"time"
+)
+
+// The JSON report for a whole suite run
+type SuiteReport struct {
+	SuiteName     string        `json:"suiteName"`
+	GeneratedTime time.Time     `json:"generatedTime"`
+	NumTests      int           `json:"numTests"`
+	NumPassed     int           `json:"numPassed"`
+	NumFailed     int           `json:"numFailed"`
+	Tests         []*TestReport `json:"tests"`
+}
+
+// Builds a suite report from the reports of the tests that have finished, sorted by test name
+func NewSuiteReport(suiteName string, testReports []*TestReport) *SuiteReport {
+	sortedTestReports := append([]*TestReport{}, testReports...)
+	sort.Slice(sortedTestReports, func(i, j int) bool {
+		return sortedTestReports[i].Name < sortedTestReports[j].Name
+	})
+
+	numPassed := 0
+	numFailed := 0
+	for _, testReport := range sortedTestReports {
+		if testReport.Status == PassedStatus {
+			numPassed++
+		} else {
+			numFailed++
+		}
+	}
+	return &SuiteReport{
+		SuiteName:     suiteName,
+		GeneratedTime: time.Now(),
+		NumTests:      len(sortedTestReports),
+		NumPassed:     numPassed,
+		NumFailed:     numFailed,
+		Tests:         sortedTestReports,
+	}
+}
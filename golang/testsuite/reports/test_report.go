@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package reports
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"time"
+)
+
+const (
+	PassedStatus = "passed"
+	FailedStatus = "failed"
+
+	// The name the test's report is recorded under in the test's artifact paths
+	TestReportArtifactName = "testReport"
+)
+
+type PhaseReport struct {
+	StartTime       time.Time `json:"startTime"`
+	DurationSeconds float64   `json:"durationSeconds"`
+	Passed          bool      `json:"passed"`
+}
+
+type StepReport struct {
+	Name            string                `json:"name"`
+	Phase           string                `json:"phase"`
+	StartTime       time.Time             `json:"startTime"`
+	DurationSeconds float64               `json:"durationSeconds"`
+	Status          test_steps.StepStatus `json:"status"`
+
+	// Empty unless the step failed with an error
+	ErrorMessage string `json:"errorMessage"`
+
+	SubSteps []*StepReport `json:"subSteps"`
+}
+
+type FailureReport struct {
+	Phase string `json:"phase"`
+
+	// The path of the step that broke (e.g. "add person > verify books read"), or empty if no step failed
+	Step string `json:"step"`
+
+	// The error on a single line, without locations
+	Message string `json:"message"`
+
+	// The error with the location of every error in its chain
+	Stack string `json:"stack"`
+}
+
+// Everything about a single finished test, in the JSON report's schema
+type TestReport struct {
+	Name           string                        `json:"name"`
+	Owner          string                        `json:"owner"`
+	Tags           []test_tags.Tag               `json:"tags"`
+	Params         []test_params.Param           `json:"params"`
+	ResolvedImages map[services.ServiceID]string `json:"resolvedImages"`
+	Status         string                        `json:"status"`
+
+	// Nil if the phase never ran
+	Setup *PhaseReport `json:"setup"`
+	Run   *PhaseReport `json:"run"`
+
+	Steps []*StepReport `json:"steps"`
+
+	// Nil if the test passed
+	Failure *FailureReport `json:"failure"`
+}
+
+// Builds the report for a test from its metadata, which must already have the results of the phases that ran
+func NewTestReport(metadata *test_metadata.TestMetadata) *TestReport {
+	stepRecorder := metadata.GetStepRecorder()
+	report := &TestReport{
+		Name:           metadata.GetTestName(),
+		Owner:          metadata.GetOwner(),
+		Tags:           metadata.GetTags(),
+		Params:         metadata.GetParams(),
+		ResolvedImages: metadata.GetResolvedServiceImages(),
+		Status:         PassedStatus,
+		Setup:          nil,
+		Run:            nil,
+		Steps:          getStepReports(stepRecorder.GetSteps()),
+		Failure:        nil,
+	}
+	for _, phaseResult := range metadata.GetPhaseResults() {
+		phaseReport := &PhaseReport{
+			StartTime:       phaseResult.StartTime,
+			DurationSeconds: phaseResult.Duration.Seconds(),
+			Passed:          phaseResult.Error == nil,
+		}
+		switch phaseResult.Phase {
+		case test_lifecycle.SetupPhase:
+			report.Setup = phaseReport
+		case test_lifecycle.RunPhase:
+			report.Run = phaseReport
+		}
+		if phaseResult.Error != nil && report.Failure == nil {
+			report.Status = FailedStatus
+			report.Failure = &FailureReport{
+				Phase:   phaseResult.Phase,
+				Step:    stepRecorder.GetFailingStepPath(),
+				Message: fmt.Sprintf("%#s", phaseResult.Error),
+				Stack:   fmt.Sprintf("%+s", phaseResult.Error),
+			}
+		}
+	}
+	return report
+}
+
+// Gets the total time the test's phases took
+func (report TestReport) GetDurationSeconds() float64 {
+	result := 0.0
+	if report.Setup != nil {
+		result += report.Setup.DurationSeconds
+	}
+	if report.Run != nil {
+		result += report.Run.DurationSeconds
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func getStepReports(records []*test_steps.StepRecord) []*StepReport {
+	result := []*StepReport{}
+	for _, record := range records {
+		result = append(result, &StepReport{
+			Name:            record.Name,
+			Phase:           record.Phase,
+			StartTime:       record.StartTime,
+			DurationSeconds: record.Duration.Seconds(),
+			Status:          record.Status,
+			ErrorMessage:    record.ErrorMessage,
+			SubSteps:        getStepReports(record.SubSteps),
+		})
+	}
+	return result
+}
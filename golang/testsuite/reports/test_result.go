@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package reports
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/assertions"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"github.com/palantir/stacktrace"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+/*
+Adds what the testsuite service can't know to the result it built for a finished test: the step the test failed at, the
+ assertion it failed on, the steps' timings, and the paths of the test's artifacts
+
+A setup or run error caused by a failed assertion is reclassified as an assertion failure.
+*/
+func AddTestResultDetails(metadata *test_metadata.TestMetadata, phaseErr error, result *bindings.TestResult) {
+	stepRecorder := metadata.GetStepRecorder()
+	result.FailingStepPath = stepRecorder.GetFailingStepPath()
+	result.StepTimings = getStepTimings(stepRecorder.GetSteps())
+
+	if result.ArtifactPaths == nil {
+		result.ArtifactPaths = map[string]string{}
+	}
+	for artifactName, filepath := range metadata.GetArtifactPaths() {
+		result.ArtifactPaths[artifactName] = filepath
+	}
+
+	// A test that timed out or was cancelled may have failed an assertion as a result, but the assertion isn't the cause
+	isReclassifiable := result.FailureCategory == bindings.TestResult_SETUP_ERROR || result.FailureCategory == bindings.TestResult_RUN_ERROR
+	isAssertionFailure := phaseErr != nil && stacktrace.GetCode(phaseErr) == assertions.FailureErrorCode
+	if isReclassifiable && isAssertionFailure {
+		result.FailureCategory = bindings.TestResult_ASSERTION
+		result.AssertionFailure = newAssertionFailure(phaseErr)
+	}
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Flattens the step tree, parents before their sub-steps
+func getStepTimings(records []*test_steps.StepRecord) []*bindings.StepTiming {
+	result := []*bindings.StepTiming{}
+	for _, record := range records {
+		result = append(result, &bindings.StepTiming{
+			StepPath:  record.Path,
+			StartTime: timestamppb.New(record.StartTime),
+			Duration:  durationpb.New(record.Duration),
+		})
+		result = append(result, getStepTimings(record.SubSteps)...)
+	}
+	return result
+}
+
+func newAssertionFailure(assertionErr error) *bindings.AssertionFailure {
+	failure, found := assertions.GetFailure(assertionErr)
+	if !found {
+		// E.g. the combined failure of soft assertions, which has no single location
+		return &bindings.AssertionFailure{
+			Detail:      fmt.Sprintf("%#s", stacktrace.RootCause(assertionErr)),
+			Differences: []*bindings.AssertionDifference{},
+		}
+	}
+
+	differences := []*bindings.AssertionDifference{}
+	for _, difference := range failure.Differences {
+		differences = append(differences, &bindings.AssertionDifference{
+			Path:     difference.Path,
+			Expected: difference.Expected,
+			Actual:   difference.Actual,
+		})
+	}
+	return &bindings.AssertionFailure{
+		Location:    failure.Location,
+		Description: failure.Description,
+		Detail:      failure.Detail,
+		Differences: differences,
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package service_images
+
+import (
+	"encoding/hex"
+	"github.com/palantir/stacktrace"
+	"strings"
+)
+
+const (
+	digestSeparator = "@"
+	tagSeparator    = ":"
+	pathSeparator   = "/"
+
+	sha256DigestPrefix    = "sha256:"
+	sha256DigestHexLength = 64
+)
+
+// A Docker image reference, split into its parts so that tag & digest pinning can be validated
+type ImageRef struct {
+	repository string
+
+	// Empty if the image isn't pinned to a tag (which means Docker will use 'latest')
+	tag string
+
+	// Empty if the image isn't pinned to a digest
+	digest string
+}
+
+/*
+Parses an image string of the form 'repository[:tag][@sha256:digest]', where the repository may contain a
+ registry host with a port (e.g. 'localhost:5000/my-org/my-image:1.2.3')
+*/
+func ParseImageRef(imageStr string) (*ImageRef, error) {
+	trimmedImageStr := strings.TrimSpace(imageStr)
+	if trimmedImageStr == "" {
+		return nil, stacktrace.NewError("Image string is empty")
+	}
+	if strings.ContainsAny(trimmedImageStr, " \t\n") {
+		return nil, stacktrace.NewError("Image string '%v' contains whitespace", trimmedImageStr)
+	}
+
+	nameAndTag := trimmedImageStr
+	digest := ""
+	if digestSeparatorIdx := strings.Index(trimmedImageStr, digestSeparator); digestSeparatorIdx != -1 {
+		nameAndTag = trimmedImageStr[:digestSeparatorIdx]
+		digest = trimmedImageStr[digestSeparatorIdx+len(digestSeparator):]
+		if err := validateDigest(digest); err != nil {
+			return nil, stacktrace.Propagate(err, "Image string '%v' has an invalid digest", trimmedImageStr)
+		}
+	}
+
+	repository := nameAndTag
+	tag := ""
+	// A colon before the last slash belongs to a registry port, not a tag
+	lastTagSeparatorIdx := strings.LastIndex(nameAndTag, tagSeparator)
+	if lastTagSeparatorIdx > strings.LastIndex(nameAndTag, pathSeparator) {
+		repository = nameAndTag[:lastTagSeparatorIdx]
+		tag = nameAndTag[lastTagSeparatorIdx+len(tagSeparator):]
+		if tag == "" {
+			return nil, stacktrace.NewError("Image string '%v' has a tag separator but an empty tag", trimmedImageStr)
+		}
+	}
+	if repository == "" {
+		return nil, stacktrace.NewError("Image string '%v' has an empty repository", trimmedImageStr)
+	}
+
+	return &ImageRef{
+		repository: repository,
+		tag:        tag,
+		digest:     digest,
+	}, nil
+}
+
+func (ref ImageRef) GetRepository() string {
+	return ref.repository
+}
+
+func (ref ImageRef) GetTag() string {
+	return ref.tag
+}
+
+func (ref ImageRef) GetDigest() string {
+	return ref.digest
+}
+
+// Whether the image will always resolve to the same build, no matter what gets pushed to the repository later
+func (ref ImageRef) IsDigestPinned() bool {
+	return ref.digest != ""
+}
+
+// Renders the reference in the form that Docker accepts
+func (ref ImageRef) String() string {
+	result := ref.repository
+	if ref.tag != "" {
+		result = result + tagSeparator + ref.tag
+	}
+	if ref.digest != "" {
+		result = result + digestSeparator + ref.digest
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func validateDigest(digest string) error {
+	if !strings.HasPrefix(digest, sha256DigestPrefix) {
+		return stacktrace.NewError("Digest '%v' doesn't start with '%v'", digest, sha256DigestPrefix)
+	}
+	digestHex := strings.TrimPrefix(digest, sha256DigestPrefix)
+	if len(digestHex) != sha256DigestHexLength {
+		return stacktrace.NewError("Digest '%v' should have %v hex characters but has %v", digest, sha256DigestHexLength, len(digestHex))
+	}
+	if _, err := hex.DecodeString(digestHex); err != nil {
+		return stacktrace.Propagate(err, "Digest '%v' isn't valid hex", digest)
+	}
+	return nil
+}
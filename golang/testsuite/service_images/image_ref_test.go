@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package service_images
+
+import (
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+)
+
+var testDigest = "sha256:" + strings.Repeat("ab", sha256DigestHexLength/2)
+
+func TestParseImageRef_ValidImages(t *testing.T) {
+	testCases := []struct {
+		imageStr           string
+		expectedRepository string
+		expectedTag        string
+		expectedDigest     string
+		expectedString     string
+	}{
+		{"my-image", "my-image", "", "", "my-image"},
+		{"my-org/my-image:1.2.3", "my-org/my-image", "1.2.3", "", "my-org/my-image:1.2.3"},
+		{"  my-org/my-image:1.2.3\n", "my-org/my-image", "1.2.3", "", "my-org/my-image:1.2.3"},
+		{"localhost:5000/my-image", "localhost:5000/my-image", "", "", "localhost:5000/my-image"},
+		{"localhost:5000/my-org/my-image:1.2.3", "localhost:5000/my-org/my-image", "1.2.3", "", "localhost:5000/my-org/my-image:1.2.3"},
+		{"my-image@" + testDigest, "my-image", "", testDigest, "my-image@" + testDigest},
+		{"my-image:1.2.3@" + testDigest, "my-image", "1.2.3", testDigest, "my-image:1.2.3@" + testDigest},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.imageStr, func(t *testing.T) {
+			ref, err := ParseImageRef(testCase.imageStr)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedRepository, ref.GetRepository())
+			require.Equal(t, testCase.expectedTag, ref.GetTag())
+			require.Equal(t, testCase.expectedDigest, ref.GetDigest())
+			require.Equal(t, testCase.expectedDigest != "", ref.IsDigestPinned())
+			require.Equal(t, testCase.expectedString, ref.String())
+		})
+	}
+}
+
+func TestParseImageRef_InvalidImages(t *testing.T) {
+	invalidImageStrs := map[string]string{
+		"empty":                      "",
+		"only whitespace":            "   ",
+		"inner whitespace":           "my org/my-image",
+		"empty tag":                  "my-image:",
+		"empty repository":           ":1.2.3",
+		"digest without algorithm":   "my-image@" + strings.TrimPrefix(testDigest, sha256DigestPrefix),
+		"digest too short":           "my-image@sha256:abcd",
+		"digest isn't hex":           "my-image@sha256:" + strings.Repeat("zz", sha256DigestHexLength/2),
+		"empty repository w/ digest": "@" + testDigest,
+	}
+	for name, imageStr := range invalidImageStrs {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseImageRef(imageStr)
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package service_images
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+// Images to use for a single test instead of the suite-wide defaults; emptystring means "use the default"
+type ImageOverrides struct {
+	DatastoreImage string
+	ApiImage       string
+}
+
+type parsedImageOverrides struct {
+	datastoreImage *ImageRef
+	apiImage       *ImageRef
+}
+
+// Resolves the images every test should use, from the suite-wide defaults and any per-test overrides
+type ImageResolver struct {
+	defaultDatastoreImage *ImageRef
+	defaultApiImage       *ImageRef
+
+	// Nil if none was configured; fault proxies are test infrastructure, so there are no per-test overrides
+	faultProxyImage *ImageRef
+
+	// Mapping of test name -> overrides for that test
+	testOverrides map[string]*parsedImageOverrides
+}
+
+/*
+Parses & validates all the images up front, so that a typo in an override for a test that runs late in the suite
+ fails immediately rather than after all the other tests have run. The fault proxy image may be emptystring.
+*/
+func NewImageResolver(
+	defaultDatastoreImageStr string,
+	defaultApiImageStr string,
+	faultProxyImageStr string,
+	testOverrides map[string]ImageOverrides,
+) (*ImageResolver, error) {
+	defaultDatastoreImage, err := ParseImageRef(defaultDatastoreImageStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the default datastore image")
+	}
+	defaultApiImage, err := ParseImageRef(defaultApiImageStr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the default API image")
+	}
+
+	var faultProxyImage *ImageRef
+	if faultProxyImageStr != "" {
+		faultProxyImage, err = ParseImageRef(faultProxyImageStr)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred parsing the fault proxy image")
+		}
+	}
+
+	parsedTestOverrides := map[string]*parsedImageOverrides{}
+	for testName, overrides := range testOverrides {
+		parsedOverrides := &parsedImageOverrides{}
+		if overrides.DatastoreImage != "" {
+			datastoreImage, err := ParseImageRef(overrides.DatastoreImage)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred parsing the datastore image override for test '%v'", testName)
+			}
+			parsedOverrides.datastoreImage = datastoreImage
+		}
+		if overrides.ApiImage != "" {
+			apiImage, err := ParseImageRef(overrides.ApiImage)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred parsing the API image override for test '%v'", testName)
+			}
+			parsedOverrides.apiImage = apiImage
+		}
+		parsedTestOverrides[testName] = parsedOverrides
+	}
+
+	return &ImageResolver{
+		defaultDatastoreImage: defaultDatastoreImage,
+		defaultApiImage:       defaultApiImage,
+		faultProxyImage:       faultProxyImage,
+		testOverrides:         parsedTestOverrides,
+	}, nil
+}
+
+// Whether the fault proxy image was configured, which tests that start fault proxies can't run without
+func (resolver *ImageResolver) HasFaultProxyImage() bool {
+	return resolver.faultProxyImage != nil
+}
+
+// Gets the test names that have overrides, so callers can verify that every override refers to a real test
+func (resolver *ImageResolver) GetOverriddenTestNames() map[string]bool {
+	result := map[string]bool{}
+	for testName := range resolver.testOverrides {
+		result[testName] = true
+	}
+	return result
+}
+
+func (resolver *ImageResolver) ResolveForTest(metadata *test_metadata.TestMetadata) *ServiceImages {
+	testName := metadata.GetTestName()
+
+	datastoreImage := resolver.defaultDatastoreImage
+	apiImage := resolver.defaultApiImage
+	if overrides, found := resolver.testOverrides[testName]; found {
+		if overrides.datastoreImage != nil {
+			datastoreImage = overrides.datastoreImage
+		}
+		if overrides.apiImage != nil {
+			apiImage = overrides.apiImage
+		}
+	}
+
+	for _, image := range []*ImageRef{datastoreImage, apiImage} {
+		if !image.IsDigestPinned() {
+			logrus.Debugf("Image '%v' used by test '%v' isn't pinned to a digest, so the build being tested may change if the tag is re-pushed", image, testName)
+		}
+	}
+
+	return NewServiceImages(datastoreImage, apiImage, resolver.faultProxyImage, metadata)
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package service_images
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/palantir/stacktrace"
+)
+
+/*
+A test that starts fault proxies, and so can only run if the faultProxyServiceImage param was given; suites should
+ leave such tests out when it wasn't, rather than letting them fail
+*/
+type FaultProxyUsingTest interface {
+	UsesFaultProxies() bool
+}
+
+// The images that a single test's services should be started with, after per-test overrides have been applied
+type ServiceImages struct {
+	datastoreImage *ImageRef
+	apiImage       *ImageRef
+
+	// Nil if no fault proxy image was configured
+	faultProxyImage *ImageRef
+
+	// Where the images that services actually got started with are recorded
+	metadata *test_metadata.TestMetadata
+}
+
+func NewServiceImages(
+	datastoreImage *ImageRef,
+	apiImage *ImageRef,
+	faultProxyImage *ImageRef,
+	metadata *test_metadata.TestMetadata,
+) *ServiceImages {
+	return &ServiceImages{
+		datastoreImage:  datastoreImage,
+		apiImage:        apiImage,
+		faultProxyImage: faultProxyImage,
+		metadata:        metadata,
+	}
+}
+
+func (images *ServiceImages) GetDatastoreImage() string {
+	return images.datastoreImage.String()
+}
+
+func (images *ServiceImages) GetApiImage() string {
+	return images.apiImage.String()
+}
+
+// The fault proxy image is optional, so tests that need it get an error if the suite wasn't given one
+func (images *ServiceImages) GetFaultProxyImage() (string, error) {
+	if images.faultProxyImage == nil {
+		return "", stacktrace.NewError("No fault proxy image was configured; set the faultProxyServiceImage param to use fault proxies")
+	}
+	return images.faultProxyImage.String(), nil
+}
+
+// Should be called every time a service is started, so the test's metadata reflects exactly which build was tested
+func (images *ServiceImages) RecordServiceImage(serviceId services.ServiceID, image string) {
+	images.metadata.RecordResolvedServiceImage(serviceId, image)
+}
+
+/*
+Should be called every time a service is started (whether or not it becomes ready), so that a failing test's
+ diagnostics show everything it started; lives here because ServiceImages is the per-test handle that everything
+ starting services already has
+*/
+func (images *ServiceImages) RecordStartedService(record *test_metadata.ServiceRecord) {
+	images.metadata.RecordStartedService(record)
+}
@@ -0,0 +1,156 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+const (
+	personUrlPathPrefix             = "/person/"
+	incrementBooksReadUrlPathPrefix = "/incrementBooksRead/"
+
+	textContentType = "text/plain"
+
+	// Low, so that tests that need timeouts (like network partition tests) complete quickly
+	clientTimeout = 2 * time.Second
+)
+
+// A person, as the API service serializes them
+type Person struct {
+	BooksRead int
+}
+
+/*
+A client for the example microservice's API service, speaking the same HTTP API as the example microservice's own client
+ library but sending its requests through a transport we control (e.g. a diagnostics.RequestRecorder)
+*/
+type ApiClient struct {
+	httpClient *http.Client
+	ipAddr     string
+	port       int
+}
+
+// The client's requests go through the given transport, or http.DefaultTransport if nil
+func NewApiClient(ipAddr string, transport http.RoundTripper) *ApiClient {
+	return &ApiClient{
+		httpClient: newExampleHttpClient(transport),
+		ipAddr:     ipAddr,
+		port:       ApiServicePort,
+	}
+}
+
+func (client *ApiClient) AddPerson(id int) error {
+	resp, err := client.httpClient.Post(client.getUrl(personUrlPathPrefix, id), textContentType, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the request to add person with ID '%v'", id)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return stacktrace.NewError("Adding person with ID '%v' returned non-OK status code %v", id, resp.StatusCode)
+	}
+	return nil
+}
+
+func (client *ApiClient) GetPerson(id int) (Person, error) {
+	resp, err := client.httpClient.Get(client.getUrl(personUrlPathPrefix, id))
+	if err != nil {
+		return Person{}, stacktrace.Propagate(err, "An error occurred making the request to get person with ID '%v'", id)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Person{}, stacktrace.NewError("Getting person with ID '%v' returned non-OK status code %v", id, resp.StatusCode)
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Person{}, stacktrace.Propagate(err, "An error occurred reading the response body")
+	}
+	var person Person
+	if err := json.Unmarshal(bodyBytes, &person); err != nil {
+		return Person{}, stacktrace.Propagate(err, "An error occurred deserializing the Person JSON")
+	}
+	return person, nil
+}
+
+func (client *ApiClient) IncrementBooksRead(id int) error {
+	resp, err := client.httpClient.Post(client.getUrl(incrementBooksReadUrlPathPrefix, id), textContentType, nil)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred making the request to increment the books read of person with ID '%v'", id)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return stacktrace.NewError("Incrementing the books read of person with ID '%v' returned non-OK status code %v", id, resp.StatusCode)
+	}
+	return nil
+}
+
+// Polls the service's healthcheck endpoint until it reports healthy, up to the given number of times
+func (client *ApiClient) WaitForHealthy(retries uint32, retriesDelayMilliseconds uint32) error {
+	url := fmt.Sprintf("http://%v:%v%v", client.ipAddr, client.port, healthcheckUrlPath)
+	if err := waitForHealthy(client.httpClient, url, retries, retriesDelayMilliseconds); err != nil {
+		return stacktrace.Propagate(err, "The API service at '%v' didn't become healthy", url)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (client *ApiClient) getUrl(urlPathPrefix string, id int) string {
+	return fmt.Sprintf("http://%v:%v%v%v", client.ipAddr, client.port, urlPathPrefix, id)
+}
+
+// A nil transport leaves the http.Client on http.DefaultTransport
+func newExampleHttpClient(transport http.RoundTripper) *http.Client {
+	return &http.Client{
+		Transport: transport,
+		Timeout:   clientTimeout,
+	}
+}
+
+func waitForHealthy(httpClient *http.Client, url string, retries uint32, retriesDelayMilliseconds uint32) error {
+	var lastErr error
+	for i := uint32(0); i < retries; i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(retriesDelayMilliseconds) * time.Millisecond)
+		}
+		lastErr = checkHealthy(httpClient, url)
+		if lastErr == nil {
+			return nil
+		}
+	}
+	return stacktrace.Propagate(
+		lastErr,
+		"The HTTP endpoint '%v' didn't report healthy, even after %v retries with %v milliseconds in between retries",
+		url,
+		retries,
+		retriesDelayMilliseconds,
+	)
+}
+
+func checkHealthy(httpClient *http.Client, url string) error {
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return stacktrace.Propagate(err, "An HTTP error occurred when sending GET request to endpoint '%v'", url)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return stacktrace.NewError("Received non-OK status code: '%v'", resp.StatusCode)
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred reading the response body")
+	}
+	if bodyStr := string(bodyBytes); bodyStr != healthyValue {
+		return stacktrace.NewError("Expected response body text '%v' from endpoint '%v' but got '%v' instead", healthyValue, url, bodyStr)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"os"
+)
+
+const (
+	ApiServicePort = 2434
+
+	configFileKey = "config-file"
+)
+
+// The format of the config file that the API service reads on startup
+type datastoreConfig struct {
+	DatastoreIp   string `json:"datastoreIp"`
+	DatastorePort int    `json:"datastorePort"`
+}
+
+type ApiServiceDefinition struct {
+	image         string
+	datastoreIp   string
+	datastorePort int
+}
+
+func NewApiServiceDefinition(image string, datastoreIp string, datastorePort int) *ApiServiceDefinition {
+	return &ApiServiceDefinition{
+		image:         image,
+		datastoreIp:   datastoreIp,
+		datastorePort: datastorePort,
+	}
+}
+
+func (definition ApiServiceDefinition) GetImage() string {
+	return definition.image
+}
+
+func (definition ApiServiceDefinition) GetUsedPorts() map[string]bool {
+	return map[string]bool{fmt.Sprintf("%v/tcp", ApiServicePort): true}
+}
+
+func (definition ApiServiceDefinition) GetGeneratedFiles() map[string]func(*os.File) error {
+	return map[string]func(*os.File) error{
+		configFileKey: definition.initializeConfigFile,
+	}
+}
+
+func (definition ApiServiceDefinition) GetRunConfigFunc() RunConfigFunc {
+	return func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+		configFilepath, found := generatedFileFilepaths[configFileKey]
+		if !found {
+			return nil, stacktrace.NewError("No filepath found for config file key '%v'", configFileKey)
+		}
+		startCmd := []string{
+			"./api.bin",
+			"--config",
+			configFilepath,
+		}
+		result := services.NewContainerRunConfigBuilder().WithCmdOverride(startCmd).Build()
+		return result, nil
+	}
+}
+
+func (definition ApiServiceDefinition) GetReadinessProbe() readiness.Probe {
+	return readiness.NewHttpGetProbe(ApiServicePort, healthcheckUrlPath).WithExpectedBody(healthyValue)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (definition ApiServiceDefinition) initializeConfigFile(fp *os.File) error {
+	logrus.Debugf("Datastore IP: %v , port: %v", definition.datastoreIp, definition.datastorePort)
+	configObj := datastoreConfig{
+		DatastoreIp:   definition.datastoreIp,
+		DatastorePort: definition.datastorePort,
+	}
+	configBytes, err := json.Marshal(configObj)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred serializing the config to JSON")
+	}
+
+	logrus.Debugf("API config JSON: %v", string(configBytes))
+
+	if _, err := fp.Write(configBytes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred writing the serialized config JSON to file")
+	}
+
+	return nil
+}
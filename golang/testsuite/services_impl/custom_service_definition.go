@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"os"
+)
+
+// A definition for services that aren't part of the catalog, assembled with CustomServiceDefinitionBuilder
+type CustomServiceDefinition struct {
+	image          string
+	usedPorts      map[string]bool
+	generatedFiles map[string]func(*os.File) error
+	runConfigFunc  RunConfigFunc
+	readinessProbe readiness.Probe
+}
+
+func (definition CustomServiceDefinition) GetImage() string {
+	return definition.image
+}
+
+func (definition CustomServiceDefinition) GetUsedPorts() map[string]bool {
+	return definition.usedPorts
+}
+
+func (definition CustomServiceDefinition) GetGeneratedFiles() map[string]func(*os.File) error {
+	return definition.generatedFiles
+}
+
+func (definition CustomServiceDefinition) GetRunConfigFunc() RunConfigFunc {
+	return definition.runConfigFunc
+}
+
+func (definition CustomServiceDefinition) GetReadinessProbe() readiness.Probe {
+	return definition.readinessProbe
+}
+
+// ====================================================================================================
+//                                              Builder
+// ====================================================================================================
+type CustomServiceDefinitionBuilder struct {
+	image          string
+	usedPorts      map[string]bool
+	generatedFiles map[string]func(*os.File) error
+	runConfigFunc  RunConfigFunc
+	readinessProbe readiness.Probe
+}
+
+func NewCustomServiceDefinitionBuilder(image string) *CustomServiceDefinitionBuilder {
+	return &CustomServiceDefinitionBuilder{
+		image:          image,
+		usedPorts:      map[string]bool{},
+		generatedFiles: map[string]func(*os.File) error{},
+		runConfigFunc: func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+			return services.NewContainerRunConfigBuilder().Build(), nil
+		},
+		// By default the service is considered ready as soon as it's started
+		readinessProbe: nil,
+	}
+}
+
+func (builder *CustomServiceDefinitionBuilder) WithUsedPorts(usedPorts map[string]bool) *CustomServiceDefinitionBuilder {
+	builder.usedPorts = usedPorts
+	return builder
+}
+
+func (builder *CustomServiceDefinitionBuilder) WithGeneratedFiles(generatedFiles map[string]func(*os.File) error) *CustomServiceDefinitionBuilder {
+	builder.generatedFiles = generatedFiles
+	return builder
+}
+
+func (builder *CustomServiceDefinitionBuilder) WithRunConfigFunc(runConfigFunc RunConfigFunc) *CustomServiceDefinitionBuilder {
+	builder.runConfigFunc = runConfigFunc
+	return builder
+}
+
+func (builder *CustomServiceDefinitionBuilder) WithReadinessProbe(readinessProbe readiness.Probe) *CustomServiceDefinitionBuilder {
+	builder.readinessProbe = readinessProbe
+	return builder
+}
+
+func (builder CustomServiceDefinitionBuilder) Build() *CustomServiceDefinition {
+	return &CustomServiceDefinition{
+		image:          builder.image,
+		usedPorts:      builder.usedPorts,
+		generatedFiles: builder.generatedFiles,
+		runConfigFunc:  builder.runConfigFunc,
+		readinessProbe: builder.readinessProbe,
+	}
+}
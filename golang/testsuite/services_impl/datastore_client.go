@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const (
+	keyUrlPathPrefix = "/key/"
+)
+
+/*
+A client for the example microservice's datastore service, speaking the same HTTP API as the example microservice's
+ own client library but sending its requests through a transport we control (e.g. a diagnostics.RequestRecorder)
+*/
+type DatastoreClient struct {
+	httpClient *http.Client
+	ipAddr     string
+	port       int
+}
+
+// The client's requests go through the given transport, or http.DefaultTransport if nil
+func NewDatastoreClient(ipAddr string, transport http.RoundTripper) *DatastoreClient {
+	return &DatastoreClient{
+		httpClient: newExampleHttpClient(transport),
+		ipAddr:     ipAddr,
+		port:       DatastorePort,
+	}
+}
+
+func (client *DatastoreClient) IpAddr() string {
+	return client.ipAddr
+}
+
+func (client *DatastoreClient) Port() int {
+	return client.port
+}
+
+func (client *DatastoreClient) Exists(key string) (bool, error) {
+	resp, err := client.httpClient.Get(client.getUrlForKey(key))
+	if err != nil {
+		return false, stacktrace.Propagate(err, "An error occurred requesting data for key '%v'", key)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, stacktrace.NewError("Checking if key '%v' exists returned unexpected status code %v", key, resp.StatusCode)
+	}
+}
+
+func (client *DatastoreClient) Get(key string) (string, error) {
+	resp, err := client.httpClient.Get(client.getUrlForKey(key))
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred requesting data for key '%v'", key)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", stacktrace.NewError("Getting key '%v' returned non-OK status code %v", key, resp.StatusCode)
+	}
+	bodyBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred reading the response body")
+	}
+	return string(bodyBytes), nil
+}
+
+func (client *DatastoreClient) Upsert(key string, value string) error {
+	resp, err := client.httpClient.Post(client.getUrlForKey(key), textContentType, strings.NewReader(value))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred requesting to upsert data '%v' to key '%v'", value, key)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return stacktrace.NewError("Upserting key '%v' returned non-OK status code %v", key, resp.StatusCode)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (client *DatastoreClient) getUrlForKey(key string) string {
+	return fmt.Sprintf("http://%v:%v%v%v", client.ipAddr, client.port, keyUrlPathPrefix, key)
+}
@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"os"
+)
+
+const (
+	DatastorePort = 1323
+)
+
+type DatastoreServiceDefinition struct {
+	image string
+}
+
+func NewDatastoreServiceDefinition(image string) *DatastoreServiceDefinition {
+	return &DatastoreServiceDefinition{image: image}
+}
+
+func (definition DatastoreServiceDefinition) GetImage() string {
+	return definition.image
+}
+
+func (definition DatastoreServiceDefinition) GetUsedPorts() map[string]bool {
+	return map[string]bool{fmt.Sprintf("%v/tcp", DatastorePort): true}
+}
+
+func (definition DatastoreServiceDefinition) GetGeneratedFiles() map[string]func(*os.File) error {
+	return map[string]func(*os.File) error{}
+}
+
+func (definition DatastoreServiceDefinition) GetRunConfigFunc() RunConfigFunc {
+	return func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+		return services.NewContainerRunConfigBuilder().Build(), nil
+	}
+}
+
+func (definition DatastoreServiceDefinition) GetReadinessProbe() readiness.Probe {
+	return readiness.NewHttpGetProbe(DatastorePort, healthcheckUrlPath).WithExpectedBody(healthyValue)
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fault_proxy"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"net/http"
+	"os"
+	"strconv"
+)
+
+const (
+	// The port that proxied connections are accepted on
+	FaultProxyListenPort = 9000
+
+	// The port that the proxy's HTTP control API is served on
+	FaultProxyControlPort = 9001
+
+	faultProxyHealthUrlPath = "/health"
+)
+
+// A fault-injecting TCP proxy (see the fault_proxy package), for putting between two services
+type FaultProxyServiceDefinition struct {
+	image      string
+	targetIp   string
+	targetPort int
+}
+
+func NewFaultProxyServiceDefinition(image string, targetIp string, targetPort int) *FaultProxyServiceDefinition {
+	return &FaultProxyServiceDefinition{
+		image:      image,
+		targetIp:   targetIp,
+		targetPort: targetPort,
+	}
+}
+
+func (definition FaultProxyServiceDefinition) GetImage() string {
+	return definition.image
+}
+
+func (definition FaultProxyServiceDefinition) GetUsedPorts() map[string]bool {
+	return map[string]bool{
+		fmt.Sprintf("%v/tcp", FaultProxyListenPort):  true,
+		fmt.Sprintf("%v/tcp", FaultProxyControlPort): true,
+	}
+}
+
+func (definition FaultProxyServiceDefinition) GetGeneratedFiles() map[string]func(*os.File) error {
+	return map[string]func(*os.File) error{}
+}
+
+func (definition FaultProxyServiceDefinition) GetRunConfigFunc() RunConfigFunc {
+	return func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+		cmdArgs := []string{
+			"--listen-port",
+			strconv.Itoa(FaultProxyListenPort),
+			"--control-port",
+			strconv.Itoa(FaultProxyControlPort),
+			"--target",
+			fmt.Sprintf("%v:%v", definition.targetIp, definition.targetPort),
+		}
+		return services.NewContainerRunConfigBuilder().WithCmdOverride(cmdArgs).Build(), nil
+	}
+}
+
+func (definition FaultProxyServiceDefinition) GetReadinessProbe() readiness.Probe {
+	return readiness.NewHttpGetProbe(FaultProxyControlPort, faultProxyHealthUrlPath).WithExpectedBody(healthyValue)
+}
+
+// The client's requests go through the given transport, or the default one if nil
+func NewFaultProxyClient(ipAddr string, transport http.RoundTripper) *fault_proxy.FaultProxyClient {
+	return fault_proxy.NewFaultProxyClient(ipAddr, FaultProxyControlPort, transport)
+}
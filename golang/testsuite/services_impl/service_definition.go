@@ -0,0 +1,187 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// The health endpoint that both the datastore & API services expose
+	healthcheckUrlPath = "/health"
+	healthyValue       = "healthy"
+
+	// The partition that NetworkContext.AddService uses
+	DefaultPartitionId networks.PartitionID = ""
+)
+
+// The function that NetworkContext.AddService calls to get a service's run config, once its IP & files are known
+type RunConfigFunc func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)
+
+/*
+Everything needed to start a service and know when it's ready, declared in one place so that tests and custom
+ Networks compose the same definitions rather than each re-implementing container configs
+*/
+type ServiceDefinition interface {
+	GetImage() string
+
+	// "Set" of ports the service listens on, in "port/protocol" form
+	GetUsedPorts() map[string]bool
+
+	// Mapping of generated file key -> function that fills in the file's contents
+	GetGeneratedFiles() map[string]func(*os.File) error
+
+	GetRunConfigFunc() RunConfigFunc
+
+	// The probe that decides when the service is ready to receive requests; nil means it's ready as soon as it's started
+	GetReadinessProbe() readiness.Probe
+}
+
+func GetContainerCreationConfig(definition ServiceDefinition) *services.ContainerCreationConfig {
+	return getContainerCreationConfig(definition, definition.GetGeneratedFiles())
+}
+
+/*
+Adds a service to the network from its definition, waits for it to become ready, and records the image it was
+ started with
+*/
+func AddService(
+	networkCtx network_context.NetworkContext,
+	serviceId services.ServiceID,
+	definition ServiceDefinition,
+	images *service_images.ServiceImages,
+	readinessWaiter *readiness.Waiter,
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	serviceCtx, hostPortBindings, _, err := addServiceToPartition(networkCtx, serviceId, DefaultPartitionId, definition, images, readinessWaiter)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v'", serviceId)
+	}
+	return serviceCtx, hostPortBindings, nil
+}
+
+// Same as AddService, but for networks that have been repartitioned
+func AddServiceToPartition(
+	networkCtx network_context.NetworkContext,
+	serviceId services.ServiceID,
+	partitionId networks.PartitionID,
+	definition ServiceDefinition,
+	images *service_images.ServiceImages,
+	readinessWaiter *readiness.Waiter,
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, error) {
+	serviceCtx, hostPortBindings, _, err := addServiceToPartition(networkCtx, serviceId, partitionId, definition, images, readinessWaiter)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to partition '%v'", serviceId, partitionId)
+	}
+	return serviceCtx, hostPortBindings, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func addServiceToPartition(
+	networkCtx network_context.NetworkContext,
+	serviceId services.ServiceID,
+	partitionId networks.PartitionID,
+	definition ServiceDefinition,
+	images *service_images.ServiceImages,
+	readinessWaiter *readiness.Waiter,
+) (*services.ServiceContext, map[string]*kurtosis_core_rpc_api_bindings.PortBinding, *serviceStartupTiming, error) {
+	timing := &serviceStartupTiming{}
+
+	addStartTime := time.Now()
+	generatedFiles, generatedFileContents := captureGeneratedFiles(definition.GetGeneratedFiles())
+	containerCreationConfig := getContainerCreationConfig(definition, generatedFiles)
+	serviceCtx, hostPortBindings, err := networkCtx.AddServiceToPartition(serviceId, partitionId, containerCreationConfig, definition.GetRunConfigFunc())
+	if err != nil {
+		return nil, nil, nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to partition '%v'", serviceId, partitionId)
+	}
+	timing.adding = time.Since(addStartTime)
+	images.RecordServiceImage(serviceId, definition.GetImage())
+
+	record := &test_metadata.ServiceRecord{
+		ServiceId:              serviceId,
+		IpAddr:                 serviceCtx.GetIPAddress(),
+		PartitionId:            string(partitionId),
+		Image:                  definition.GetImage(),
+		HostPortBindings:       map[string]string{},
+		GeneratedFiles:         generatedFileContents,
+		ReadinessProbe:         "",
+		ReadinessProbeAttempts: []readiness.ProbeAttempt{},
+	}
+	for portSpec, binding := range hostPortBindings {
+		record.HostPortBindings[portSpec] = fmt.Sprintf("%v:%v", binding.InterfaceIp, binding.InterfacePort)
+	}
+
+	readinessStartTime := time.Now()
+	if probe := definition.GetReadinessProbe(); probe != nil {
+		readinessErr := readinessWaiter.WaitForReady(network_context.GetContext(networkCtx), serviceId, serviceCtx.GetIPAddress(), probe)
+		record.ReadinessProbe = probe.String()
+		record.ReadinessProbeAttempts = readinessWaiter.GetHistory()[serviceId]
+		if readinessErr != nil {
+			images.RecordStartedService(record)
+			return nil, nil, nil, stacktrace.Propagate(readinessErr, "An error occurred waiting for service '%v' to become ready", serviceId)
+		}
+	}
+	timing.waitingForReadiness = time.Since(readinessStartTime)
+	images.RecordStartedService(record)
+
+	logrus.Infof("Added service '%v' with host port bindings: %+v", serviceId, hostPortBindings)
+	return serviceCtx, hostPortBindings, timing, nil
+}
+
+func getContainerCreationConfig(definition ServiceDefinition, generatedFiles map[string]func(*os.File) error) *services.ContainerCreationConfig {
+	return services.NewContainerCreationConfigBuilder(
+		definition.GetImage(),
+	).WithUsedPorts(
+		definition.GetUsedPorts(),
+	).WithGeneratedFiles(
+		generatedFiles,
+	).Build()
+}
+
+/*
+Wraps the generated file functions so that the contents of each file are captured once it's written, returning the
+ wrapped functions and the map the contents will be captured into
+*/
+func captureGeneratedFiles(generatedFiles map[string]func(*os.File) error) (map[string]func(*os.File) error, map[string]string) {
+	contentsMutex := &sync.Mutex{}
+	contents := map[string]string{}
+	wrappedGeneratedFiles := map[string]func(*os.File) error{}
+	for fileKey, initializeFile := range generatedFiles {
+		// Copies, so the closure doesn't see later loop iterations
+		fileKey := fileKey
+		initializeFile := initializeFile
+		wrappedGeneratedFiles[fileKey] = func(fp *os.File) error {
+			if err := initializeFile(fp); err != nil {
+				return err
+			}
+			fileBytes, err := ioutil.ReadFile(fp.Name())
+			if err != nil {
+				// The contents are only for diagnostics, so failing to capture them shouldn't fail the service
+				logrus.Warnf("Couldn't capture the contents of generated file '%v': %v", fileKey, err)
+				return nil
+			}
+			contentsMutex.Lock()
+			defer contentsMutex.Unlock()
+			contents[fileKey] = string(fileBytes)
+			return nil
+		}
+	}
+	return wrappedGeneratedFiles, contents
+}
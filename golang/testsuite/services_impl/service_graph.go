@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"sync"
+	"time"
+)
+
+/*
+Gets a service's definition once all its dependencies are ready, so that the definition can use them (e.g. the
+ API service needs the datastore's IP). The map only contains the service's direct dependencies.
+*/
+type DefinitionFunc func(dependencyContexts map[services.ServiceID]*services.ServiceContext) (ServiceDefinition, error)
+
+type serviceGraphNode struct {
+	serviceId     services.ServiceID
+	partitionId   networks.PartitionID
+	dependencyIds []services.ServiceID
+	getDefinition DefinitionFunc
+}
+
+type serviceStartupTiming struct {
+	waitingForDependencies time.Duration
+	adding                 time.Duration
+	waitingForReadiness    time.Duration
+}
+
+/*
+A set of services with explicitly-declared dependencies, which are started with as much parallelism as the
+ dependencies allow: every service is added & health-checked as soon as all the services it depends on are ready
+*/
+type ServiceGraph struct {
+	nodes map[services.ServiceID]*serviceGraphNode
+
+	// The order services were declared in, so logs & errors come out in a stable order
+	declarationOrder []services.ServiceID
+}
+
+func NewServiceGraph() *ServiceGraph {
+	return &ServiceGraph{
+		nodes:            map[services.ServiceID]*serviceGraphNode{},
+		declarationOrder: []services.ServiceID{},
+	}
+}
+
+func (graph *ServiceGraph) AddService(serviceId services.ServiceID, dependencyIds []services.ServiceID, getDefinition DefinitionFunc) error {
+	return graph.AddServiceToPartition(serviceId, DefaultPartitionId, dependencyIds, getDefinition)
+}
+
+func (graph *ServiceGraph) AddServiceToPartition(serviceId services.ServiceID, partitionId networks.PartitionID, dependencyIds []services.ServiceID, getDefinition DefinitionFunc) error {
+	if _, found := graph.nodes[serviceId]; found {
+		return stacktrace.NewError("Service '%v' has already been added to the graph", serviceId)
+	}
+	graph.nodes[serviceId] = &serviceGraphNode{
+		serviceId:     serviceId,
+		partitionId:   partitionId,
+		dependencyIds: dependencyIds,
+		getDefinition: getDefinition,
+	}
+	graph.declarationOrder = append(graph.declarationOrder, serviceId)
+	return nil
+}
+
+/*
+Starts every service in the graph, returning the contexts of all the services. If any services fail, the errors
+ of all of them are returned together (rather than just the first) and services depending on them aren't started.
+*/
+func (graph *ServiceGraph) Start(
+	networkCtx network_context.NetworkContext,
+	images *service_images.ServiceImages,
+	readinessWaiter *readiness.Waiter,
+) (map[services.ServiceID]*services.ServiceContext, error) {
+	if err := graph.validate(); err != nil {
+		return nil, stacktrace.Propagate(err, "The service graph is invalid")
+	}
+
+	doneChannels := map[services.ServiceID]chan struct{}{}
+	for serviceId := range graph.nodes {
+		doneChannels[serviceId] = make(chan struct{})
+	}
+
+	resultsMutex := &sync.Mutex{}
+	serviceContexts := map[services.ServiceID]*services.ServiceContext{}
+	serviceErrors := map[services.ServiceID]error{}
+	// Services that weren't started because a dependency failed
+	skippedServiceIds := map[services.ServiceID]bool{}
+	timings := map[services.ServiceID]*serviceStartupTiming{}
+
+	graphStartTime := time.Now()
+	waitGroup := &sync.WaitGroup{}
+	for _, node := range graph.nodes {
+		waitGroup.Add(1)
+		go func(node *serviceGraphNode) {
+			defer waitGroup.Done()
+			defer close(doneChannels[node.serviceId])
+
+			waitStartTime := time.Now()
+			dependencyContexts := map[services.ServiceID]*services.ServiceContext{}
+			failedDependencyIds := []string{}
+			for _, dependencyId := range node.dependencyIds {
+				<-doneChannels[dependencyId]
+				resultsMutex.Lock()
+				dependencyCtx, found := serviceContexts[dependencyId]
+				resultsMutex.Unlock()
+				if !found {
+					failedDependencyIds = append(failedDependencyIds, string(dependencyId))
+					continue
+				}
+				dependencyContexts[dependencyId] = dependencyCtx
+			}
+			if len(failedDependencyIds) > 0 {
+				resultsMutex.Lock()
+				skippedServiceIds[node.serviceId] = true
+				serviceErrors[node.serviceId] = stacktrace.NewError("Not started because dependencies failed: %v", strings.Join(failedDependencyIds, ", "))
+				resultsMutex.Unlock()
+				return
+			}
+			waitDuration := time.Since(waitStartTime)
+
+			serviceCtx, timing, err := graph.startNode(networkCtx, images, readinessWaiter, node, dependencyContexts)
+			resultsMutex.Lock()
+			defer resultsMutex.Unlock()
+			if err != nil {
+				serviceErrors[node.serviceId] = err
+				return
+			}
+			timing.waitingForDependencies = waitDuration
+			serviceContexts[node.serviceId] = serviceCtx
+			timings[node.serviceId] = timing
+		}(node)
+	}
+	waitGroup.Wait()
+
+	graph.logTimings(timings, time.Since(graphStartTime))
+
+	if len(serviceErrors) > 0 {
+		return nil, graph.getAggregatedError(serviceErrors, skippedServiceIds)
+	}
+	return serviceContexts, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (graph *ServiceGraph) validate() error {
+	for _, serviceId := range graph.declarationOrder {
+		for _, dependencyId := range graph.nodes[serviceId].dependencyIds {
+			if _, found := graph.nodes[dependencyId]; !found {
+				return stacktrace.NewError("Service '%v' depends on service '%v', which isn't in the graph", serviceId, dependencyId)
+			}
+		}
+	}
+
+	visited := map[services.ServiceID]bool{}
+	// Services whose dependencies are currently being visited, used to detect cycles
+	inProgress := map[services.ServiceID]bool{}
+	var visit func(serviceId services.ServiceID, path []string) error
+	visit = func(serviceId services.ServiceID, path []string) error {
+		if visited[serviceId] {
+			return nil
+		}
+		path = append(path, string(serviceId))
+		if inProgress[serviceId] {
+			return stacktrace.NewError("Found a dependency cycle: %v", strings.Join(path, " -> "))
+		}
+		inProgress[serviceId] = true
+		for _, dependencyId := range graph.nodes[serviceId].dependencyIds {
+			if err := visit(dependencyId, path); err != nil {
+				return err
+			}
+		}
+		delete(inProgress, serviceId)
+		visited[serviceId] = true
+		return nil
+	}
+	for _, serviceId := range graph.declarationOrder {
+		if err := visit(serviceId, []string{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (graph *ServiceGraph) startNode(
+	networkCtx network_context.NetworkContext,
+	images *service_images.ServiceImages,
+	readinessWaiter *readiness.Waiter,
+	node *serviceGraphNode,
+	dependencyContexts map[services.ServiceID]*services.ServiceContext,
+) (*services.ServiceContext, *serviceStartupTiming, error) {
+	definition, err := node.getDefinition(dependencyContexts)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred getting the definition for service '%v'", node.serviceId)
+	}
+	serviceCtx, _, timing, err := addServiceToPartition(networkCtx, node.serviceId, node.partitionId, definition, images, readinessWaiter)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred starting service '%v'", node.serviceId)
+	}
+	return serviceCtx, timing, nil
+}
+
+func (graph *ServiceGraph) logTimings(timings map[services.ServiceID]*serviceStartupTiming, totalDuration time.Duration) {
+	logrus.Infof("Service startup took %v in total:", totalDuration)
+	for _, serviceId := range graph.declarationOrder {
+		timing, found := timings[serviceId]
+		if !found {
+			logrus.Infof(" - %v: not started", serviceId)
+			continue
+		}
+		logrus.Infof(
+			" - %v: waited %v for dependencies, added in %v, ready after %v",
+			serviceId,
+			timing.waitingForDependencies,
+			timing.adding,
+			timing.waitingForReadiness,
+		)
+	}
+}
+
+// Combines the errors of all the services that failed, listing root failures before services that were skipped
+func (graph *ServiceGraph) getAggregatedError(serviceErrors map[services.ServiceID]error, skippedServiceIds map[services.ServiceID]bool) error {
+	failureDescriptions := []string{}
+	skippedDescriptions := []string{}
+	for _, serviceId := range graph.declarationOrder {
+		err, found := serviceErrors[serviceId]
+		if !found {
+			continue
+		}
+		if skippedServiceIds[serviceId] {
+			skippedDescriptions = append(skippedDescriptions, "   "+string(serviceId)+": "+err.Error())
+		} else {
+			failureDescriptions = append(failureDescriptions, "   "+string(serviceId)+": "+err.Error())
+		}
+	}
+	return stacktrace.NewError(
+		"%v service(s) failed to start and %v service(s) were skipped as a result:\n%v",
+		len(failureDescriptions),
+		len(skippedDescriptions),
+		strings.Join(append(failureDescriptions, skippedDescriptions...), "\n"),
+	)
+}
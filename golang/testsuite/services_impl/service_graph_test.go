@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package services_impl
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestServiceGraph_Validate(t *testing.T) {
+	testCases := []struct {
+		name string
+
+		// Each service along with the IDs of the services it depends on, in declaration order
+		dependencies []serviceDependencies
+
+		// Empty if the graph is valid
+		expectedErrSubstring string
+	}{
+		{
+			name:         "no services",
+			dependencies: []serviceDependencies{},
+		},
+		{
+			name: "diamond",
+			dependencies: []serviceDependencies{
+				{"datastore", []services.ServiceID{}},
+				{"api-0", []services.ServiceID{"datastore"}},
+				{"api-1", []services.ServiceID{"datastore"}},
+				{"proxy", []services.ServiceID{"api-0", "api-1"}},
+			},
+		},
+		{
+			name: "dependency declared after its dependent",
+			dependencies: []serviceDependencies{
+				{"api-0", []services.ServiceID{"datastore"}},
+				{"datastore", []services.ServiceID{}},
+			},
+		},
+		{
+			name: "unknown dependency",
+			dependencies: []serviceDependencies{
+				{"api-0", []services.ServiceID{"datastore"}},
+			},
+			expectedErrSubstring: "isn't in the graph",
+		},
+		{
+			name: "self-dependency",
+			dependencies: []serviceDependencies{
+				{"api-0", []services.ServiceID{"api-0"}},
+			},
+			expectedErrSubstring: "api-0 -> api-0",
+		},
+		{
+			name: "cycle",
+			dependencies: []serviceDependencies{
+				{"a", []services.ServiceID{"b"}},
+				{"b", []services.ServiceID{"c"}},
+				{"c", []services.ServiceID{"a"}},
+			},
+			expectedErrSubstring: "a -> b -> c -> a",
+		},
+		{
+			name: "cycle reachable from an acyclic service",
+			dependencies: []serviceDependencies{
+				{"entry", []services.ServiceID{"a"}},
+				{"a", []services.ServiceID{"b"}},
+				{"b", []services.ServiceID{"a"}},
+			},
+			expectedErrSubstring: "entry -> a -> b -> a",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			graph := NewServiceGraph()
+			for _, dependencies := range testCase.dependencies {
+				require.NoError(t, graph.AddService(dependencies.serviceId, dependencies.dependencyIds, nil))
+			}
+			err := graph.validate()
+			if testCase.expectedErrSubstring == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			require.Contains(t, err.Error(), testCase.expectedErrSubstring)
+		})
+	}
+}
+
+func TestServiceGraph_RejectsDuplicateServices(t *testing.T) {
+	graph := NewServiceGraph()
+	require.NoError(t, graph.AddService("datastore", []services.ServiceID{}, nil))
+	require.Error(t, graph.AddService("datastore", []services.ServiceID{}, nil))
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+type serviceDependencies struct {
+	serviceId     services.ServiceID
+	dependencyIds []services.ServiceID
+}
@@ -0,0 +1,225 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"encoding/json"
+	"github.com/kurtosis-tech/example-microservice/api/api_service_client"
+	"github.com/kurtosis-tech/example-microservice/datastore/datastore_service_client"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+)
+
+const (
+	personUrlPathPrefix             = "/person/"
+	incrementBooksReadUrlPathPrefix = "/incrementBooksRead/"
+
+	personKeyPrefix = "person-"
+)
+
+// The config file that the API service reads on startup, matching what services_impl.ApiServiceDefinition generates
+type apiConfig struct {
+	DatastoreIp   string `json:"datastoreIp"`
+	DatastorePort int    `json:"datastorePort"`
+}
+
+/*
+An in-process implementation of the example-microservice API's HTTP API, backed by a datastore service:
+ GET /health -> "healthy"
+ POST /person/<id> -> adds a person with 0 books read, or 409 if they already exist
+ GET /person/<id> -> the person's JSON, or 404 if they don't exist
+ POST /incrementBooksRead/<id> -> increments the person's books read, or 404 if they don't exist
+*/
+type ApiServer struct {
+	faultInjector *faultInjector
+
+	datastoreClient *datastore_service_client.DatastoreClient
+
+	// Nil until the server is started
+	server *httptest.Server
+}
+
+func NewApiServer(datastoreIp string, datastorePort int) *ApiServer {
+	return &ApiServer{
+		faultInjector:   newFaultInjector(),
+		datastoreClient: datastore_service_client.NewDatastoreClient(datastoreIp, datastorePort),
+		server:          nil,
+	}
+}
+
+// Creates an API server from the same config file that the real API service reads
+func NewApiServerFromConfigFile(configFilepath string) (*ApiServer, error) {
+	configBytes, err := ioutil.ReadFile(configFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading the API config file at '%v'", configFilepath)
+	}
+	var config apiConfig
+	if err := json.Unmarshal(configBytes, &config); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred deserializing the API config file at '%v'", configFilepath)
+	}
+	if config.DatastoreIp == "" {
+		return nil, stacktrace.NewError("No datastore IP provided in the API config file at '%v'", configFilepath)
+	}
+	if config.DatastorePort == 0 {
+		return nil, stacktrace.NewError("No datastore port provided in the API config file at '%v'", configFilepath)
+	}
+	return NewApiServer(config.DatastoreIp, config.DatastorePort), nil
+}
+
+// Starts serving on the given address (e.g. "127.0.0.1:0" for any free port)
+func (api *ApiServer) Start(listenAddr string) error {
+	if api.server != nil {
+		return stacktrace.NewError("The API server is already started")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthUrlPath, healthHandler)
+	mux.HandleFunc(personUrlPathPrefix, api.handlePersonRequest)
+	mux.HandleFunc(incrementBooksReadUrlPathPrefix, api.handleIncrementBooksReadRequest)
+	server, err := startHttptestServer(listenAddr, api.faultInjector.wrap(mux))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred starting the API server")
+	}
+	api.server = server
+	return nil
+}
+
+// Gets the address the server is listening on, which is only valid once it's started
+func (api *ApiServer) GetListenAddr() string {
+	return api.server.Listener.Addr().String()
+}
+
+// Stops the server, blocking until its outstanding requests have completed
+func (api *ApiServer) Stop() error {
+	if api.server == nil {
+		return stacktrace.NewError("The API server isn't started")
+	}
+	api.server.Close()
+	api.server = nil
+	return nil
+}
+
+func (api *ApiServer) SetFaultModes(modes FaultModes) error {
+	if err := api.faultInjector.setFaultModes(modes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the API server's fault modes")
+	}
+	return nil
+}
+
+func (api *ApiServer) GetFaultModes() FaultModes {
+	return api.faultInjector.getFaultModes()
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (api *ApiServer) handlePersonRequest(writer http.ResponseWriter, request *http.Request) {
+	idStr := strings.TrimPrefix(request.URL.Path, personUrlPathPrefix)
+	key, err := getPersonKey(idStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exists, err := api.datastoreClient.Exists(key)
+	if err != nil {
+		logrus.Errorf("An error occurred checking if key '%v' exists: %v", key, err)
+		http.Error(writer, "An error occurred checking if the person exists", http.StatusInternalServerError)
+		return
+	}
+
+	switch request.Method {
+	case http.MethodPost:
+		if exists {
+			http.Error(writer, "A person with ID '"+idStr+"' already exists", http.StatusConflict)
+			return
+		}
+		api.upsertPerson(writer, key, api_service_client.Person{BooksRead: 0})
+	case http.MethodGet:
+		if !exists {
+			http.Error(writer, "No person with ID '"+idStr+"' exists", http.StatusNotFound)
+			return
+		}
+		value, err := api.datastoreClient.Get(key)
+		if err != nil {
+			logrus.Errorf("An error occurred getting data for person key '%v': %v", key, err)
+			http.Error(writer, "An error occurred getting the person", http.StatusInternalServerError)
+			return
+		}
+		writer.Write([]byte(value))
+	default:
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *ApiServer) handleIncrementBooksReadRequest(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	idStr := strings.TrimPrefix(request.URL.Path, incrementBooksReadUrlPathPrefix)
+	key, err := getPersonKey(idStr)
+	if err != nil {
+		http.Error(writer, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	exists, err := api.datastoreClient.Exists(key)
+	if err != nil {
+		logrus.Errorf("An error occurred checking if key '%v' exists: %v", key, err)
+		http.Error(writer, "An error occurred checking if the person exists", http.StatusInternalServerError)
+		return
+	}
+	if !exists {
+		http.Error(writer, "No person with ID '"+idStr+"' exists", http.StatusNotFound)
+		return
+	}
+	value, err := api.datastoreClient.Get(key)
+	if err != nil {
+		logrus.Errorf("An error occurred getting data for person key '%v': %v", key, err)
+		http.Error(writer, "An error occurred getting the person", http.StatusInternalServerError)
+		return
+	}
+	var person api_service_client.Person
+	if err := json.Unmarshal([]byte(value), &person); err != nil {
+		logrus.Errorf("An error occurred deserializing person JSON for key '%v': %v", key, err)
+		http.Error(writer, "An error occurred deserializing the person", http.StatusInternalServerError)
+		return
+	}
+	person.BooksRead++
+	api.upsertPerson(writer, key, person)
+}
+
+// Writes the person to the datastore (unless the write is lost to data loss) and responds accordingly
+func (api *ApiServer) upsertPerson(writer http.ResponseWriter, key string, person api_service_client.Person) {
+	if api.faultInjector.shouldLoseWrite() {
+		writer.WriteHeader(http.StatusOK)
+		return
+	}
+	personBytes, err := json.Marshal(person)
+	if err != nil {
+		logrus.Errorf("An error occurred serializing the person JSON for key '%v': %v", key, err)
+		http.Error(writer, "An error occurred serializing the person", http.StatusInternalServerError)
+		return
+	}
+	if err := api.datastoreClient.Upsert(key, string(personBytes)); err != nil {
+		logrus.Errorf("An error occurred upserting the person JSON for key '%v': %v", key, err)
+		http.Error(writer, "An error occurred saving the person", http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
+
+func getPersonKey(idStr string) (string, error) {
+	if _, err := strconv.Atoi(idStr); err != nil {
+		return "", stacktrace.Propagate(err, "Could not parse person ID string '%v' to int", idStr)
+	}
+	return personKeyPrefix + idStr, nil
+}
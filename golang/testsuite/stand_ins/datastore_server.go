@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+)
+
+const (
+	keyUrlPathPrefix = "/key/"
+)
+
+/*
+An in-process implementation of the example-microservice datastore's HTTP API:
+ GET /health -> "healthy"
+ GET /key/<key> -> the key's value, or 404 if it doesn't exist
+ POST /key/<key> -> sets the key's value to the request body
+*/
+type DatastoreServer struct {
+	faultInjector *faultInjector
+
+	mutex  *sync.Mutex
+	values map[string]string
+
+	// Nil until the server is started
+	server *httptest.Server
+}
+
+func NewDatastoreServer() *DatastoreServer {
+	return &DatastoreServer{
+		faultInjector: newFaultInjector(),
+		mutex:         &sync.Mutex{},
+		values:        map[string]string{},
+		server:        nil,
+	}
+}
+
+// Starts serving on the given address (e.g. "127.0.0.1:0" for any free port)
+func (datastore *DatastoreServer) Start(listenAddr string) error {
+	if datastore.server != nil {
+		return stacktrace.NewError("The datastore server is already started")
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthUrlPath, healthHandler)
+	mux.HandleFunc(keyUrlPathPrefix, datastore.handleKeyRequest)
+	server, err := startHttptestServer(listenAddr, datastore.faultInjector.wrap(mux))
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred starting the datastore server")
+	}
+	datastore.server = server
+	return nil
+}
+
+// Gets the address the server is listening on, which is only valid once it's started
+func (datastore *DatastoreServer) GetListenAddr() string {
+	return datastore.server.Listener.Addr().String()
+}
+
+// Stops the server, blocking until its outstanding requests have completed; the stored values are kept
+func (datastore *DatastoreServer) Stop() error {
+	if datastore.server == nil {
+		return stacktrace.NewError("The datastore server isn't started")
+	}
+	datastore.server.Close()
+	datastore.server = nil
+	return nil
+}
+
+func (datastore *DatastoreServer) SetFaultModes(modes FaultModes) error {
+	if err := datastore.faultInjector.setFaultModes(modes); err != nil {
+		return stacktrace.Propagate(err, "An error occurred setting the datastore server's fault modes")
+	}
+	return nil
+}
+
+func (datastore *DatastoreServer) GetFaultModes() FaultModes {
+	return datastore.faultInjector.getFaultModes()
+}
+
+// Gets a copy of every key & value currently stored
+func (datastore *DatastoreServer) GetValues() map[string]string {
+	datastore.mutex.Lock()
+	defer datastore.mutex.Unlock()
+	result := map[string]string{}
+	for key, value := range datastore.values {
+		result[key] = value
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (datastore *DatastoreServer) handleKeyRequest(writer http.ResponseWriter, request *http.Request) {
+	key := strings.TrimPrefix(request.URL.Path, keyUrlPathPrefix)
+	if key == "" {
+		http.Error(writer, "No key was provided", http.StatusNotFound)
+		return
+	}
+
+	switch request.Method {
+	case http.MethodGet:
+		datastore.mutex.Lock()
+		value, found := datastore.values[key]
+		datastore.mutex.Unlock()
+		if !found {
+			http.Error(writer, "No key "+key+" exists!", http.StatusNotFound)
+			return
+		}
+		writer.Write([]byte(value))
+	case http.MethodPost:
+		bodyBytes, err := ioutil.ReadAll(request.Body)
+		if err != nil {
+			http.Error(writer, "An error occurred reading the request body", http.StatusInternalServerError)
+			return
+		}
+		if !datastore.faultInjector.shouldLoseWrite() {
+			datastore.mutex.Lock()
+			datastore.values[key] = string(bodyBytes)
+			datastore.mutex.Unlock()
+		}
+		writer.WriteHeader(http.StatusOK)
+	default:
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
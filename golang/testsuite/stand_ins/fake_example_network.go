@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fake_network"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/palantir/stacktrace"
+)
+
+const (
+	// The images that NewFakeExampleNetwork backs with stand-ins; a fake network never pulls them, so they needn't exist
+	FakeDatastoreImage = "kurtosistech/example-microservices_datastore:test"
+	FakeApiImage       = "kurtosistech/example-microservices_api:test"
+)
+
+/*
+Creates a FakeNetworkContext whose datastore & API services are backed by stand-ins, along with the ServiceImages
+ that a test or Network should start those services with, for unit-testing code written against the example
+ microservices
+
+The network context should be destroyed when the caller is done with it.
+*/
+func NewFakeExampleNetwork(metadata *test_metadata.TestMetadata) (*fake_network.FakeNetworkContext, *service_images.ServiceImages, error) {
+	datastoreImage, err := service_images.ParseImageRef(FakeDatastoreImage)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred parsing the fake datastore image '%v'", FakeDatastoreImage)
+	}
+	apiImage, err := service_images.ParseImageRef(FakeApiImage)
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred parsing the fake API image '%v'", FakeApiImage)
+	}
+
+	networkCtx, err := fake_network.NewFakeNetworkContext()
+	if err != nil {
+		return nil, nil, stacktrace.Propagate(err, "An error occurred creating the fake network context")
+	}
+	networkCtx.WithStandIn(FakeDatastoreImage, NewDatastoreStandInFactory())
+	networkCtx.WithStandIn(FakeApiImage, NewApiStandInFactory())
+	return networkCtx, service_images.NewServiceImages(datastoreImage, apiImage, nil, metadata), nil
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"github.com/palantir/stacktrace"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Healthchecks are never faulted, so that a faulty stand-in can still be started and waited on
+	healthUrlPath = "/health"
+	healthyValue  = "healthy"
+)
+
+// The faults a stand-in server is currently injecting; the zero value serves every request normally
+type FaultModes struct {
+	// Delay added before handling each request
+	ResponseDelayMillis int
+
+	// Probability in [0, 1] that a request gets a 500 instead of being handled
+	InternalErrorProbability float64
+
+	// Probability in [0, 1] that a write is acknowledged but silently discarded
+	DataLossProbability float64
+}
+
+func (modes FaultModes) validate() error {
+	if modes.ResponseDelayMillis < 0 {
+		return stacktrace.NewError("Response delay must be non-negative, but was %v", modes.ResponseDelayMillis)
+	}
+	if modes.InternalErrorProbability < 0 || modes.InternalErrorProbability > 1 {
+		return stacktrace.NewError("Internal error probability must be in [0, 1], but was %v", modes.InternalErrorProbability)
+	}
+	if modes.DataLossProbability < 0 || modes.DataLossProbability > 1 {
+		return stacktrace.NewError("Data loss probability must be in [0, 1], but was %v", modes.DataLossProbability)
+	}
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Holds a server's current fault modes, which can be changed while the server is handling requests
+type faultInjector struct {
+	mutex *sync.RWMutex
+	modes FaultModes
+}
+
+func newFaultInjector() *faultInjector {
+	return &faultInjector{
+		mutex: &sync.RWMutex{},
+		modes: FaultModes{},
+	}
+}
+
+func (injector *faultInjector) setFaultModes(modes FaultModes) error {
+	if err := modes.validate(); err != nil {
+		return stacktrace.Propagate(err, "Invalid fault modes")
+	}
+	injector.mutex.Lock()
+	defer injector.mutex.Unlock()
+	injector.modes = modes
+	return nil
+}
+
+func (injector *faultInjector) getFaultModes() FaultModes {
+	injector.mutex.RLock()
+	defer injector.mutex.RUnlock()
+	return injector.modes
+}
+
+// Wraps the handler so that every request except healthchecks is subject to the response delay & internal errors
+func (injector *faultInjector) wrap(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path == healthUrlPath {
+			handler.ServeHTTP(writer, request)
+			return
+		}
+		modes := injector.getFaultModes()
+		time.Sleep(time.Duration(modes.ResponseDelayMillis) * time.Millisecond)
+		if rand.Float64() < modes.InternalErrorProbability {
+			http.Error(writer, "Injected internal error", http.StatusInternalServerError)
+			return
+		}
+		handler.ServeHTTP(writer, request)
+	})
+}
+
+// Decides whether the write currently being handled should be lost
+func (injector *faultInjector) shouldLoseWrite() bool {
+	return rand.Float64() < injector.getFaultModes().DataLossProbability
+}
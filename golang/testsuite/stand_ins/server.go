@@ -0,0 +1,30 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"github.com/palantir/stacktrace"
+	"net"
+	"net/http"
+	"net/http/httptest"
+)
+
+// Starts an httptest server on the given address, rather than the random loopback port that httptest would choose
+func startHttptestServer(listenAddr string, handler http.Handler) (*httptest.Server, error) {
+	listener, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred listening on '%v'", listenAddr)
+	}
+	server := httptest.NewUnstartedServer(handler)
+	server.Listener.Close()
+	server.Listener = listener
+	server.Start()
+	return server, nil
+}
+
+func healthHandler(writer http.ResponseWriter, request *http.Request) {
+	writer.Write([]byte(healthyValue))
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package stand_ins
+
+import (
+	"flag"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fake_network"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fault_proxy"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"io/ioutil"
+	"net/http/httptest"
+)
+
+const (
+	// The flag that the API service's run config passes the config filepath in, same as the real API binary
+	apiConfigFlagName = "config"
+
+	// The flags that the fault proxy service's run config passes its settings in, same as the fault_proxy_server binary
+	faultProxyListenPortFlagName  = "listen-port"
+	faultProxyControlPortFlagName = "control-port"
+	faultProxyTargetFlagName      = "target"
+)
+
+// Compile-time checks that the stand-ins can back fake network services
+var _ fake_network.StandIn = &DatastoreStandIn{}
+var _ fake_network.StandIn = &ApiStandIn{}
+var _ fake_network.StandIn = &FaultProxyStandIn{}
+
+// Backs a fake network datastore service with a DatastoreServer on the service's IP & the datastore port
+type DatastoreStandIn struct {
+	server *DatastoreServer
+}
+
+func NewDatastoreStandInFactory() fake_network.StandInFactory {
+	return func(serviceId services.ServiceID) fake_network.StandIn {
+		return &DatastoreStandIn{server: NewDatastoreServer()}
+	}
+}
+
+func (standIn *DatastoreStandIn) Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error {
+	listenAddr := fmt.Sprintf("%v:%v", ipAddr, services_impl.DatastorePort)
+	if err := standIn.server.Start(listenAddr); err != nil {
+		return stacktrace.Propagate(err, "An error occurred starting the datastore server on '%v'", listenAddr)
+	}
+	return nil
+}
+
+func (standIn *DatastoreStandIn) Stop() error {
+	return standIn.server.Stop()
+}
+
+func (standIn *DatastoreStandIn) GetServer() *DatastoreServer {
+	return standIn.server
+}
+
+/*
+Backs a fake network API service with an ApiServer on the service's IP & the API port, configured from the config
+ file passed in the service's run config just like the real API binary
+*/
+type ApiStandIn struct {
+	// Nil until the stand-in is started, since the server is created from the service's config file
+	server *ApiServer
+}
+
+func NewApiStandInFactory() fake_network.StandInFactory {
+	return func(serviceId services.ServiceID) fake_network.StandIn {
+		return &ApiStandIn{server: nil}
+	}
+}
+
+func (standIn *ApiStandIn) Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error {
+	configFilepath, err := getApiConfigFilepath(runConfig.GetCmdOverrideArgs())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the config filepath from the API's run config")
+	}
+	server, err := NewApiServerFromConfigFile(configFilepath)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred creating the API server")
+	}
+	listenAddr := fmt.Sprintf("%v:%v", ipAddr, services_impl.ApiServicePort)
+	if err := server.Start(listenAddr); err != nil {
+		return stacktrace.Propagate(err, "An error occurred starting the API server on '%v'", listenAddr)
+	}
+	standIn.server = server
+	return nil
+}
+
+func (standIn *ApiStandIn) Stop() error {
+	if standIn.server == nil {
+		return stacktrace.NewError("The API stand-in isn't started")
+	}
+	return standIn.server.Stop()
+}
+
+// Gets the stand-in's server, which is nil until the stand-in is started
+func (standIn *ApiStandIn) GetServer() *ApiServer {
+	return standIn.server
+}
+
+/*
+Backs a fake network fault proxy service with an in-process FaultProxy and its HTTP control API, configured from the
+ service's run config just like the fault_proxy_server binary
+*/
+type FaultProxyStandIn struct {
+	// Both nil until the stand-in is started, since the proxy is created from the service's run config
+	proxy         *fault_proxy.FaultProxy
+	controlServer *httptest.Server
+}
+
+func NewFaultProxyStandInFactory() fake_network.StandInFactory {
+	return func(serviceId services.ServiceID) fake_network.StandIn {
+		return &FaultProxyStandIn{proxy: nil, controlServer: nil}
+	}
+}
+
+func (standIn *FaultProxyStandIn) Start(ipAddr string, generatedFileFilepaths map[string]string, runConfig *services.ContainerRunConfig) error {
+	listenPort, controlPort, targetAddr, err := getFaultProxyArgs(runConfig.GetCmdOverrideArgs())
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the proxy settings from the fault proxy's run config")
+	}
+	proxy := fault_proxy.NewFaultProxy(targetAddr)
+	listenAddr := fmt.Sprintf("%v:%v", ipAddr, listenPort)
+	if err := proxy.Start(listenAddr); err != nil {
+		return stacktrace.Propagate(err, "An error occurred starting the fault proxy on '%v'", listenAddr)
+	}
+	controlAddr := fmt.Sprintf("%v:%v", ipAddr, controlPort)
+	controlServer, err := startHttptestServer(controlAddr, fault_proxy.NewControlHandler(proxy))
+	if err != nil {
+		proxy.Stop()
+		return stacktrace.Propagate(err, "An error occurred starting the fault proxy's control API on '%v'", controlAddr)
+	}
+	standIn.proxy = proxy
+	standIn.controlServer = controlServer
+	return nil
+}
+
+func (standIn *FaultProxyStandIn) Stop() error {
+	if standIn.proxy == nil {
+		return stacktrace.NewError("The fault proxy stand-in isn't started")
+	}
+	standIn.controlServer.Close()
+	if err := standIn.proxy.Stop(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred stopping the fault proxy")
+	}
+	standIn.proxy = nil
+	standIn.controlServer = nil
+	return nil
+}
+
+// Gets the stand-in's proxy, which is nil until the stand-in is started
+func (standIn *FaultProxyStandIn) GetProxy() *fault_proxy.FaultProxy {
+	return standIn.proxy
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Parses the API command line (e.g. "./api.bin --config /path") the same way the real API binary does
+func getApiConfigFilepath(cmdArgs []string) (string, error) {
+	if len(cmdArgs) == 0 {
+		return "", stacktrace.NewError("The API's run config has no command")
+	}
+	flagSet := flag.NewFlagSet(cmdArgs[0], flag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	configFilepath := flagSet.String(apiConfigFlagName, "", "Filepath to the config file")
+	if err := flagSet.Parse(cmdArgs[1:]); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred parsing the API command args '%v'", cmdArgs)
+	}
+	if *configFilepath == "" {
+		return "", stacktrace.NewError("The API command args '%v' have no --%v flag", cmdArgs, apiConfigFlagName)
+	}
+	return *configFilepath, nil
+}
+
+// Parses the fault proxy command line (e.g. "--listen-port 9000 --control-port 9001 --target 1.2.3.4:80") the same
+//  way the fault_proxy_server binary does
+func getFaultProxyArgs(cmdArgs []string) (listenPort int, controlPort int, targetAddr string, err error) {
+	// The run config overrides only the args, not the binary, so there's no program name to skip
+	flagSet := flag.NewFlagSet("fault-proxy", flag.ContinueOnError)
+	flagSet.SetOutput(ioutil.Discard)
+	listenPortArg := flagSet.Int(faultProxyListenPortFlagName, 0, "Port to accept proxied connections on")
+	controlPortArg := flagSet.Int(faultProxyControlPortFlagName, 0, "Port to serve the HTTP control API on")
+	targetArg := flagSet.String(faultProxyTargetFlagName, "", "Address ('host:port') to forward connections to")
+	if err := flagSet.Parse(cmdArgs); err != nil {
+		return 0, 0, "", stacktrace.Propagate(err, "An error occurred parsing the fault proxy command args '%v'", cmdArgs)
+	}
+	if *listenPortArg == 0 || *controlPortArg == 0 || *targetArg == "" {
+		return 0, 0, "", stacktrace.NewError(
+			"The fault proxy command args '%v' must give all of --%v, --%v, and --%v",
+			cmdArgs,
+			faultProxyListenPortFlagName,
+			faultProxyControlPortFlagName,
+			faultProxyTargetFlagName,
+		)
+	}
+	return *listenPortArg, *controlPortArg, *targetArg, nil
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_context
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"net/http"
+)
+
+/*
+What the suite gives each of its tests: the images to start the test's services with, the transport for the test's
+ clients' requests, and the recorder of the test's steps, which tests record to via Step:
+
+ step := ctx.Step("add person")
+ if err := ...; err != nil {
+	return step.Fail(err)
+ }
+ step.Done()
+*/
+type TestContext struct {
+	images *service_images.ServiceImages
+
+	// Nil to use http.DefaultTransport
+	httpTransport http.RoundTripper
+
+	stepRecorder *test_steps.StepRecorder
+}
+
+func NewTestContext(images *service_images.ServiceImages, httpTransport http.RoundTripper, stepRecorder *test_steps.StepRecorder) *TestContext {
+	return &TestContext{images: images, httpTransport: httpTransport, stepRecorder: stepRecorder}
+}
+
+func (ctx *TestContext) GetImages() *service_images.ServiceImages {
+	return ctx.images
+}
+
+func (ctx *TestContext) GetHttpTransport() http.RoundTripper {
+	return ctx.httpTransport
+}
+
+// Starts a top-level step of the test; see test_steps.StepRecorder
+func (ctx *TestContext) Step(name string) *test_steps.Step {
+	return ctx.stepRecorder.Step(name)
+}
@@ -0,0 +1,20 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_metadata
+
+import (
+	"time"
+)
+
+// The outcome of one phase (setup or run) of a test
+type PhaseResult struct {
+	Phase     string
+	StartTime time.Time
+	Duration  time.Duration
+
+	// Nil if the phase succeeded
+	Error error
+}
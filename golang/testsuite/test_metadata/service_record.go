@@ -0,0 +1,29 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_metadata
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+)
+
+// Everything known about a service the test started, kept so that failures can be diagnosed after the fact
+type ServiceRecord struct {
+	ServiceId   services.ServiceID `json:"serviceId"`
+	IpAddr      string             `json:"ipAddr"`
+	PartitionId string             `json:"partitionId"`
+	Image       string             `json:"image"`
+
+	// Mapping of "port/protocol" -> "hostIp:hostPort"
+	HostPortBindings map[string]string `json:"hostPortBindings"`
+
+	// Mapping of generated file key -> the file's contents
+	GeneratedFiles map[string]string `json:"generatedFiles"`
+
+	// Empty if the service was considered ready as soon as it started
+	ReadinessProbe         string                   `json:"readinessProbe"`
+	ReadinessProbeAttempts []readiness.ProbeAttempt `json:"readinessProbeAttempts"`
+}
@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_metadata
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"time"
+)
+
+var stepStatusToEventStatus = map[test_steps.StepStatus]bindings.StepFinishedEvent_StepStatus{
+	test_steps.PassedStatus:     bindings.StepFinishedEvent_PASSED,
+	test_steps.FailedStatus:     bindings.StepFinishedEvent_FAILED,
+	test_steps.UnfinishedStatus: bindings.StepFinishedEvent_UNFINISHED,
+}
+
+// Publishes a test's steps as progress events
+type stepProgressListener struct {
+	publisher *test_progress.Publisher
+}
+
+func newStepProgressListener(publisher *test_progress.Publisher) *stepProgressListener {
+	return &stepProgressListener{publisher: publisher}
+}
+
+func (listener stepProgressListener) OnStepStarted(stepPath string) {
+	listener.publisher.Publish(test_progress.NewStepStartedEvent(stepPath))
+}
+
+func (listener stepProgressListener) OnStepFinished(stepPath string, status test_steps.StepStatus, duration time.Duration, errorMessage string) {
+	listener.publisher.Publish(test_progress.NewStepFinishedEvent(stepPath, stepStatusToEventStatus[status], duration, errorMessage))
+}
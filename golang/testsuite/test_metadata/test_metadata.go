@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_metadata
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+/*
+Suite-side information about a test which Kurtosis' own test metadata (timeouts, partitioning, artifacts) has
+ no place for, recorded as the test executes so that we can prove afterwards what was actually tested
+*/
+type TestMetadata struct {
+	testName string
+
+	mutex *sync.Mutex
+
+	// As declared by the test, if it's a test_tags.TaggedTest
+	tags  []test_tags.Tag
+	owner string
+
+	// The params of the table row the test was generated from, or empty if it wasn't generated from a test_params.TestTable
+	params []test_params.Param
+
+	// Mapping of service ID -> the image the service was started with
+	resolvedServiceImages map[services.ServiceID]string
+
+	// Every service the test started (including ones later removed), in the order they finished starting
+	startedServices []*ServiceRecord
+
+	// The phases the test has finished, in the order they finished
+	phaseResults []*PhaseResult
+
+	// Mapping of artifact name -> the filepath the artifact was written to (e.g. the diagnostics bundle of a failed test)
+	artifactPaths map[string]string
+
+	// The steps the test has gone through, which the test records to itself
+	stepRecorder *test_steps.StepRecorder
+
+	// Publishes the test's steps & started services as they happen, for streaming the test's progress
+	progressPublisher *test_progress.Publisher
+}
+
+func NewTestMetadata(testName string) *TestMetadata {
+	stepRecorder := test_steps.NewStepRecorder()
+	progressPublisher := test_progress.NewPublisher()
+	stepRecorder.AddListener(newStepProgressListener(progressPublisher))
+	return &TestMetadata{
+		testName:              testName,
+		mutex:                 &sync.Mutex{},
+		tags:                  []test_tags.Tag{},
+		owner:                 "",
+		params:                []test_params.Param{},
+		resolvedServiceImages: map[services.ServiceID]string{},
+		startedServices:       []*ServiceRecord{},
+		phaseResults:          []*PhaseResult{},
+		artifactPaths:         map[string]string{},
+		stepRecorder:          stepRecorder,
+		progressPublisher:     progressPublisher,
+	}
+}
+
+func (metadata *TestMetadata) GetTestName() string {
+	return metadata.testName
+}
+
+// Gets the recorder that the test should record its steps to, which lives as long as the metadata does
+func (metadata *TestMetadata) GetStepRecorder() *test_steps.StepRecorder {
+	return metadata.stepRecorder
+}
+
+func (metadata *TestMetadata) GetProgressPublisher() *test_progress.Publisher {
+	return metadata.progressPublisher
+}
+
+func (metadata *TestMetadata) SetTagsAndOwner(tags []test_tags.Tag, owner string) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	metadata.tags = append([]test_tags.Tag{}, tags...)
+	metadata.owner = owner
+}
+
+func (metadata *TestMetadata) GetTags() []test_tags.Tag {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	return append([]test_tags.Tag{}, metadata.tags...)
+}
+
+// Emptystring if the test doesn't declare an owner
+func (metadata *TestMetadata) GetOwner() string {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	return metadata.owner
+}
+
+func (metadata *TestMetadata) SetParams(params []test_params.Param) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	metadata.params = append([]test_params.Param{}, params...)
+}
+
+func (metadata *TestMetadata) GetParams() []test_params.Param {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	return append([]test_params.Param{}, metadata.params...)
+}
+
+func (metadata *TestMetadata) RecordResolvedServiceImage(serviceId services.ServiceID, image string) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+
+	logrus.Infof("Test '%v' started service '%v' with image '%v'", metadata.testName, serviceId, image)
+	metadata.resolvedServiceImages[serviceId] = image
+}
+
+// Returns a copy of the service ID -> image mapping, so callers can't modify the record
+func (metadata *TestMetadata) GetResolvedServiceImages() map[services.ServiceID]string {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+
+	result := map[services.ServiceID]string{}
+	for serviceId, image := range metadata.resolvedServiceImages {
+		result[serviceId] = image
+	}
+	return result
+}
+
+func (metadata *TestMetadata) RecordStartedService(record *ServiceRecord) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	metadata.startedServices = append(metadata.startedServices, record)
+	metadata.progressPublisher.Publish(test_progress.NewServiceAddedEvent(string(record.ServiceId), record.Image, record.IpAddr))
+}
+
+func (metadata *TestMetadata) GetStartedServices() []*ServiceRecord {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	return append([]*ServiceRecord{}, metadata.startedServices...)
+}
+
+func (metadata *TestMetadata) RecordPhaseResult(result *PhaseResult) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	metadata.phaseResults = append(metadata.phaseResults, result)
+}
+
+func (metadata *TestMetadata) GetPhaseResults() []*PhaseResult {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	return append([]*PhaseResult{}, metadata.phaseResults...)
+}
+
+func (metadata *TestMetadata) RecordArtifactPath(artifactName string, filepath string) {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+	metadata.artifactPaths[artifactName] = filepath
+}
+
+// Returns a copy of the artifact name -> filepath mapping, so callers can't modify the record
+func (metadata *TestMetadata) GetArtifactPaths() map[string]string {
+	metadata.mutex.Lock()
+	defer metadata.mutex.Unlock()
+
+	result := map[string]string{}
+	for artifactName, filepath := range metadata.artifactPaths {
+		result[artifactName] = filepath
+	}
+	return result
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_params
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// The struct tag that marks a field of a parameter table row as a parameter, giving the parameter's name
+	paramStructTag = "param"
+
+	paramsStrSeparator = ", "
+)
+
+// Parameter names go into test names, so they're kept to characters that are safe there
+var paramNameRegex = regexp.MustCompile("^[a-zA-Z][a-zA-Z0-9]*$")
+
+// A single parameter of a generated test, with its value formatted for display
+type Param struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+/*
+Gets the parameters of a parameter table row, which must be a struct whose parameter fields are tagged with the
+ parameter's name (e.g. `param:"numBooksRead"`). The parameters are returned in the order the fields are declared.
+
+Only bool, int, uint, float, and string fields can be parameters, since the values must read well in test names.
+*/
+func GetParams(row interface{}) ([]Param, error) {
+	rowValue := reflect.ValueOf(row)
+	if rowValue.Kind() != reflect.Struct {
+		return nil, stacktrace.NewError("Expected parameter table row to be a struct, but was a '%v'", rowValue.Kind())
+	}
+	rowType := rowValue.Type()
+
+	result := []Param{}
+	for i := 0; i < rowType.NumField(); i++ {
+		field := rowType.Field(i)
+		paramName, found := field.Tag.Lookup(paramStructTag)
+		if !found {
+			continue
+		}
+		if !paramNameRegex.MatchString(paramName) {
+			return nil, stacktrace.NewError(
+				"Field '%v' has param name '%v', which doesn't match regex '%v'",
+				field.Name,
+				paramName,
+				paramNameRegex.String(),
+			)
+		}
+		if !isSupportedParamKind(field.Type.Kind()) {
+			return nil, stacktrace.NewError(
+				"Field '%v' (param '%v') is a '%v', which can't be a parameter",
+				field.Name,
+				paramName,
+				field.Type.Kind(),
+			)
+		}
+		result = append(result, Param{
+			Name:  paramName,
+			Value: fmt.Sprintf("%v", rowValue.Field(i).Interface()),
+		})
+	}
+	if len(result) == 0 {
+		return nil, stacktrace.NewError("Parameter table row of type '%v' has no fields tagged as params", rowType)
+	}
+	return result, nil
+}
+
+// Gets a human-readable form of the params for logging, e.g. "personId=23, numBooksRead=3"
+func GetParamsString(params []Param) string {
+	paramStrs := []string{}
+	for _, param := range params {
+		paramStrs = append(paramStrs, param.Name+"="+param.Value)
+	}
+	return strings.Join(paramStrs, paramsStrSeparator)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func isSupportedParamKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_params
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/palantir/stacktrace"
+	"reflect"
+	"regexp"
+)
+
+const (
+	testNameParamSeparator      = "_"
+	testNameParamValueSeparator = "-"
+
+	// What characters in param values that aren't safe in test names are replaced with
+	unsafeTestNameCharReplacement = "-"
+)
+
+var unsafeTestNameCharsRegex = regexp.MustCompile("[^a-zA-Z0-9.]+")
+
+/*
+Creates the test for a single row of a parameter table
+
+The test name is the generated one, which is what the test's images & metadata are keyed by. The row is one of the
+ table's rows, which the factory will need to cast back to its concrete type.
+*/
+type TestFactory func(testName string, row interface{}) network_context.Test
+
+/*
+A test declared once along with a table of parameters, which is expanded into one uniquely-named test per row so that
+ a matrix of cases can be covered without duplicating the test
+
+Each generated test is named after the base name and the row's params, e.g. "myTest_numApis-3_image-my-org-api-1.2".
+*/
+type TestTable struct {
+	baseName string
+	factory  TestFactory
+	rows     []interface{}
+}
+
+func NewTestTable(baseName string, factory TestFactory) *TestTable {
+	return &TestTable{
+		baseName: baseName,
+		factory:  factory,
+		rows:     []interface{}{},
+	}
+}
+
+// Adds a row, which must be a struct whose parameter fields are tagged (see GetParams)
+func (table *TestTable) WithRow(row interface{}) *TestTable {
+	table.rows = append(table.rows, row)
+	return table
+}
+
+func (table TestTable) GetBaseName() string {
+	return table.baseName
+}
+
+/*
+Generates one test per row, verifying that every row is the same type with valid params, and that no two rows
+ generate the same test name
+*/
+func (table TestTable) Expand() ([]*GeneratedTest, error) {
+	if len(table.rows) == 0 {
+		return nil, stacktrace.NewError("Test table '%v' has no rows", table.baseName)
+	}
+
+	rowType := reflect.TypeOf(table.rows[0])
+	generatedTestNames := map[string]bool{}
+	result := []*GeneratedTest{}
+	for idx, row := range table.rows {
+		if reflect.TypeOf(row) != rowType {
+			return nil, stacktrace.NewError(
+				"Row %v of test table '%v' is of type '%v', but the table's rows are of type '%v'",
+				idx,
+				table.baseName,
+				reflect.TypeOf(row),
+				rowType,
+			)
+		}
+		params, err := GetParams(row)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the params of row %v of test table '%v'", idx, table.baseName)
+		}
+		testName := getGeneratedTestName(table.baseName, params)
+		if _, found := generatedTestNames[testName]; found {
+			return nil, stacktrace.NewError(
+				"Row %v of test table '%v' generates test name '%v', which an earlier row already generated",
+				idx,
+				table.baseName,
+				testName,
+			)
+		}
+		generatedTestNames[testName] = true
+		result = append(result, &GeneratedTest{
+			name:     testName,
+			baseName: table.baseName,
+			params:   params,
+			row:      row,
+			factory:  table.factory,
+		})
+	}
+	return result, nil
+}
+
+// A single test generated from a row of a TestTable
+type GeneratedTest struct {
+	name     string
+	baseName string
+	params   []Param
+	row      interface{}
+	factory  TestFactory
+}
+
+func (generated GeneratedTest) GetName() string {
+	return generated.name
+}
+
+// Gets the name of the table the test was generated from
+func (generated GeneratedTest) GetBaseName() string {
+	return generated.baseName
+}
+
+func (generated GeneratedTest) GetParams() []Param {
+	return append([]Param{}, generated.params...)
+}
+
+// Creates a fresh copy of the test
+func (generated GeneratedTest) CreateTest() network_context.Test {
+	return generated.factory(generated.name, generated.row)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func getGeneratedTestName(baseName string, params []Param) string {
+	result := baseName
+	for _, param := range params {
+		safeValue := unsafeTestNameCharsRegex.ReplaceAllString(param.Value, unsafeTestNameCharReplacement)
+		result += testNameParamSeparator + param.Name + testNameParamValueSeparator + safeValue
+	}
+	return result
+}
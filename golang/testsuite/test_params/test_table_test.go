@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_params
+
+import (
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	testBaseName = "myTest"
+)
+
+type validRow struct {
+	NumApis int    `param:"numApis"`
+	Image   string `param:"image"`
+	Enabled bool   `param:"enabled"`
+
+	// Not a param, so it doesn't go in the test name
+	description string
+}
+
+type otherValidRow struct {
+	NumApis int `param:"numApis"`
+}
+
+type rowWithoutParams struct {
+	NumApis int
+}
+
+type rowWithInvalidParamName struct {
+	NumApis int `param:"num-apis"`
+}
+
+type rowWithUnsupportedParamKind struct {
+	ApiIds []int `param:"apiIds"`
+}
+
+func TestTestTable_Expand(t *testing.T) {
+	testCases := []struct {
+		name              string
+		rows              []interface{}
+		expectedTestNames []string
+
+		// True if the table shouldn't expand
+		expectErr bool
+	}{
+		{
+			name:              "single row",
+			rows:              []interface{}{otherValidRow{NumApis: 3}},
+			expectedTestNames: []string{"myTest_numApis-3"},
+		},
+		{
+			name: "params in field order, unsafe characters replaced",
+			rows: []interface{}{
+				validRow{NumApis: 1, Image: "my-org/api:1.2", Enabled: true, description: "ignored"},
+				validRow{NumApis: 2, Image: "my-org/api:1.2", Enabled: false},
+			},
+			expectedTestNames: []string{
+				"myTest_numApis-1_image-my-org-api-1.2_enabled-true",
+				"myTest_numApis-2_image-my-org-api-1.2_enabled-false",
+			},
+		},
+		{
+			name:      "no rows",
+			rows:      []interface{}{},
+			expectErr: true,
+		},
+		{
+			name:      "rows of different types",
+			rows:      []interface{}{otherValidRow{NumApis: 1}, validRow{NumApis: 1}},
+			expectErr: true,
+		},
+		{
+			name:      "rows generating the same name",
+			rows:      []interface{}{validRow{NumApis: 1, Image: "a/b"}, validRow{NumApis: 1, Image: "a:b"}},
+			expectErr: true,
+		},
+		{
+			name:      "row that isn't a struct",
+			rows:      []interface{}{3},
+			expectErr: true,
+		},
+		{
+			name:      "row without params",
+			rows:      []interface{}{rowWithoutParams{NumApis: 1}},
+			expectErr: true,
+		},
+		{
+			name:      "invalid param name",
+			rows:      []interface{}{rowWithInvalidParamName{NumApis: 1}},
+			expectErr: true,
+		},
+		{
+			name:      "unsupported param kind",
+			rows:      []interface{}{rowWithUnsupportedParamKind{ApiIds: []int{1}}},
+			expectErr: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			table := NewTestTable(testBaseName, createNilTest)
+			for _, row := range testCase.rows {
+				table.WithRow(row)
+			}
+			generatedTests, err := table.Expand()
+			if testCase.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			generatedTestNames := []string{}
+			for _, generatedTest := range generatedTests {
+				require.Equal(t, testBaseName, generatedTest.GetBaseName())
+				generatedTestNames = append(generatedTestNames, generatedTest.GetName())
+			}
+			require.Equal(t, testCase.expectedTestNames, generatedTestNames)
+		})
+	}
+}
+
+func TestGeneratedTest_CreateTestGetsNameAndRow(t *testing.T) {
+	row := validRow{NumApis: 2, Image: "api", Enabled: true}
+	var factoryTestName string
+	var factoryRow interface{}
+	factory := func(testName string, row interface{}) network_context.Test {
+		factoryTestName = testName
+		factoryRow = row
+		return nil
+	}
+	generatedTests, err := NewTestTable(testBaseName, factory).WithRow(row).Expand()
+	require.NoError(t, err)
+	require.Len(t, generatedTests, 1)
+
+	generatedTests[0].CreateTest()
+	require.Equal(t, "myTest_numApis-2_image-api_enabled-true", factoryTestName)
+	require.Equal(t, row, factoryRow)
+	require.Equal(
+		t,
+		[]Param{{Name: "numApis", Value: "2"}, {Name: "image", Value: "api"}, {Name: "enabled", Value: "true"}},
+		generatedTests[0].GetParams(),
+	)
+	require.Equal(t, "numApis=2, image=api, enabled=true", GetParamsString(generatedTests[0].GetParams()))
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func createNilTest(testName string, row interface{}) network_context.Test {
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_steps
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	treeIndent = "  "
+)
+
+// A snapshot of a step, for reporting
+type StepRecord struct {
+	Name string
+
+	// The step's name, prefixed with the names of the steps it's nested in (e.g. "add person > verify books read")
+	Path string
+
+	Phase     string
+	StartTime time.Time
+
+	// For a step that's still running, how long it's been running so far
+	Duration time.Duration
+
+	Status StepStatus
+
+	// Empty unless the step failed with an error
+	ErrorMessage string
+
+	SubSteps []*StepRecord
+}
+
+/*
+Renders the steps as an indented tree, one line per step, e.g.:
+ add person (passed in 1.2s)
+   increment books read (failed in 300ms): An error occurred incrementing the number of books read
+*/
+func GetStepTreeString(records []*StepRecord) string {
+	builder := &strings.Builder{}
+	writeStepTree(builder, records, 0)
+	return builder.String()
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Must be called with the recorder's mutex held
+func getStepRecords(steps []*Step) []*StepRecord {
+	result := []*StepRecord{}
+	for _, step := range steps {
+		duration := step.duration
+		if step.status == RunningStatus {
+			duration = time.Since(step.startTime)
+		}
+		result = append(result, &StepRecord{
+			Name:         step.name,
+			Path:         step.getPath(),
+			Phase:        step.phase,
+			StartTime:    step.startTime,
+			Duration:     duration,
+			Status:       step.status,
+			ErrorMessage: step.errorMessage,
+			SubSteps:     getStepRecords(step.subSteps),
+		})
+	}
+	return result
+}
+
+func writeStepTree(builder *strings.Builder, records []*StepRecord, depth int) {
+	for _, record := range records {
+		line := fmt.Sprintf("%v%v (%v in %v)", strings.Repeat(treeIndent, depth), record.Name, record.Status, record.Duration)
+		if record.ErrorMessage != "" {
+			line += ": " + record.ErrorMessage
+		}
+		builder.WriteString(line + "\n")
+		writeStepTree(builder, record.SubSteps, depth+1)
+	}
+}
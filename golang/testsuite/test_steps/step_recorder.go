@@ -0,0 +1,275 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_steps
+
+import (
+	"fmt"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"sync"
+	"time"
+)
+
+type StepStatus string
+
+const (
+	RunningStatus StepStatus = "running"
+	PassedStatus  StepStatus = "passed"
+	FailedStatus  StepStatus = "failed"
+
+	// The step's phase finished successfully without the step being marked done or failed
+	UnfinishedStatus StepStatus = "unfinished"
+
+	stepPathSeparator = " > "
+	logIndent         = "  "
+)
+
+/*
+Records the steps a test goes through, timing each one and logging as each starts & finishes:
+
+ step := stepRecorder.Step("add person")
+ if err := ...; err != nil {
+	return step.Fail(err)
+ }
+ step.Done()
+
+Steps can be nested with Step.Step. When a phase (setup or run) ends, any of its steps that are still running are
+ closed: if the phase failed they're marked failed (the innermost being the step that broke), and otherwise they're
+ marked unfinished.
+*/
+type StepRecorder struct {
+	mutex *sync.Mutex
+
+	// The phase that new steps belong to, or empty if no phase is in progress
+	currentPhase string
+
+	rootSteps []*Step
+
+	listeners []StepListener
+}
+
+/*
+Notified as steps start & finish
+
+Listeners are called with the recorder's lock held (so that they see steps in the order they happened), so they mustn't
+ call back into the recorder.
+*/
+type StepListener interface {
+	OnStepStarted(stepPath string)
+
+	// The error message is empty unless the step failed with an error
+	OnStepFinished(stepPath string, status StepStatus, duration time.Duration, errorMessage string)
+}
+
+func NewStepRecorder() *StepRecorder {
+	return &StepRecorder{
+		mutex:        &sync.Mutex{},
+		currentPhase: "",
+		rootSteps:    []*Step{},
+		listeners:    []StepListener{},
+	}
+}
+
+func (recorder *StepRecorder) AddListener(listener StepListener) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.listeners = append(recorder.listeners, listener)
+}
+
+// Starts a top-level step
+func (recorder *StepRecorder) Step(name string) *Step {
+	return recorder.startStep(nil, name)
+}
+
+// Marks the start of a phase, whose name new steps will be recorded with
+func (recorder *StepRecorder) StartPhase(phase string) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.currentPhase = phase
+}
+
+// Marks the end of the current phase, closing its still-running steps according to the phase's error (nil if it passed)
+func (recorder *StepRecorder) EndPhase(phaseErr error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	for _, step := range recorder.rootSteps {
+		if step.phase == recorder.currentPhase {
+			step.closeIfRunning(phaseErr)
+		}
+	}
+	recorder.currentPhase = ""
+}
+
+// Gets a snapshot of the steps recorded so far, in the order they started
+func (recorder *StepRecorder) GetSteps() []*StepRecord {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return getStepRecords(recorder.rootSteps)
+}
+
+/*
+Gets the path (e.g. "add person > verify books read") of the step that broke, which is the innermost step along the
+ first chain of failed steps, or empty if no step failed
+*/
+func (recorder *StepRecorder) GetFailingStepPath() string {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	failingStep := findFailingStep(recorder.rootSteps)
+	if failingStep == nil {
+		return ""
+	}
+	return failingStep.getPath()
+}
+
+// A single step of a test, which must be ended with either Done or Fail
+type Step struct {
+	recorder *StepRecorder
+
+	// Nil for top-level steps
+	parent *Step
+
+	name      string
+	phase     string
+	startTime time.Time
+
+	// The below are guarded by the recorder's mutex
+	duration time.Duration
+	status   StepStatus
+
+	// Empty unless the step failed with an error
+	errorMessage string
+
+	subSteps []*Step
+}
+
+// Starts a sub-step of this step
+func (step *Step) Step(name string) *Step {
+	return step.recorder.startStep(step, name)
+}
+
+// Marks the step as passed
+func (step *Step) Done() {
+	step.recorder.mutex.Lock()
+	defer step.recorder.mutex.Unlock()
+	if step.status != RunningStatus {
+		logrus.Warnf("Step '%v' was marked done, but it had already finished as %v", step.getPath(), step.status)
+		return
+	}
+	step.close(PassedStatus, nil)
+	logrus.Infof("%vStep '%v' done in %v", step.getLogIndent(), step.name, step.duration)
+}
+
+// Marks the step as failed with the given error, returning the error annotated with the step's path
+func (step *Step) Fail(err error) error {
+	step.recorder.mutex.Lock()
+	defer step.recorder.mutex.Unlock()
+	path := step.getPath()
+	if step.status != RunningStatus {
+		logrus.Warnf("Step '%v' was marked failed, but it had already finished as %v", path, step.status)
+	} else {
+		step.close(FailedStatus, err)
+		logrus.Errorf("%vStep '%v' failed after %v", step.getLogIndent(), step.name, step.duration)
+	}
+	return stacktrace.Propagate(err, "Step '%v' failed", path)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (recorder *StepRecorder) startStep(parent *Step, name string) *Step {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	step := &Step{
+		recorder:     recorder,
+		parent:       parent,
+		name:         name,
+		phase:        recorder.currentPhase,
+		startTime:    time.Now(),
+		duration:     0,
+		status:       RunningStatus,
+		errorMessage: "",
+		subSteps:     []*Step{},
+	}
+	if parent == nil {
+		recorder.rootSteps = append(recorder.rootSteps, step)
+	} else {
+		parent.subSteps = append(parent.subSteps, step)
+	}
+	logrus.Infof("%vStep '%v'...", step.getLogIndent(), name)
+	path := step.getPath()
+	for _, listener := range recorder.listeners {
+		listener.OnStepStarted(path)
+	}
+	return step
+}
+
+// Must be called with the recorder's mutex held
+func (step *Step) close(status StepStatus, err error) {
+	step.duration = time.Since(step.startTime)
+	step.status = status
+	if err != nil {
+		step.errorMessage = fmt.Sprintf("%#s", err)
+	}
+	path := step.getPath()
+	for _, listener := range step.recorder.listeners {
+		listener.OnStepFinished(path, step.status, step.duration, step.errorMessage)
+	}
+}
+
+// Must be called with the recorder's mutex held
+func (step *Step) closeIfRunning(phaseErr error) {
+	hasBrokenSubStep := false
+	for _, subStep := range step.subSteps {
+		subStep.closeIfRunning(phaseErr)
+		if subStep.status == FailedStatus {
+			hasBrokenSubStep = true
+		}
+	}
+	if step.status != RunningStatus {
+		return
+	}
+	if phaseErr == nil {
+		step.close(UnfinishedStatus, nil)
+		return
+	}
+	// Only the step that broke gets the phase's error, rather than every step containing it
+	if hasBrokenSubStep {
+		step.close(FailedStatus, nil)
+	} else {
+		step.close(FailedStatus, phaseErr)
+	}
+}
+
+func (step *Step) getPath() string {
+	names := []string{}
+	for current := step; current != nil; current = current.parent {
+		names = append([]string{current.name}, names...)
+	}
+	return strings.Join(names, stepPathSeparator)
+}
+
+func (step *Step) getLogIndent() string {
+	depth := 0
+	for current := step.parent; current != nil; current = current.parent {
+		depth++
+	}
+	return strings.Repeat(logIndent, depth)
+}
+
+// Must be called with the recorder's mutex held
+func findFailingStep(steps []*Step) *Step {
+	for _, step := range steps {
+		if step.status != FailedStatus {
+			continue
+		}
+		if failingSubStep := findFailingStep(step.subSteps); failingSubStep != nil {
+			return failingSubStep
+		}
+		return step
+	}
+	return nil
+}
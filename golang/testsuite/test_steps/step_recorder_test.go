@@ -0,0 +1,253 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_steps
+
+import (
+	"github.com/palantir/stacktrace"
+	"github.com/stretchr/testify/require"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	setupPhase = "setup"
+	runPhase   = "run"
+)
+
+func TestStepRecorder_NestsSubSteps(t *testing.T) {
+	recorder := NewStepRecorder()
+	recorder.StartPhase(runPhase)
+
+	parent := recorder.Step("add person")
+	child := parent.Step("verify books read")
+	grandchild := child.Step("get person")
+	grandchild.Done()
+	child.Done()
+	parent.Done()
+	sibling := recorder.Step("remove person")
+	sibling.Done()
+	recorder.EndPhase(nil)
+
+	steps := recorder.GetSteps()
+	require.Len(t, steps, 2)
+	require.Equal(t, "add person", steps[0].Path)
+	require.Equal(t, runPhase, steps[0].Phase)
+	require.Len(t, steps[0].SubSteps, 1)
+	require.Equal(t, "add person > verify books read", steps[0].SubSteps[0].Path)
+	require.Len(t, steps[0].SubSteps[0].SubSteps, 1)
+	require.Equal(t, "add person > verify books read > get person", steps[0].SubSteps[0].SubSteps[0].Path)
+	require.Equal(t, "remove person", steps[1].Path)
+	require.Empty(t, steps[1].SubSteps)
+	for _, path := range []string{"add person", "add person > verify books read", "add person > verify books read > get person", "remove person"} {
+		require.Equal(t, PassedStatus, findRecord(t, steps, path).Status, "Step '%v' should have passed", path)
+	}
+	require.Empty(t, recorder.GetFailingStepPath())
+}
+
+func TestStepRecorder_FailMarksStepFailed(t *testing.T) {
+	recorder := NewStepRecorder()
+	recorder.StartPhase(runPhase)
+
+	recorder.Step("add person").Done()
+	parent := recorder.Step("increment books read")
+	child := parent.Step("increment once")
+	err := child.Fail(stacktrace.NewError("Test increment error"))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Step 'increment books read > increment once' failed")
+	require.Contains(t, err.Error(), "Test increment error")
+	require.Error(t, parent.Fail(err))
+
+	steps := recorder.GetSteps()
+	require.Equal(t, PassedStatus, findRecord(t, steps, "add person").Status)
+	childRecord := findRecord(t, steps, "increment books read > increment once")
+	require.Equal(t, FailedStatus, childRecord.Status)
+	require.Contains(t, childRecord.ErrorMessage, "Test increment error")
+	require.Equal(t, FailedStatus, findRecord(t, steps, "increment books read").Status)
+	// The innermost failed step is the one that broke, not the steps containing it
+	require.Equal(t, "increment books read > increment once", recorder.GetFailingStepPath())
+}
+
+func TestStepRecorder_FinishingTwiceKeepsFirstStatus(t *testing.T) {
+	recorder := NewStepRecorder()
+
+	doneStep := recorder.Step("done first")
+	doneStep.Done()
+	require.Error(t, doneStep.Fail(stacktrace.NewError("Test late error")))
+
+	failedStep := recorder.Step("failed first")
+	require.Error(t, failedStep.Fail(stacktrace.NewError("Test error")))
+	failedStep.Done()
+
+	steps := recorder.GetSteps()
+	require.Equal(t, PassedStatus, findRecord(t, steps, "done first").Status)
+	require.Empty(t, findRecord(t, steps, "done first").ErrorMessage)
+	require.Equal(t, FailedStatus, findRecord(t, steps, "failed first").Status)
+}
+
+func TestStepRecorder_EndPhaseClosesRunningSteps(t *testing.T) {
+	testCases := []struct {
+		name     string
+		phaseErr error
+
+		expectedStatuses map[string]StepStatus
+
+		// Empty if no step is expected to get the phase's error
+		expectedStepWithPhaseErr string
+
+		expectedFailingStepPath string
+	}{
+		{
+			name:     "phase passed",
+			phaseErr: nil,
+			expectedStatuses: map[string]StepStatus{
+				"finished":                    PassedStatus,
+				"left running":                UnfinishedStatus,
+				"left running > finished":     PassedStatus,
+				"left running > left running": UnfinishedStatus,
+			},
+			expectedStepWithPhaseErr: "",
+			expectedFailingStepPath:  "",
+		},
+		{
+			name:     "phase failed",
+			phaseErr: stacktrace.NewError("Test phase error"),
+			expectedStatuses: map[string]StepStatus{
+				"finished":                    PassedStatus,
+				"left running":                FailedStatus,
+				"left running > finished":     PassedStatus,
+				"left running > left running": FailedStatus,
+			},
+			expectedStepWithPhaseErr: "left running > left running",
+			expectedFailingStepPath:  "left running > left running",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			recorder := NewStepRecorder()
+			recorder.StartPhase(runPhase)
+			recorder.Step("finished").Done()
+			leftRunning := recorder.Step("left running")
+			leftRunning.Step("finished").Done()
+			leftRunning.Step("left running")
+
+			recorder.EndPhase(testCase.phaseErr)
+
+			steps := recorder.GetSteps()
+			for path, expectedStatus := range testCase.expectedStatuses {
+				record := findRecord(t, steps, path)
+				require.Equal(t, expectedStatus, record.Status, "Step '%v' has the wrong status", path)
+				if path == testCase.expectedStepWithPhaseErr {
+					require.Contains(t, record.ErrorMessage, "Test phase error")
+				} else {
+					require.Empty(t, record.ErrorMessage, "Only the step that broke should get the phase's error, but step '%v' got it too", path)
+				}
+			}
+			require.Equal(t, testCase.expectedFailingStepPath, recorder.GetFailingStepPath())
+		})
+	}
+}
+
+func TestStepRecorder_EndPhaseOnlyClosesItsOwnSteps(t *testing.T) {
+	recorder := NewStepRecorder()
+	recorder.StartPhase(setupPhase)
+	setupStep := recorder.Step("start services")
+	recorder.EndPhase(nil)
+
+	recorder.StartPhase(runPhase)
+	runStep := recorder.Step("add person")
+	recorder.EndPhase(stacktrace.NewError("Test run error"))
+
+	steps := recorder.GetSteps()
+	setupRecord := findRecord(t, steps, "start services")
+	require.Equal(t, setupPhase, setupRecord.Phase)
+	require.Equal(t, UnfinishedStatus, setupRecord.Status)
+	runRecord := findRecord(t, steps, "add person")
+	require.Equal(t, runPhase, runRecord.Phase)
+	require.Equal(t, FailedStatus, runRecord.Status)
+
+	// Steps closed by their phase ending can't be finished again
+	setupStep.Done()
+	runStep.Done()
+	require.Equal(t, UnfinishedStatus, findRecord(t, recorder.GetSteps(), "start services").Status)
+	require.Equal(t, FailedStatus, findRecord(t, recorder.GetSteps(), "add person").Status)
+}
+
+func TestStepRecorder_NotifiesListeners(t *testing.T) {
+	recorder := NewStepRecorder()
+	listener := &recordingListener{}
+	recorder.AddListener(listener)
+
+	parent := recorder.Step("add person")
+	parent.Step("verify books read").Done()
+	require.Error(t, parent.Fail(stacktrace.NewError("Test error")))
+
+	require.Equal(t, []string{"add person", "add person > verify books read"}, listener.startedPaths)
+	require.Equal(t, []string{"add person > verify books read", "add person"}, listener.finishedPaths)
+	require.Equal(t, []StepStatus{PassedStatus, FailedStatus}, listener.finishedStatuses)
+}
+
+func TestGetStepTreeString(t *testing.T) {
+	records := []*StepRecord{
+		{
+			Name:     "add person",
+			Status:   PassedStatus,
+			Duration: time.Second,
+			SubSteps: []*StepRecord{
+				{
+					Name:         "increment books read",
+					Status:       FailedStatus,
+					Duration:     300 * time.Millisecond,
+					ErrorMessage: "Test error",
+					SubSteps:     []*StepRecord{},
+				},
+			},
+		},
+	}
+
+	require.Equal(
+		t,
+		strings.Join([]string{
+			"add person (passed in 1s)",
+			"  increment books read (failed in 300ms): Test error",
+			"",
+		}, "\n"),
+		GetStepTreeString(records),
+	)
+}
+
+// ====================================================================================================
+//
+//	Private helper functions
+//
+// ====================================================================================================
+type recordingListener struct {
+	startedPaths     []string
+	finishedPaths    []string
+	finishedStatuses []StepStatus
+}
+
+func (listener *recordingListener) OnStepStarted(stepPath string) {
+	listener.startedPaths = append(listener.startedPaths, stepPath)
+}
+
+func (listener *recordingListener) OnStepFinished(stepPath string, status StepStatus, duration time.Duration, errorMessage string) {
+	listener.finishedPaths = append(listener.finishedPaths, stepPath)
+	listener.finishedStatuses = append(listener.finishedStatuses, status)
+}
+
+func findRecord(t *testing.T, records []*StepRecord, path string) *StepRecord {
+	for _, record := range records {
+		if record.Path == path {
+			return record
+		}
+		if strings.HasPrefix(path, record.Path+stepPathSeparator) {
+			return findRecord(t, record.SubSteps, path)
+		}
+	}
+	require.FailNow(t, "No step with path '"+path+"' was recorded")
+	return nil
+}
@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_tags
+
+import (
+	"github.com/palantir/stacktrace"
+	"regexp"
+)
+
+/*
+Selects which of a suite's tests run, from tags & name regexes
+
+A test is selected if:
+ - it has at least one of the include tags (or there are no include tags), and
+ - one of its names matches at least one of the include patterns (or there are no include patterns), and
+ - it has none of the exclude tags, and
+ - none of its names match any of the exclude patterns, and
+ - it isn't tagged known-failing, unless known-failing is one of the include tags
+
+A test's names are its own name plus, for a test generated from a test table, the table's base name, so that a pattern
+ naming the table selects (or excludes) every test it generates.
+*/
+type TestFilter struct {
+	includeTags         map[Tag]bool
+	excludeTags         map[Tag]bool
+	includeNamePatterns []*regexp.Regexp
+	excludeNamePatterns []*regexp.Regexp
+}
+
+// Validates the tags and compiles the patterns up front, so that a typo fails the suite rather than silently selecting nothing
+func NewTestFilter(
+	includeTagStrs []string,
+	excludeTagStrs []string,
+	includeNamePatternStrs []string,
+	excludeNamePatternStrs []string,
+) (*TestFilter, error) {
+	includeTags, err := parseTags(includeTagStrs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the include tags")
+	}
+	excludeTags, err := parseTags(excludeTagStrs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred parsing the exclude tags")
+	}
+	includeNamePatterns, err := compilePatterns(includeNamePatternStrs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred compiling the include test name patterns")
+	}
+	excludeNamePatterns, err := compilePatterns(excludeNamePatternStrs)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred compiling the exclude test name patterns")
+	}
+	return &TestFilter{
+		includeTags:         includeTags,
+		excludeTags:         excludeTags,
+		includeNamePatterns: includeNamePatterns,
+		excludeNamePatterns: excludeNamePatterns,
+	}, nil
+}
+
+// A filter that selects every test that isn't known to fail
+func NewMatchAllTestFilter() *TestFilter {
+	return &TestFilter{
+		includeTags:         map[Tag]bool{},
+		excludeTags:         map[Tag]bool{},
+		includeNamePatterns: []*regexp.Regexp{},
+		excludeNamePatterns: []*regexp.Regexp{},
+	}
+}
+
+func (filter TestFilter) IsSelected(testNames []string, tags []Tag) bool {
+	if len(filter.includeTags) > 0 && !hasAnyTag(tags, filter.includeTags) {
+		return false
+	}
+	if len(filter.includeNamePatterns) > 0 && !anyMatchesAnyPattern(testNames, filter.includeNamePatterns) {
+		return false
+	}
+	if hasAnyTag(tags, filter.excludeTags) {
+		return false
+	}
+	if anyMatchesAnyPattern(testNames, filter.excludeNamePatterns) {
+		return false
+	}
+	// Known-failing tests would fail every run that didn't ask for them
+	if _, isIncluded := filter.includeTags[KnownFailing]; !isIncluded && hasAnyTag(tags, map[Tag]bool{KnownFailing: true}) {
+		return false
+	}
+	return true
+}
+
+// Gets the include & exclude patterns that match none of the given test names, which most likely name a test that
+//  doesn't exist (e.g. one that was renamed)
+func (filter TestFilter) GetUnmatchedNamePatterns(testNames []string) []string {
+	result := []string{}
+	allPatterns := append(append([]*regexp.Regexp{}, filter.includeNamePatterns...), filter.excludeNamePatterns...)
+	for _, pattern := range allPatterns {
+		if !anyMatchesAnyPattern(testNames, []*regexp.Regexp{pattern}) {
+			result = append(result, pattern.String())
+		}
+	}
+	return result
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func parseTags(tagStrs []string) (map[Tag]bool, error) {
+	result := map[Tag]bool{}
+	for _, tagStr := range tagStrs {
+		tag := Tag(tagStr)
+		if _, found := knownTags[tag]; !found {
+			return nil, stacktrace.NewError("Unrecognized tag '%v'", tagStr)
+		}
+		result[tag] = true
+	}
+	return result, nil
+}
+
+func compilePatterns(patternStrs []string) ([]*regexp.Regexp, error) {
+	result := []*regexp.Regexp{}
+	for _, patternStr := range patternStrs {
+		pattern, err := regexp.Compile(patternStr)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred compiling pattern '%v'", patternStr)
+		}
+		result = append(result, pattern)
+	}
+	return result, nil
+}
+
+func hasAnyTag(tags []Tag, tagSet map[Tag]bool) bool {
+	for _, tag := range tags {
+		if _, found := tagSet[tag]; found {
+			return true
+		}
+	}
+	return false
+}
+
+func anyMatchesAnyPattern(testNames []string, patterns []*regexp.Regexp) bool {
+	for _, testName := range testNames {
+		for _, pattern := range patterns {
+			if pattern.MatchString(testName) {
+				return true
+			}
+		}
+	}
+	return false
+}
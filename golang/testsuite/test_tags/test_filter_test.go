@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_tags
+
+import (
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+const (
+	plainTestName = "basicDatastoreTest"
+
+	// A test generated from a test table, which is known by both its own name & the table's base name
+	generatedTestName = "datastorePropertyTest_numPeople-10"
+	tableBaseName     = "datastorePropertyTest"
+)
+
+func TestTestFilter_IsSelected(t *testing.T) {
+	testCases := []struct {
+		name                string
+		includeTags         []string
+		excludeTags         []string
+		includeNamePatterns []string
+		excludeNamePatterns []string
+		testNames           []string
+		tags                []Tag
+		expectedIsSelected  bool
+	}{
+		{
+			name:               "empty filter selects untagged test",
+			testNames:          []string{plainTestName},
+			tags:               []Tag{},
+			expectedIsSelected: true,
+		},
+		{
+			name:               "included tag",
+			includeTags:        []string{string(Smoke)},
+			testNames:          []string{plainTestName},
+			tags:               []Tag{Smoke, Slow},
+			expectedIsSelected: true,
+		},
+		{
+			name:               "missing included tag",
+			includeTags:        []string{string(Smoke)},
+			testNames:          []string{plainTestName},
+			tags:               []Tag{Slow},
+			expectedIsSelected: false,
+		},
+		{
+			name:               "excluded tag wins over included tag",
+			includeTags:        []string{string(Smoke)},
+			excludeTags:        []string{string(Slow)},
+			testNames:          []string{plainTestName},
+			tags:               []Tag{Smoke, Slow},
+			expectedIsSelected: false,
+		},
+		{
+			name:                "included name pattern",
+			includeNamePatterns: []string{"^basic"},
+			testNames:           []string{plainTestName},
+			tags:                []Tag{},
+			expectedIsSelected:  true,
+		},
+		{
+			name:                "unmatched included name pattern",
+			includeNamePatterns: []string{"^advanced"},
+			testNames:           []string{plainTestName},
+			tags:                []Tag{},
+			expectedIsSelected:  false,
+		},
+		{
+			name:                "excluded name pattern",
+			excludeNamePatterns: []string{"Datastore"},
+			testNames:           []string{plainTestName},
+			tags:                []Tag{},
+			expectedIsSelected:  false,
+		},
+		{
+			name:                "table base name selects generated test",
+			includeNamePatterns: []string{"^" + tableBaseName + "$"},
+			testNames:           []string{generatedTestName, tableBaseName},
+			tags:                []Tag{},
+			expectedIsSelected:  true,
+		},
+		{
+			name:                "table base name excludes generated test",
+			excludeNamePatterns: []string{"^" + tableBaseName + "$"},
+			testNames:           []string{generatedTestName, tableBaseName},
+			tags:                []Tag{},
+			expectedIsSelected:  false,
+		},
+		{
+			name:               "known-failing test skipped by default",
+			testNames:          []string{plainTestName},
+			tags:               []Tag{KnownFailing},
+			expectedIsSelected: false,
+		},
+		{
+			name:               "known-failing test skipped when other tags are included",
+			includeTags:        []string{string(Perf)},
+			testNames:          []string{plainTestName},
+			tags:               []Tag{Perf, KnownFailing},
+			expectedIsSelected: false,
+		},
+		{
+			name:               "known-failing test selected when explicitly included",
+			includeTags:        []string{string(KnownFailing)},
+			testNames:          []string{plainTestName},
+			tags:               []Tag{Perf, KnownFailing},
+			expectedIsSelected: true,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := NewTestFilter(testCase.includeTags, testCase.excludeTags, testCase.includeNamePatterns, testCase.excludeNamePatterns)
+			require.NoError(t, err)
+			require.Equal(t, testCase.expectedIsSelected, filter.IsSelected(testCase.testNames, testCase.tags))
+		})
+	}
+}
+
+func TestTestFilter_MatchAllSkipsOnlyKnownFailing(t *testing.T) {
+	filter := NewMatchAllTestFilter()
+	require.True(t, filter.IsSelected([]string{plainTestName}, []Tag{Smoke, Slow, Partition, Perf}))
+	require.False(t, filter.IsSelected([]string{plainTestName}, []Tag{KnownFailing}))
+}
+
+func TestTestFilter_GetUnmatchedNamePatterns(t *testing.T) {
+	filter, err := NewTestFilter(
+		[]string{},
+		[]string{},
+		[]string{"^basic", "^" + tableBaseName + "$", "^renamedTest$"},
+		[]string{"Typo$"},
+	)
+	require.NoError(t, err)
+	require.Equal(
+		t,
+		[]string{"^renamedTest$", "Typo$"},
+		filter.GetUnmatchedNamePatterns([]string{plainTestName, generatedTestName, tableBaseName}),
+	)
+}
+
+func TestNewTestFilter_RejectsInvalidArgs(t *testing.T) {
+	_, err := NewTestFilter([]string{"nonexistent-tag"}, []string{}, []string{}, []string{})
+	require.Error(t, err)
+	_, err = NewTestFilter([]string{}, []string{"nonexistent-tag"}, []string{}, []string{})
+	require.Error(t, err)
+	_, err = NewTestFilter([]string{}, []string{}, []string{"("}, []string{})
+	require.Error(t, err)
+	_, err = NewTestFilter([]string{}, []string{}, []string{}, []string{"["})
+	require.Error(t, err)
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package test_tags
+
+type Tag string
+
+const (
+	// Quick, high-value tests that are worth running on every change
+	Smoke Tag = "smoke"
+
+	// Tests that take long enough that they're only worth running periodically
+	Slow Tag = "slow"
+
+	// Tests that repartition the network, and so need partitioning enabled
+	Partition Tag = "partition"
+
+	// Tests that drive concurrent load at the services, so their results depend on timing & the machine they run on
+	Perf Tag = "perf"
+
+	// Tests that are expected to fail (e.g. because they demonstrate a bug that hasn't been fixed yet), which only run
+	//  when this tag is explicitly included
+	KnownFailing Tag = "known-failing"
+)
+
+var knownTags = map[Tag]bool{
+	Smoke:        true,
+	Slow:         true,
+	Partition:    true,
+	Perf:         true,
+	KnownFailing: true,
+}
+
+/*
+Tests that implement this declare tags, which params can use to select which tests run, and an owner, who is the
+ one to ask about the test (e.g. when it fails)
+
+Tests that don't implement this are treated as untagged & unowned.
+*/
+type TaggedTest interface {
+	GetTags() []Tag
+
+	GetOwner() string
+}
+
+// Gets the tags & owner of the given test, which may or may not be a TaggedTest
+func GetTagsAndOwner(test interface{}) ([]Tag, string) {
+	taggedTest, ok := test.(TaggedTest)
+	if !ok {
+		return []Tag{}, ""
+	}
+	return taggedTest.GetTags(), taggedTest.GetOwner()
+}
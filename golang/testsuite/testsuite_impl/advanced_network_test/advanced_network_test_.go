@@ -7,39 +7,61 @@ package advanced_network_test
 
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/assertions"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
 	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
+	"time"
 )
 
 const (
+	owner = "api-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 60
+
 	testPersonId = 46
+
+	// How long a write through one API service may take to be visible through another
+	maxConsistencyWait = 10 * time.Second
 )
 
 type AdvancedNetworkTest struct {
-	datastoreServiceImage string
-	apiServiceImage string
+	ctx *test_context.TestContext
 }
 
-func NewAdvancedNetworkTest(datastoreServiceImage string, apiServiceImage string) *AdvancedNetworkTest {
-	return &AdvancedNetworkTest{datastoreServiceImage: datastoreServiceImage, apiServiceImage: apiServiceImage}
+func NewAdvancedNetworkTest(ctx *test_context.TestContext) *AdvancedNetworkTest {
+	return &AdvancedNetworkTest{ctx: ctx}
 }
 
 func (test *AdvancedNetworkTest) Configure(builder *testsuite.TestConfigurationBuilder) {
-	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test *AdvancedNetworkTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Smoke}
 }
 
-func (test *AdvancedNetworkTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
-	network := networks_impl.NewTestNetwork(networkCtx, test.datastoreServiceImage, test.apiServiceImage)
+func (test *AdvancedNetworkTest) GetOwner() string {
+	return owner
+}
+
+func (test *AdvancedNetworkTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
 	// Note how setup logic has been pushed into a custom Network implementation, to make test-writing easy
+	step := test.ctx.Step("start datastore and two API services")
 	if err := network.SetupDatastoreAndTwoApis(); err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred setting up the network")
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred setting up the network"))
 	}
+	step.Done()
 	return network, nil
 }
 
 func (test *AdvancedNetworkTest) Run(network networks.Network) error {
+	poller := assertions.NewPoller(runTimeoutSeconds * time.Second)
 	castedNetwork := network.(*networks_impl.TestNetwork)
 	personModifierClient, err := castedNetwork.GetPersonModifyingApiClient()
 	if err != nil {
@@ -50,30 +72,34 @@ func (test *AdvancedNetworkTest) Run(network networks.Network) error {
 		return stacktrace.Propagate(err, "An error occurred getting the person-retrieving API client")
 	}
 
-	logrus.Infof("Adding test person via person-modifying API client...")
+	addPersonStep := test.ctx.Step("add test person via person-modifying API client")
 	if err := personModifierClient.AddPerson(testPersonId); err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding test person")
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
 	}
-	logrus.Info("Test person added")
+	addPersonStep.Done()
 
-	logrus.Infof("Incrementing test person's number of books read through person-modifying API client...")
+	incrementStep := test.ctx.Step("increment books read via person-modifying API client")
 	if err := personModifierClient.IncrementBooksRead(testPersonId); err != nil {
-		return stacktrace.Propagate(err, "An error occurred incrementing the number of books read")
+		return incrementStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing the number of books read"))
 	}
-	logrus.Info("Incremented number of books read")
+	incrementStep.Done()
 
-	logrus.Info("Retrieving test person to verify number of books read person-retrieving API client...")
-	person, err := personRetrieverClient.GetPerson(testPersonId)
+	// The retriever reads through a different API service than the one that wrote, so we allow for eventual consistency
+	verifyStep := test.ctx.Step("poll person-retrieving API client for incremented books read")
+	_, err = poller.PollUntil(
+		maxConsistencyWait,
+		func() (interface{}, error) {
+			person, err := personRetrieverClient.GetPerson(testPersonId)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred getting the test person")
+			}
+			return person.BooksRead, assertions.Equal(1, person.BooksRead, "number of books read by the test person")
+		},
+		"the person-retrieving API client to see the incremented number of books read",
+	)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred getting the test person")
-	}
-	logrus.Info("Retrieved test person")
-
-	if person.BooksRead != 1 {
-		return stacktrace.NewError(
-			"Expected number of books read to be incremented, but was '%v'",
-			person.BooksRead,
-		)
+		return verifyStep.Fail(stacktrace.Propagate(err, "The person-retrieving API client never saw the incremented number of books read"))
 	}
+	verifyStep.Done()
 	return nil
 }
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package advanced_network_test
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/diagnostics"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/stand_ins"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"testing"
+)
+
+const (
+	testName = "advancedNetworkTest"
+
+	maxRecordedRequests = 100
+)
+
+func TestAdvancedNetworkTest_SetsUpAndRunsAgainstFakeNetwork(t *testing.T) {
+	metadata := test_metadata.NewTestMetadata(testName)
+	networkCtx, serviceImages, err := stand_ins.NewFakeExampleNetwork(metadata)
+	require.NoError(t, err)
+	t.Cleanup(func() { networkCtx.Destroy() })
+	requestRecorder, err := diagnostics.NewRequestRecorder(http.DefaultTransport, maxRecordedRequests)
+	require.NoError(t, err)
+	test := NewAdvancedNetworkTest(test_context.NewTestContext(serviceImages, requestRecorder, metadata.GetStepRecorder()))
+
+	network, err := test.Setup(networkCtx)
+	require.NoError(t, err)
+	require.Equal(t, []services.ServiceID{"api-0", "api-1", "datastore"}, networkCtx.GetServiceIds())
+	require.Len(t, networkCtx.GetAddServiceCalls(), 3)
+
+	require.NoError(t, test.Run(network))
+	// The test's clients went through the transport it was given
+	require.NotEmpty(t, requestRecorder.GetRecentRequests())
+	require.Empty(t, networkCtx.GetRemovedServiceIds())
+	require.Empty(t, networkCtx.GetRepartitionCalls())
+
+	steps := metadata.GetStepRecorder().GetSteps()
+	require.Len(t, steps, 4)
+	for _, step := range steps {
+		require.Equal(t, test_steps.PassedStatus, step.Status, "Step '%v' didn't pass", step.Path)
+	}
+}
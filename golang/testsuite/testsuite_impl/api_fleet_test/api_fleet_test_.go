@@ -0,0 +1,125 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package api_fleet_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	owner = "api-team"
+
+	setupTimeoutSeconds = 90
+	runTimeoutSeconds   = 90
+
+	initialNumApiServices = 3
+
+	testPersonId = 91
+
+	numIncrementsPerPhase = 6
+)
+
+// Demonstrates scaling the API fleet up and down mid-test while a load-balancing client spreads calls across it
+type ApiFleetTest struct {
+	ctx *test_context.TestContext
+}
+
+func NewApiFleetTest(ctx *test_context.TestContext) *ApiFleetTest {
+	return &ApiFleetTest{ctx: ctx}
+}
+
+func (test ApiFleetTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test ApiFleetTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Slow}
+}
+
+func (test ApiFleetTest) GetOwner() string {
+	return owner
+}
+
+func (test ApiFleetTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	step := test.ctx.Step(fmt.Sprintf("start datastore and %v API services", initialNumApiServices))
+	if err := network.SetupDatastoreAndNApis(initialNumApiServices); err != nil {
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred setting up the network"))
+	}
+	step.Done()
+	return network, nil
+}
+
+func (test ApiFleetTest) Run(network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+	client := castedNetwork.GetLoadBalancingApiClient(networks_impl.RoundRobin)
+
+	addPersonStep := test.ctx.Step("add test person via the load-balanced API fleet")
+	if err := client.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
+	}
+	addPersonStep.Done()
+
+	initialIncrementStep := test.ctx.Step(fmt.Sprintf("increment books read %v times with the initial fleet", numIncrementsPerPhase))
+	if err := incrementBooksRead(client, numIncrementsPerPhase); err != nil {
+		return initialIncrementStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read with the initial fleet"))
+	}
+	initialIncrementStep.Done()
+
+	scaleStep := test.ctx.Step("scale API fleet up by one and down by one")
+	addedServiceId, err := castedNetwork.AddApiService()
+	if err != nil {
+		return scaleStep.Fail(stacktrace.Propagate(err, "An error occurred scaling up the API fleet"))
+	}
+	removedServiceId := castedNetwork.GetApiServiceIds()[0]
+	if err := castedNetwork.RemoveApiService(removedServiceId); err != nil {
+		return scaleStep.Fail(stacktrace.Propagate(err, "An error occurred scaling down the API fleet"))
+	}
+	logrus.Infof("Added API service '%v' and removed API service '%v'", addedServiceId, removedServiceId)
+	scaleStep.Done()
+
+	rescaledIncrementStep := test.ctx.Step(fmt.Sprintf("increment books read %v times with the rescaled fleet", numIncrementsPerPhase))
+	if err := incrementBooksRead(client, numIncrementsPerPhase); err != nil {
+		return rescaledIncrementStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read with the rescaled fleet"))
+	}
+	rescaledIncrementStep.Done()
+
+	verifyStep := test.ctx.Step("verify books read & calls to the added API service")
+	person, err := client.GetPerson(testPersonId)
+	if err != nil {
+		return verifyStep.Fail(stacktrace.Propagate(err, "An error occurred getting the test person"))
+	}
+	expectedBooksRead := 2 * numIncrementsPerPhase
+	if person.BooksRead != expectedBooksRead {
+		return verifyStep.Fail(stacktrace.NewError("Expected number of books read '%v' != actual number of books read '%v'", expectedBooksRead, person.BooksRead))
+	}
+
+	callCounts := client.GetCallCounts()
+	logrus.Infof("Calls per API service: %+v", callCounts)
+	if callCounts[addedServiceId] == 0 {
+		return verifyStep.Fail(stacktrace.NewError("Expected the API service added mid-test to receive calls, but it received none"))
+	}
+	verifyStep.Done()
+	return nil
+}
+
+func incrementBooksRead(client *networks_impl.LoadBalancingApiClient, numIncrements int) error {
+	for i := 0; i < numIncrements; i++ {
+		if err := client.IncrementBooksRead(testPersonId); err != nil {
+			return stacktrace.Propagate(err, "An error occurred incrementing the number of books read")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package api_linearizability_test
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/linearizability"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	owner = "api-team"
+
+	setupTimeoutSeconds = 90
+	runTimeoutSeconds   = 120
+
+	numApiServices = 3
+
+	// Each of these people has exactly one writer
+	firstTestPersonId = 200
+	numTestPersons    = 2
+
+	// Written by every shared writer, if the test has any
+	sharedTestPersonId = 300
+
+	numReaders             = 4
+	numOperationsPerClient = 12
+)
+
+/*
+Drives concurrent clients across every API service, with one writer per person adding the person & incrementing their
+ books read while readers get people from other API services, and checks that the resulting history is linearizable
+
+If the test has shared writers, they also all increment the books read of one more person at once. The example
+ microservice's API increments via an unsynchronized read-modify-write, so concurrent increments of one person lose
+ updates and the history isn't linearizable; such a test is tagged known-failing, so only runs when that tag is
+ included.
+*/
+type ApiLinearizabilityTest struct {
+	ctx *test_context.TestContext
+
+	// 0 for a test in which each person has exactly one writer
+	numSharedWriters int
+}
+
+func NewApiLinearizabilityTest(ctx *test_context.TestContext, numSharedWriters int) *ApiLinearizabilityTest {
+	return &ApiLinearizabilityTest{ctx: ctx, numSharedWriters: numSharedWriters}
+}
+
+func (test ApiLinearizabilityTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test ApiLinearizabilityTest) GetTags() []test_tags.Tag {
+	if test.numSharedWriters > 0 {
+		return []test_tags.Tag{test_tags.Perf, test_tags.KnownFailing}
+	}
+	return []test_tags.Tag{test_tags.Perf}
+}
+
+func (test ApiLinearizabilityTest) GetOwner() string {
+	return owner
+}
+
+func (test ApiLinearizabilityTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	if err := network.SetupDatastoreAndNApis(numApiServices); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred setting up the network")
+	}
+	return network, nil
+}
+
+func (test ApiLinearizabilityTest) Run(network networks.Network) error {
+	return test.RunWithContext(context.Background(), network)
+}
+
+// Stops the workload's clients once the context is cancelled, rather than finishing their calls for nothing
+func (test ApiLinearizabilityTest) RunWithContext(ctx context.Context, network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+
+	apiClients := []*services_impl.ApiClient{}
+	for _, serviceId := range castedNetwork.GetApiServiceIds() {
+		apiClient, err := castedNetwork.GetApiClient(serviceId)
+		if err != nil {
+			return stacktrace.Propagate(err, "An error occurred getting the client for API service '%v'", serviceId)
+		}
+		apiClients = append(apiClients, apiClient)
+	}
+
+	readPersonIds := []int{}
+	for i := 0; i < numTestPersons; i++ {
+		readPersonIds = append(readPersonIds, firstTestPersonId+i)
+	}
+	if test.numSharedWriters > 0 {
+		readPersonIds = append(readPersonIds, sharedTestPersonId)
+	}
+	numWriters := numTestPersons + test.numSharedWriters
+
+	// Clients [0, numTestPersons) are the single writers, one per person, then come the shared writers, and the rest
+	//  are readers
+	doOperation := func(clientId int, operationIdx int, recorder *linearizability.HistoryRecorder) error {
+		if err := ctx.Err(); err != nil {
+			return stacktrace.Propagate(err, "The test was cancelled")
+		}
+		apiClient := apiClients[clientId%len(apiClients)]
+		if clientId < numTestPersons {
+			doWrite(apiClient, recorder, clientId, firstTestPersonId+clientId, operationIdx == 0)
+			return nil
+		}
+		if clientId < numWriters {
+			// The first shared writer adds the shared person; the others' increments fail until it exists, which the
+			//  checker allows for, as failed calls needn't have taken effect
+			doWrite(apiClient, recorder, clientId, sharedTestPersonId, clientId == numTestPersons && operationIdx == 0)
+			return nil
+		}
+
+		personId := readPersonIds[(clientId+operationIdx)%len(readPersonIds)]
+		pending := recorder.Invoke(clientId, personOperationInput{kind: getPersonOperation, personId: personId})
+		person, err := apiClient.GetPerson(personId)
+		if err != nil {
+			pending.Fail(err)
+		} else {
+			pending.Complete(person.BooksRead)
+		}
+		return nil
+	}
+
+	numClients := numWriters + numReaders
+	logrus.Infof(
+		"Running %v concurrent clients making %v calls each across %v API services...",
+		numClients,
+		numOperationsPerClient,
+		len(apiClients),
+	)
+	history, err := linearizability.RunConcurrentWorkload(numClients, numOperationsPerClient, doOperation)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred running the concurrent workload")
+	}
+	logrus.Infof("Recorded a history of %v operations", len(history))
+
+	model := personModel{}
+	violation, err := linearizability.NewChecker(model).Check(history)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred checking the history for linearizability")
+	}
+	if violation != nil {
+		return stacktrace.NewError(
+			"The history of calls to the API services isn't linearizable:\n%v",
+			linearizability.RenderViolation(model, violation),
+		)
+	}
+	logrus.Info("Verified that the history is linearizable")
+	return nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Adds the person if isAdd, or else increments their books read, recording the call in the history
+func doWrite(apiClient *services_impl.ApiClient, recorder *linearizability.HistoryRecorder, clientId int, personId int, isAdd bool) {
+	if isAdd {
+		pending := recorder.Invoke(clientId, personOperationInput{kind: addPersonOperation, personId: personId})
+		if err := apiClient.AddPerson(personId); err != nil {
+			pending.Fail(err)
+		} else {
+			pending.Complete(nil)
+		}
+		return
+	}
+	pending := recorder.Invoke(clientId, personOperationInput{kind: incrementBooksReadOperation, personId: personId})
+	if err := apiClient.IncrementBooksRead(personId); err != nil {
+		pending.Fail(err)
+	} else {
+		pending.Complete(nil)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package api_linearizability_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/linearizability"
+	"sort"
+)
+
+type personOperationKind string
+
+const (
+	addPersonOperation          personOperationKind = "AddPerson"
+	incrementBooksReadOperation personOperationKind = "IncrementBooksRead"
+	getPersonOperation          personOperationKind = "GetPerson"
+)
+
+type personOperationInput struct {
+	kind     personOperationKind
+	personId int
+}
+
+// The state of a single person; comparable, as the checker requires
+type personState struct {
+	exists    bool
+	booksRead int
+}
+
+/*
+Models each person as a register that's added once and then has its number of books read incremented & read
+
+The outputs of GetPerson operations are the number of books read; the other operations have no output.
+*/
+type personModel struct{}
+
+func (model personModel) GetInitialState() interface{} {
+	return personState{exists: false, booksRead: 0}
+}
+
+func (model personModel) Step(state interface{}, operation *linearizability.Operation) (bool, interface{}) {
+	// Go doesn't have generics so we have to do these casts first
+	castedState := state.(personState)
+	input := operation.Input.(personOperationInput)
+
+	switch input.kind {
+	case addPersonOperation:
+		if castedState.exists {
+			return false, castedState
+		}
+		return true, personState{exists: true, booksRead: 0}
+	case incrementBooksReadOperation:
+		if !castedState.exists {
+			return false, castedState
+		}
+		return true, personState{exists: true, booksRead: castedState.booksRead + 1}
+	case getPersonOperation:
+		if !operation.Completed {
+			return true, castedState
+		}
+		booksRead := operation.Output.(int)
+		return castedState.exists && castedState.booksRead == booksRead, castedState
+	default:
+		return false, castedState
+	}
+}
+
+// People don't affect each other, so each person's operations are checked separately
+func (model personModel) Partition(history []*linearizability.Operation) [][]*linearizability.Operation {
+	operationsByPersonId := map[int][]*linearizability.Operation{}
+	for _, operation := range history {
+		personId := operation.Input.(personOperationInput).personId
+		operationsByPersonId[personId] = append(operationsByPersonId[personId], operation)
+	}
+	personIds := []int{}
+	for personId := range operationsByPersonId {
+		personIds = append(personIds, personId)
+	}
+	sort.Ints(personIds)
+
+	result := [][]*linearizability.Operation{}
+	for _, personId := range personIds {
+		result = append(result, operationsByPersonId[personId])
+	}
+	return result
+}
+
+func (model personModel) DescribeOperation(operation *linearizability.Operation) string {
+	input := operation.Input.(personOperationInput)
+	description := fmt.Sprintf("%v(%v)", input.kind, input.personId)
+	switch {
+	case !operation.Completed:
+		return description + " -> ?"
+	case input.kind == getPersonOperation:
+		return fmt.Sprintf("%v -> %v", description, operation.Output)
+	default:
+		return description + " -> ok"
+	}
+}
@@ -6,222 +6,124 @@
 package basic_datastore_and_api_test
 
 import (
-	"encoding/json"
 	"fmt"
-	"github.com/kurtosis-tech/example-microservice/api/api_service_client"
-	"github.com/kurtosis-tech/example-microservice/datastore/datastore_service_client"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/assertions"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
 	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
-	"os"
+	"time"
 )
 
 const (
-	datastoreImage                        = "kurtosistech/example-microservices_datastore"
-	datastoreServiceId services.ServiceID = "datastore"
-	datastorePort                         = 1323
-
-	apiServiceImage                    = "kurtosistech/example-microservices_api"
-	apiServiceId    services.ServiceID = "api"
-	apiServicePort                     = 2434
-
-	waitForStartupDelayMilliseconds = 1000
-	waitForStartupMaxPolls          = 15
+	owner = "api-team"
 
-	testPersonId     = 23
-	testNumBooksRead = 3
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 60
 
-	configFileKey = "config-file"
+	datastoreServiceId services.ServiceID = "datastore"
+	apiServiceId       services.ServiceID = "api"
 )
 
-type datastoreConfig struct {
-	DatastoreIp   string `json:"datastoreIp"`
-	DatastorePort int    `json:"datastorePort"`
+// A row of the test's parameter table (see test_params.TestTable)
+type Params struct {
+	PersonId     int `param:"personId"`
+	NumBooksRead int `param:"numBooksRead"`
 }
 
 type BasicDatastoreAndApiTest struct {
-	datastoreImage string
-	apiImage       string
+	ctx    *test_context.TestContext
+	params Params
 }
 
-func NewBasicDatastoreAndApiTest(datastoreImage string, apiImage string) *BasicDatastoreAndApiTest {
-	return &BasicDatastoreAndApiTest{datastoreImage: datastoreImage, apiImage: apiImage}
+func NewBasicDatastoreAndApiTest(ctx *test_context.TestContext, params Params) *BasicDatastoreAndApiTest {
+	return &BasicDatastoreAndApiTest{ctx: ctx, params: params}
 }
 
 func (b BasicDatastoreAndApiTest) Configure(builder *testsuite.TestConfigurationBuilder) {
-	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
 }
 
-func (b BasicDatastoreAndApiTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
-
-	datastoreContainerCreationConfig, datastoreRunConfigFunc := getDatastoreServiceConfigurations()
-
-	datastoreServiceContext, datastoreSvcHostPortBindings, err := networkCtx.AddService(datastoreServiceId, datastoreContainerCreationConfig, datastoreRunConfigFunc)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
-	}
-
-	datastoreClient := datastore_service_client.NewDatastoreClient(datastoreServiceContext.GetIPAddress(), datastorePort)
-
-	err = datastoreClient.WaitForHealthy(waitForStartupMaxPolls, waitForStartupDelayMilliseconds)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred waiting for the datastore service to become available")
-	}
+func (b BasicDatastoreAndApiTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Smoke}
+}
 
-	logrus.Infof("Added datastore service with host port bindings: %+v", datastoreSvcHostPortBindings)
+func (b BasicDatastoreAndApiTest) GetOwner() string {
+	return owner
+}
 
-	apiServiceContainerCreationConfig, apiServiceRunConfigFunc := getApiServiceConfigurations(datastoreClient)
+func (b BasicDatastoreAndApiTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	readinessWaiter := readiness.NewWaiter(time.Now().Add(setupTimeoutSeconds * time.Second))
+	startServicesStep := b.ctx.Step("start services")
 
-	apiServiceContext, apiSvcHostPortBindings, err := networkCtx.AddService(apiServiceId, apiServiceContainerCreationConfig, apiServiceRunConfigFunc)
+	datastoreStep := startServicesStep.Step("start datastore service")
+	datastoreDefinition := services_impl.NewDatastoreServiceDefinition(b.ctx.GetImages().GetDatastoreImage())
+	datastoreServiceContext, _, err := services_impl.AddService(networkCtx, datastoreServiceId, datastoreDefinition, b.ctx.GetImages(), readinessWaiter)
 	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the API service")
+		return nil, datastoreStep.Fail(stacktrace.Propagate(err, "An error occurred adding the datastore service"))
 	}
-
-	apiClient := api_service_client.NewAPIClient(apiServiceContext.GetIPAddress(), apiServicePort)
-
-	err = apiClient.WaitForHealthy(waitForStartupMaxPolls, waitForStartupDelayMilliseconds)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred waiting for the api service to become available")
+	datastoreStep.Done()
+
+	apiStep := startServicesStep.Step("start API service")
+	apiDefinition := services_impl.NewApiServiceDefinition(
+		b.ctx.GetImages().GetApiImage(),
+		datastoreServiceContext.GetIPAddress(),
+		services_impl.DatastorePort,
+	)
+	if _, _, err := services_impl.AddService(networkCtx, apiServiceId, apiDefinition, b.ctx.GetImages(), readinessWaiter); err != nil {
+		return nil, apiStep.Fail(stacktrace.Propagate(err, "An error occurred adding the API service"))
 	}
+	apiStep.Done()
 
-	logrus.Infof("Added API service with host port bindings: %+v", apiSvcHostPortBindings)
+	startServicesStep.Done()
 	return networkCtx, nil
 }
 
 func (b BasicDatastoreAndApiTest) Run(network networks.Network) error {
 	// Go doesn't have generics so we have to do this cast first
-	castedNetwork := network.(*networks.NetworkContext)
+	castedNetwork := network.(network_context.NetworkContext)
 
 	serviceContext, err := castedNetwork.GetServiceContext(apiServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the API service context")
 	}
 
-	apiClient := api_service_client.NewAPIClient(serviceContext.GetIPAddress(), apiServicePort)
+	apiClient := services_impl.NewApiClient(serviceContext.GetIPAddress(), b.ctx.GetHttpTransport())
 
-	logrus.Infof("Verifying that person with test ID '%v' doesn't already exist...", testPersonId)
-	if _, err = apiClient.GetPerson(testPersonId); err == nil {
-		return stacktrace.NewError("Expected an error trying to get a person who doesn't exist yet, but didn't receive one")
+	verifyAbsentStep := b.ctx.Step(fmt.Sprintf("verify person %v doesn't exist", b.params.PersonId))
+	if _, err = apiClient.GetPerson(b.params.PersonId); err == nil {
+		return verifyAbsentStep.Fail(stacktrace.NewError("Expected an error trying to get a person who doesn't exist yet, but didn't receive one"))
 	}
-	logrus.Infof("Verified that test person doesn't already exist")
+	verifyAbsentStep.Done()
 
-	logrus.Infof("Adding test person with ID '%v'...", testPersonId)
-	if err := apiClient.AddPerson(testPersonId); err != nil {
-		return stacktrace.Propagate(err, "An error occurred adding person with test ID '%v'", testPersonId)
+	addPersonStep := b.ctx.Step(fmt.Sprintf("add person %v", b.params.PersonId))
+	if err := apiClient.AddPerson(b.params.PersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding person with test ID '%v'", b.params.PersonId))
 	}
-	logrus.Info("Test person added")
+	addPersonStep.Done()
 
-	logrus.Infof("Incrementing test person's number of books read by %v...", testNumBooksRead)
-	for i := 0; i < testNumBooksRead; i++ {
-		if err := apiClient.IncrementBooksRead(testPersonId); err != nil {
-			return stacktrace.Propagate(err, "An error occurred incrementing the number of books read")
+	incrementStep := b.ctx.Step(fmt.Sprintf("increment books read %v times", b.params.NumBooksRead))
+	for i := 0; i < b.params.NumBooksRead; i++ {
+		if err := apiClient.IncrementBooksRead(b.params.PersonId); err != nil {
+			return incrementStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing the number of books read"))
 		}
 	}
-	logrus.Info("Incremented number of books read")
+	incrementStep.Done()
 
-	logrus.Info("Retrieving test person to verify number of books read...")
-	person, err := apiClient.GetPerson(testPersonId)
+	verifyBooksReadStep := b.ctx.Step("verify books read")
+	person, err := apiClient.GetPerson(b.params.PersonId)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred getting the test person to verify the number of books read")
+		return verifyBooksReadStep.Fail(stacktrace.Propagate(err, "An error occurred getting the test person to verify the number of books read"))
 	}
-	logrus.Info("Retrieved test person")
-
-	if person.BooksRead != testNumBooksRead {
-		return stacktrace.NewError(
-			"Expected number of book read '%v' != actual number of books read '%v'",
-			testNumBooksRead,
-			person.BooksRead,
-		)
+	if err := assertions.Equal(b.params.NumBooksRead, person.BooksRead, "number of books read by person %v", b.params.PersonId); err != nil {
+		return verifyBooksReadStep.Fail(stacktrace.Propagate(err, "The test person's number of books read is wrong"))
 	}
+	verifyBooksReadStep.Done()
 
 	return nil
 }
-
-// ====================================================================================================
-//                                       Private helper functions
-// ====================================================================================================
-
-func getDatastoreServiceConfigurations() (*services.ContainerCreationConfig, func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)) {
-	datastoreContainerCreationConfig := getDataStoreContainerCreationConfig()
-
-	datastoreRunConfigFunc := getDataStoreRunConfigFunc()
-	return datastoreContainerCreationConfig, datastoreRunConfigFunc
-}
-
-func getDataStoreContainerCreationConfig() *services.ContainerCreationConfig {
-	containerCreationConfig := services.NewContainerCreationConfigBuilder(
-		datastoreImage,
-	).WithUsedPorts(
-		map[string]bool{fmt.Sprintf("%v/tcp", datastorePort): true},
-	).Build()
-	return containerCreationConfig
-}
-
-func getDataStoreRunConfigFunc() func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-	runConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-		return services.NewContainerRunConfigBuilder().Build(), nil
-	}
-	return runConfigFunc
-}
-
-func getApiServiceConfigurations(datastoreClient *datastore_service_client.DatastoreClient) (*services.ContainerCreationConfig, func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)) {
-	configInitializingFunc := getApiServiceConfigInitializingFunc(datastoreClient)
-
-	apiServiceContainerCreationConfig := getApiServiceContainerCreationConfig(configInitializingFunc)
-
-	apiServiceRunConfigFunc := getApiServiceRunConfigFunc()
-	return apiServiceContainerCreationConfig, apiServiceRunConfigFunc
-}
-
-func getApiServiceConfigInitializingFunc(datastoreClient *datastore_service_client.DatastoreClient) func(fp *os.File) error {
-	configInitializingFunc := func(fp *os.File) error {
-		logrus.Debugf("Datastore IP: %v , port: %v", datastoreClient.IpAddr(), datastoreClient.Port())
-		configObj := datastoreConfig{
-			DatastoreIp:   datastoreClient.IpAddr(),
-			DatastorePort: datastoreClient.Port(),
-		}
-		configBytes, err := json.Marshal(configObj)
-		if err != nil {
-			return stacktrace.Propagate(err, "An error occurred serializing the config to JSON")
-		}
-
-		logrus.Debugf("API config JSON: %v", string(configBytes))
-
-		if _, err := fp.Write(configBytes); err != nil {
-			return stacktrace.Propagate(err, "An error occurred writing the serialized config JSON to file")
-		}
-
-		return nil
-	}
-	return configInitializingFunc
-}
-
-func getApiServiceContainerCreationConfig(configInitializingFunc func(fp *os.File) error) *services.ContainerCreationConfig {
-	apiServiceContainerCreationConfig := services.NewContainerCreationConfigBuilder(
-		apiServiceImage,
-	).WithUsedPorts(
-		map[string]bool{fmt.Sprintf("%v/tcp", apiServicePort): true},
-	).WithGeneratedFiles(map[string]func(*os.File) error{
-		configFileKey: configInitializingFunc,
-	}).Build()
-	return apiServiceContainerCreationConfig
-}
-
-func getApiServiceRunConfigFunc() func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-	apiServiceRunConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-		configFilepath, found := generatedFileFilepaths[configFileKey]
-		if !found {
-			return nil, stacktrace.NewError("No filepath found for config file key '%v'", configFileKey)
-		}
-		startCmd := []string{
-			"./api.bin",
-			"--config",
-			configFilepath,
-		}
-		result := services.NewContainerRunConfigBuilder().WithCmdOverride(startCmd).Build()
-		return result, nil
-	}
-	return apiServiceRunConfigFunc
-}
@@ -6,120 +6,96 @@
 package basic_datastore_test
 
 import (
-	"fmt"
-	"github.com/kurtosis-tech/example-microservice/datastore/datastore_service_client"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/assertions"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
 	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
 	"github.com/palantir/stacktrace"
-	"github.com/sirupsen/logrus"
+	"time"
 )
 
 const (
-	datastoreImage                        = "kurtosistech/example-microservices_datastore"
+	owner = "datastore-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 60
+
 	datastoreServiceId services.ServiceID = "datastore"
-	datastorePort                         = 1323
 	testKey                               = "test-key"
 	testValue                             = "test-value"
-
-	waitForStartupDelayMilliseconds = 1000
-	waitForStartupMaxPolls          = 15
 )
 
 type BasicDatastoreTest struct {
-	datastoreImage string
+	ctx *test_context.TestContext
 }
 
-func NewBasicDatastoreTest(datastoreImage string) *BasicDatastoreTest {
-	return &BasicDatastoreTest{datastoreImage: datastoreImage}
+func NewBasicDatastoreTest(ctx *test_context.TestContext) *BasicDatastoreTest {
+	return &BasicDatastoreTest{ctx: ctx}
 }
 
 func (test BasicDatastoreTest) Configure(builder *testsuite.TestConfigurationBuilder) {
-	builder.WithSetupTimeoutSeconds(60).WithRunTimeoutSeconds(60)
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
 }
 
-func (test BasicDatastoreTest) Setup(networkCtx *networks.NetworkContext) (networks.Network, error) {
-
-	containerCreationConfig, runConfigFunc := getDatastoreServiceConfigurations()
-
-	serviceContext, hostPortBindings, err := networkCtx.AddService(datastoreServiceId, containerCreationConfig, runConfigFunc)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
-	}
+func (test BasicDatastoreTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Smoke}
+}
 
-	datastoreClient := datastore_service_client.NewDatastoreClient(serviceContext.GetIPAddress(), datastorePort)
+func (test BasicDatastoreTest) GetOwner() string {
+	return owner
+}
 
-	err = datastoreClient.WaitForHealthy(waitForStartupMaxPolls, waitForStartupDelayMilliseconds)
-	if err != nil {
-		return nil, stacktrace.Propagate(err, "An error occurred waiting for the datastore service to become available")
+func (test BasicDatastoreTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	step := test.ctx.Step("start datastore service")
+	readinessWaiter := readiness.NewWaiter(time.Now().Add(setupTimeoutSeconds * time.Second))
+	datastoreDefinition := services_impl.NewDatastoreServiceDefinition(test.ctx.GetImages().GetDatastoreImage())
+	if _, _, err := services_impl.AddService(networkCtx, datastoreServiceId, datastoreDefinition, test.ctx.GetImages(), readinessWaiter); err != nil {
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred adding the datastore service"))
 	}
-
-	logrus.Infof("Added datastore service with host port bindings: %+v", hostPortBindings)
+	step.Done()
 	return networkCtx, nil
 }
 
 func (test BasicDatastoreTest) Run(network networks.Network) error {
 	// Necessary because Go doesn't have generics
-	castedNetwork := network.(*networks.NetworkContext)
+	castedNetwork := network.(network_context.NetworkContext)
 
 	serviceContext, err := castedNetwork.GetServiceContext(datastoreServiceId)
 	if err != nil {
 		return stacktrace.Propagate(err, "An error occurred getting the datastore service info")
 	}
 
-	datastoreClient := datastore_service_client.NewDatastoreClient(serviceContext.GetIPAddress(), datastorePort)
+	datastoreClient := services_impl.NewDatastoreClient(serviceContext.GetIPAddress(), test.ctx.GetHttpTransport())
 
-	logrus.Infof("Verifying that key '%v' doesn't already exist...", testKey)
+	verifyAbsentStep := test.ctx.Step("verify test key doesn't exist")
 	exists, err := datastoreClient.Exists(testKey)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred checking if the test key exists")
+		return verifyAbsentStep.Fail(stacktrace.Propagate(err, "An error occurred checking if the test key exists"))
 	}
-	if exists {
-		return stacktrace.NewError("Test key should not exist yet")
+	if err := assertions.False(exists, "whether key '%v' exists before it's inserted", testKey); err != nil {
+		return verifyAbsentStep.Fail(stacktrace.Propagate(err, "Test key should not exist yet"))
 	}
-	logrus.Infof("Confirmed that key '%v' doesn't already exist", testKey)
+	verifyAbsentStep.Done()
 
-	logrus.Infof("Inserting value '%v' at key '%v'...", testKey, testValue)
+	upsertStep := test.ctx.Step("insert test value")
 	if err := datastoreClient.Upsert(testKey, testValue); err != nil {
-		return stacktrace.Propagate(err, "An error occurred upserting the test key")
+		return upsertStep.Fail(stacktrace.Propagate(err, "An error occurred upserting the test key"))
 	}
-	logrus.Infof("Inserted value successfully")
+	upsertStep.Done()
 
-	logrus.Infof("Getting the key we just inserted to verify the value...")
+	verifyValueStep := test.ctx.Step("verify test value")
 	value, err := datastoreClient.Get(testKey)
 	if err != nil {
-		return stacktrace.Propagate(err, "An error occurred getting the test key after upload")
+		return verifyValueStep.Fail(stacktrace.Propagate(err, "An error occurred getting the test key after upload"))
 	}
-	if value != testValue {
-		return stacktrace.NewError("Returned value '%v' != test value '%v'", value, testValue)
+	if err := assertions.Equal(testValue, value, "value at key '%v'", testKey); err != nil {
+		return verifyValueStep.Fail(stacktrace.Propagate(err, "The datastore returned the wrong value for the test key"))
 	}
-	logrus.Info("Value verified")
+	verifyValueStep.Done()
 	return nil
 }
-
-// ====================================================================================================
-//                                       Private helper functions
-// ====================================================================================================
-
-func getDatastoreServiceConfigurations() (*services.ContainerCreationConfig, func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error)) {
-	containerCreationConfig := getContainerCreationConfig()
-
-	runConfigFunc := getRunConfigFunc()
-	return containerCreationConfig, runConfigFunc
-}
-
-func getContainerCreationConfig() *services.ContainerCreationConfig {
-	containerCreationConfig := services.NewContainerCreationConfigBuilder(
-		datastoreImage,
-	).WithUsedPorts(
-		map[string]bool{fmt.Sprintf("%v/tcp", datastorePort): true},
-	).Build()
-	return containerCreationConfig
-}
-
-func getRunConfigFunc() func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-	runConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
-		return services.NewContainerRunConfigBuilder().Build(), nil
-	}
-	return runConfigFunc
-}
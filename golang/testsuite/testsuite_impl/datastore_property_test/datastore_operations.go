@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package datastore_property_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/property_testing"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"math/rand"
+)
+
+const (
+	// Few enough keys that operations frequently hit keys that earlier operations wrote
+	numKeys = 3
+
+	numValues = 5
+
+	// Relative likelihoods of generating each operation type
+	existsOperationWeight = 1
+	getOperationWeight    = 2
+	upsertOperationWeight = 2
+)
+
+// An operation against the datastore, which checks its result against the reference model
+type datastoreOperation interface {
+	property_testing.Operation
+
+	// Applies the operation to both the datastore (with the key prefixed, so that each run has a fresh keyspace) and the model
+	apply(client *services_impl.DatastoreClient, keyPrefix string, model map[string]string) error
+}
+
+type existsOperation struct {
+	key string
+}
+
+func (operation existsOperation) String() string {
+	return fmt.Sprintf("Exists(%v)", operation.key)
+}
+
+func (operation existsOperation) apply(client *services_impl.DatastoreClient, keyPrefix string, model map[string]string) error {
+	actual, err := client.Exists(keyPrefix + operation.key)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred checking if key '%v' exists", operation.key)
+	}
+	_, expected := model[operation.key]
+	if actual != expected {
+		return stacktrace.NewError("Expected key '%v' to exist = %v, but the datastore said %v", operation.key, expected, actual)
+	}
+	return nil
+}
+
+type getOperation struct {
+	key string
+}
+
+func (operation getOperation) String() string {
+	return fmt.Sprintf("Get(%v)", operation.key)
+}
+
+func (operation getOperation) apply(client *services_impl.DatastoreClient, keyPrefix string, model map[string]string) error {
+	actual, err := client.Get(keyPrefix + operation.key)
+	expected, found := model[operation.key]
+	if !found {
+		if err == nil {
+			return stacktrace.NewError("Expected getting nonexistent key '%v' to fail, but got value '%v'", operation.key, actual)
+		}
+		return nil
+	}
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting key '%v', which should have value '%v'", operation.key, expected)
+	}
+	if actual != expected {
+		return stacktrace.NewError("Expected key '%v' to have value '%v', but the datastore returned '%v'", operation.key, expected, actual)
+	}
+	return nil
+}
+
+type upsertOperation struct {
+	key   string
+	value string
+}
+
+func (operation upsertOperation) String() string {
+	return fmt.Sprintf("Upsert(%v, %v)", operation.key, operation.value)
+}
+
+func (operation upsertOperation) apply(client *services_impl.DatastoreClient, keyPrefix string, model map[string]string) error {
+	if err := client.Upsert(keyPrefix+operation.key, operation.value); err != nil {
+		return stacktrace.Propagate(err, "An error occurred upserting value '%v' at key '%v'", operation.value, operation.key)
+	}
+	model[operation.key] = operation.value
+	return nil
+}
+
+func generateDatastoreOperation(random *rand.Rand) property_testing.Operation {
+	key := fmt.Sprintf("key%v", random.Intn(numKeys))
+	choice := random.Intn(existsOperationWeight + getOperationWeight + upsertOperationWeight)
+	switch {
+	case choice < existsOperationWeight:
+		return existsOperation{key: key}
+	case choice < existsOperationWeight+getOperationWeight:
+		return getOperation{key: key}
+	default:
+		return upsertOperation{key: key, value: fmt.Sprintf("value%v", random.Intn(numValues))}
+	}
+}
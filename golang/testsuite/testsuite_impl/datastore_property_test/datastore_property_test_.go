@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package datastore_property_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/property_testing"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	owner = "datastore-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 180
+
+	datastoreServiceId services.ServiceID = "datastore"
+
+	numSequences      = 50
+	maxSequenceLength = 30
+)
+
+/*
+Checks that the datastore behaves like a map, by running random sequences of Exists/Get/Upsert operations against
+ both the datastore and an in-memory map and comparing the results
+
+A failure reports the seed the sequences were generated with, which can be passed back in to replay the same sequences.
+*/
+type DatastorePropertyTest struct {
+	ctx *test_context.TestContext
+
+	// 0 means a seed will be picked when the test runs
+	seed int64
+}
+
+func NewDatastorePropertyTest(ctx *test_context.TestContext, seed int64) *DatastorePropertyTest {
+	return &DatastorePropertyTest{ctx: ctx, seed: seed}
+}
+
+func (test DatastorePropertyTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test DatastorePropertyTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Slow}
+}
+
+func (test DatastorePropertyTest) GetOwner() string {
+	return owner
+}
+
+func (test DatastorePropertyTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	readinessWaiter := readiness.NewWaiter(time.Now().Add(setupTimeoutSeconds * time.Second))
+	datastoreDefinition := services_impl.NewDatastoreServiceDefinition(test.ctx.GetImages().GetDatastoreImage())
+	if _, _, err := services_impl.AddService(networkCtx, datastoreServiceId, datastoreDefinition, test.ctx.GetImages(), readinessWaiter); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred adding the datastore service")
+	}
+	return networkCtx, nil
+}
+
+func (test DatastorePropertyTest) Run(network networks.Network) error {
+	// Necessary because Go doesn't have generics
+	castedNetwork := network.(network_context.NetworkContext)
+
+	serviceContext, err := castedNetwork.GetServiceContext(datastoreServiceId)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the datastore service info")
+	}
+	datastoreClient := services_impl.NewDatastoreClient(serviceContext.GetIPAddress(), test.ctx.GetHttpTransport())
+
+	seed := test.seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	// The datastore can't be cleared, so each run of a sequence gets a fresh keyspace instead
+	numRuns := 0
+	runSequence := func(operations []property_testing.Operation) error {
+		numRuns++
+		keyPrefix := fmt.Sprintf("run%v-", numRuns)
+		model := map[string]string{}
+		for idx, operation := range operations {
+			// Go doesn't have generics so we have to do this cast first
+			castedOperation := operation.(datastoreOperation)
+			if err := castedOperation.apply(datastoreClient, keyPrefix, model); err != nil {
+				return stacktrace.Propagate(err, "Operation %v (%v) didn't match the model", idx+1, operation)
+			}
+		}
+		return nil
+	}
+
+	checker := property_testing.NewPropertyChecker(generateDatastoreOperation, runSequence, seed).
+		WithNumSequences(numSequences).
+		WithMaxSequenceLength(maxSequenceLength)
+	if err := checker.Check(); err != nil {
+		return stacktrace.Propagate(err, "The datastore didn't behave like the model")
+	}
+	logrus.Infof("The datastore behaved like the model across %v runs", numRuns)
+	return nil
+}
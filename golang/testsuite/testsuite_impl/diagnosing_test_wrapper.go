@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite_impl
+
+import (
+	"context"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/diagnostics"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_params"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// The directory of the suite's output directory that diagnostics bundles go in
+	diagnosticsDirname = "diagnostics"
+)
+
+/*
+Wraps a test so that, if its setup or run fails, a diagnostics bundle (the test's services, their images, generated files
+ & readiness probe history, and the test clients' most recent requests) is written to the given directory
+*/
+type diagnosingTest struct {
+	underlying         network_context.Test
+	metadata           *test_metadata.TestMetadata
+	requestRecorder    *diagnostics.RequestRecorder
+	diagnosticsDirpath string
+}
+
+func newDiagnosingTest(
+	underlying network_context.Test,
+	metadata *test_metadata.TestMetadata,
+	requestRecorder *diagnostics.RequestRecorder,
+	diagnosticsDirpath string,
+) *diagnosingTest {
+	return &diagnosingTest{
+		underlying:         underlying,
+		metadata:           metadata,
+		requestRecorder:    requestRecorder,
+		diagnosticsDirpath: diagnosticsDirpath,
+	}
+}
+
+func (test diagnosingTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	test.underlying.Configure(builder)
+}
+
+func (test diagnosingTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	// Each test runs in its own testsuite container, but we clear anyways so a bundle never shows another test's requests
+	test.requestRecorder.Clear()
+
+	if params := test.metadata.GetParams(); len(params) > 0 {
+		logrus.Infof("Test '%v' is running with params: %v", test.metadata.GetTestName(), test_params.GetParamsString(params))
+	}
+
+	network, err := test.underlying.Setup(networkCtx)
+	if err != nil {
+		return nil, test.writeDiagnosticsBundle(test_lifecycle.SetupPhase, err)
+	}
+	return network, nil
+}
+
+func (test diagnosingTest) Run(network networks.Network) error {
+	return test.RunWithContext(context.Background(), network)
+}
+
+func (test diagnosingTest) RunWithContext(ctx context.Context, network networks.Network) error {
+	if err := test_lifecycle.RunTest(ctx, test.underlying, network); err != nil {
+		return test.writeDiagnosticsBundle(test_lifecycle.RunPhase, err)
+	}
+	return nil
+}
+
+func (test diagnosingTest) Teardown(network networks.Network) error {
+	return test_lifecycle.TeardownTest(test.underlying, network)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Writes the diagnostics bundle for the given test error, returning the error to report for the test
+func (test diagnosingTest) writeDiagnosticsBundle(phase string, testErr error) error {
+	bundleFilepath, err := diagnostics.WriteBundle(test.diagnosticsDirpath, phase, testErr, test.metadata, test.requestRecorder)
+	if err != nil {
+		logrus.Errorf(
+			"Test '%v' failed during %v, and an error occurred writing its diagnostics bundle:\n%v",
+			test.metadata.GetTestName(),
+			phase,
+			err,
+		)
+		return testErr
+	}
+	test.metadata.RecordArtifactPath(diagnostics.BundleArtifactName, bundleFilepath)
+	return stacktrace.Propagate(testErr, "The test failed during %v; a diagnostics bundle was written to '%v'", phase, bundleFilepath)
+}
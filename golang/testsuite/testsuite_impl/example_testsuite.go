@@ -7,34 +7,234 @@ package testsuite_impl
 
 import (
 	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/rpc_api/bindings"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/diagnostics"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/reports"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_params"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/advanced_network_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/api_fleet_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/api_linearizability_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/basic_datastore_and_api_test"
 	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/basic_datastore_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/datastore_property_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/fault_proxy_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/lost_update_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/service_replacement_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/split_brain_test"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/testsuite_impl/topology_test"
 	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"path"
+	"sort"
+)
+
+const (
+	basicDatastoreTestName                  = "basicDatastoreTest"
+	basicDatastoreAndApiTestName            = "basicDatastoreAndApiTest"
+	advancedNetworkTestName                 = "advancedNetworkTest"
+	topologyTestName                        = "topologyTest"
+	apiFleetTestName                        = "apiFleetTest"
+	splitBrainTestName                      = "splitBrainTest"
+	serviceReplacementTestName              = "serviceReplacementTest"
+	faultProxyTestName                      = "faultProxyTest"
+	datastorePropertyTestName               = "datastorePropertyTest"
+	apiLinearizabilityTestName              = "apiLinearizabilityTest"
+	sharedWritersApiLinearizabilityTestName = "sharedWritersApiLinearizabilityTest"
+	lostUpdateTestName                      = "lostUpdateTest"
+
+	// How many writers share a person in sharedWritersApiLinearizabilityTest
+	numSharedWritersOfLinearizabilityTest = 3
+
+	apiFleetTopologyStaticFileId     services.StaticFileID = "api-fleet-topology"
+	apiFleetTopologyRelativeFilepath                       = "topologies/datastore-and-api-fleet.yml"
 )
 
 type ExampleTestsuite struct {
-	apiServiceImage string
-	datastoreServiceImage string
+	imageResolver *service_images.ImageResolver
+
+	// The directory holding the contents of the static_files directory, which the suite's static files are resolved in
+	staticFilesDirpath string
+
+	/*
+	Where diagnostics bundles & reports are written: the suite execution volume in the testsuite container (so that
+	 they outlive it, and CI can collect them), or a local directory when running locally
+	*/
+	outputDirpath string
+
+	// Records the test clients' requests, for the diagnostics bundles of failed tests
+	requestRecorder *diagnostics.RequestRecorder
+
+	// Writes each finished test's report, and the suite's reports, to the output directory
+	reportWriter *reports.ReportWriter
+
+	// The seed the property-based tests generate their operations from, or 0 to pick one when they run
+	propertyTestSeed int64
+
+	// Mapping of test name -> metadata, created once so it survives the suite's tests being re-fetched between
+	//  setup and run
+	testMetadata map[string]*test_metadata.TestMetadata
+
+	// Mapping of test name -> the context the test is built with, whose images are resolved once when the suite is created
+	testContexts map[string]*test_context.TestContext
+
+	// Mapping of test name -> the test generated from a test table, for the tests that are declared via test tables
+	generatedTests map[string]*test_params.GeneratedTest
+
+	// The names of the tests that the params' filters selected; only these are given to Kurtosis
+	selectedTestNames map[string]bool
 }
 
-func NewExampleTestsuite(apiServiceImage string, datastoreServiceImage string) *ExampleTestsuite {
-	return &ExampleTestsuite{apiServiceImage: apiServiceImage, datastoreServiceImage: datastoreServiceImage}
+func NewExampleTestsuite(
+	imageResolver *service_images.ImageResolver,
+	staticFilesDirpath string,
+	outputDirpath string,
+	requestRecorder *diagnostics.RequestRecorder,
+	testFilter *test_tags.TestFilter,
+	propertyTestSeed int64,
+) (*ExampleTestsuite, error) {
+	suite := &ExampleTestsuite{
+		imageResolver:      imageResolver,
+		staticFilesDirpath: staticFilesDirpath,
+		outputDirpath:      outputDirpath,
+		requestRecorder:    requestRecorder,
+		reportWriter:       reports.NewReportWriter(path.Join(outputDirpath, reportsDirname), reportsSuiteName),
+		propertyTestSeed:   propertyTestSeed,
+		testMetadata:       map[string]*test_metadata.TestMetadata{},
+		testContexts:       map[string]*test_context.TestContext{},
+		generatedTests:     map[string]*test_params.GeneratedTest{},
+		selectedTestNames:  map[string]bool{},
+	}
+
+	testNames := []string{
+		basicDatastoreTestName,
+		advancedNetworkTestName,
+		topologyTestName,
+		apiFleetTestName,
+		splitBrainTestName,
+		serviceReplacementTestName,
+		faultProxyTestName,
+		datastorePropertyTestName,
+		apiLinearizabilityTestName,
+		sharedWritersApiLinearizabilityTestName,
+		lostUpdateTestName,
+	}
+	for _, testName := range testNames {
+		suite.testMetadata[testName] = test_metadata.NewTestMetadata(testName)
+	}
+
+	for _, table := range suite.getTestTables() {
+		generatedTests, err := table.Expand()
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred expanding test table '%v'", table.GetBaseName())
+		}
+		for _, generatedTest := range generatedTests {
+			testName := generatedTest.GetName()
+			if _, found := suite.testMetadata[testName]; found {
+				return nil, stacktrace.NewError("Test table '%v' generated test '%v', but a test with that name already exists", table.GetBaseName(), testName)
+			}
+			metadata := test_metadata.NewTestMetadata(testName)
+			metadata.SetParams(generatedTest.GetParams())
+			suite.testMetadata[testName] = metadata
+			suite.generatedTests[testName] = generatedTest
+			logrus.Debugf(
+				"Test table '%v' generated test '%v' with params: %v",
+				table.GetBaseName(),
+				testName,
+				test_params.GetParamsString(generatedTest.GetParams()),
+			)
+		}
+	}
+
+	for testName := range imageResolver.GetOverriddenTestNames() {
+		if _, found := suite.testMetadata[testName]; !found {
+			return nil, stacktrace.NewError("Image overrides were provided for test '%v', but no test with that name exists", testName)
+		}
+	}
+	for testName, metadata := range suite.testMetadata {
+		images := imageResolver.ResolveForTest(metadata)
+		suite.testContexts[testName] = test_context.NewTestContext(images, requestRecorder, metadata.GetStepRecorder())
+	}
+
+	// A pattern that matches nothing is almost certainly a typo or a stale test name, which would otherwise silently
+	//  select or exclude the wrong tests
+	allTestNames := []string{}
+	for testName := range suite.testMetadata {
+		allTestNames = append(allTestNames, testName)
+	}
+	for _, table := range suite.getTestTables() {
+		allTestNames = append(allTestNames, table.GetBaseName())
+	}
+	if unmatchedPatterns := testFilter.GetUnmatchedNamePatterns(allTestNames); len(unmatchedPatterns) > 0 {
+		return nil, stacktrace.NewError("The test name patterns in the params %v don't match any test in the suite", unmatchedPatterns)
+	}
+
+	excludedTestNames := []string{}
+	// Fault proxies are optional, so a suite without a fault proxy image (e.g. a freshly-bootstrapped one) leaves out the
+	//  tests that need one rather than failing them
+	testNamesNeedingFaultProxyImage := []string{}
+	for testName, test := range suite.getAllTests() {
+		tags, owner := test_tags.GetTagsAndOwner(test)
+		suite.testMetadata[testName].SetTagsAndOwner(tags, owner)
+		filterNames := []string{testName}
+		if generatedTest, found := suite.generatedTests[testName]; found {
+			filterNames = append(filterNames, generatedTest.GetBaseName())
+		}
+		if !testFilter.IsSelected(filterNames, tags) {
+			excludedTestNames = append(excludedTestNames, testName)
+			continue
+		}
+		if faultProxyUsingTest, ok := test.(service_images.FaultProxyUsingTest); ok && faultProxyUsingTest.UsesFaultProxies() && !imageResolver.HasFaultProxyImage() {
+			testNamesNeedingFaultProxyImage = append(testNamesNeedingFaultProxyImage, testName)
+			continue
+		}
+		suite.selectedTestNames[testName] = true
+	}
+	if len(excludedTestNames) > 0 {
+		sort.Strings(excludedTestNames)
+		logrus.Infof("The test filters in the params excluded tests: %v", excludedTestNames)
+	}
+	if len(testNamesNeedingFaultProxyImage) > 0 {
+		sort.Strings(testNamesNeedingFaultProxyImage)
+		logrus.Warnf("Skipping tests %v, which start fault proxies, as the faultProxyServiceImage param wasn't set", testNamesNeedingFaultProxyImage)
+	}
+	if len(suite.selectedTestNames) == 0 {
+		return nil, stacktrace.NewError("Every test in the suite was excluded by the test filters in the params, or needs a fault proxy image that wasn't given")
+	}
+
+	return suite, nil
 }
 
 func (suite ExampleTestsuite) GetTests() map[string]testsuite.Test {
-	tests := map[string]testsuite.Test{
-		"basicDatastoreTest": basic_datastore_test.NewBasicDatastoreTest(suite.datastoreServiceImage),
-		"basicDatastoreAndApiTest": basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
-		),
-		"advancedNetworkTest": advanced_network_test.NewAdvancedNetworkTest(
-			suite.datastoreServiceImage,
-			suite.apiServiceImage,
-		),
+	result := map[string]testsuite.Test{}
+	for testName, test := range suite.GetNetworkContextTests() {
+		result[testName] = network_context.NewKurtosisTest(test)
+	}
+	return result
+}
+
+/*
+Gets the suite's tests in a form that can be set up against any network_context.NetworkContext implementation, rather
+ than only the real one that GetTests' tests receive from Kurtosis
+*/
+func (suite ExampleTestsuite) GetNetworkContextTests() map[string]network_context.Test {
+	result := map[string]network_context.Test{}
+	for testName, test := range suite.getAllTests() {
+		if _, found := suite.selectedTestNames[testName]; !found {
+			continue
+		}
+		metadata := suite.testMetadata[testName]
+		// The reporting wrapper goes outermost so its timings & failures include writing the diagnostics bundle
+		diagnosingTest := newDiagnosingTest(test, metadata, suite.requestRecorder, path.Join(suite.outputDirpath, diagnosticsDirname))
+		result[testName] = newReportingTest(diagnosingTest, metadata, suite.reportWriter)
 	}
-	return tests
+	return result
 }
 
 func (suite ExampleTestsuite) GetNetworkWidthBits() uint32 {
@@ -42,8 +242,106 @@ func (suite ExampleTestsuite) GetNetworkWidthBits() uint32 {
 }
 
 func (suite ExampleTestsuite) GetStaticFiles() map[services.StaticFileID]string {
-	return map[services.StaticFileID]string{}
+	return map[services.StaticFileID]string{
+		apiFleetTopologyStaticFileId: path.Join(suite.staticFilesDirpath, apiFleetTopologyRelativeFilepath),
+	}
+}
+
+func (suite ExampleTestsuite) GetTestProgressPublisher(testName string) *test_progress.Publisher {
+	metadata, found := suite.testMetadata[testName]
+	if !found {
+		return nil
+	}
+	return metadata.GetProgressPublisher()
 }
 
+func (suite ExampleTestsuite) AddTestResultDetails(testName string, phaseErr error, result *bindings.TestResult) {
+	metadata, found := suite.testMetadata[testName]
+	if !found {
+		return
+	}
+	reports.AddTestResultDetails(metadata, phaseErr, result)
+}
 
+// Gets the suite-side metadata for every test, including the images each test's services were started with
+func (suite ExampleTestsuite) GetTestMetadata() map[string]*test_metadata.TestMetadata {
+	return suite.testMetadata
+}
 
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Gets every test in the suite, whether or not the filters selected it
+func (suite ExampleTestsuite) getAllTests() map[string]network_context.Test {
+	result := map[string]network_context.Test{
+		basicDatastoreTestName: basic_datastore_test.NewBasicDatastoreTest(
+			suite.testContexts[basicDatastoreTestName],
+		),
+		advancedNetworkTestName: advanced_network_test.NewAdvancedNetworkTest(
+			suite.testContexts[advancedNetworkTestName],
+		),
+		topologyTestName: topology_test.NewTopologyTest(
+			suite.testContexts[topologyTestName],
+			suite.GetStaticFiles()[apiFleetTopologyStaticFileId],
+		),
+		apiFleetTestName: api_fleet_test.NewApiFleetTest(
+			suite.testContexts[apiFleetTestName],
+		),
+		splitBrainTestName: split_brain_test.NewSplitBrainTest(
+			suite.testContexts[splitBrainTestName],
+		),
+		serviceReplacementTestName: service_replacement_test.NewServiceReplacementTest(
+			suite.testContexts[serviceReplacementTestName],
+		),
+		faultProxyTestName: fault_proxy_test.NewFaultProxyTest(
+			suite.testContexts[faultProxyTestName],
+		),
+		datastorePropertyTestName: datastore_property_test.NewDatastorePropertyTest(
+			suite.testContexts[datastorePropertyTestName],
+			suite.propertyTestSeed,
+		),
+		apiLinearizabilityTestName: api_linearizability_test.NewApiLinearizabilityTest(
+			suite.testContexts[apiLinearizabilityTestName],
+			0,
+		),
+		sharedWritersApiLinearizabilityTestName: api_linearizability_test.NewApiLinearizabilityTest(
+			suite.testContexts[sharedWritersApiLinearizabilityTestName],
+			numSharedWritersOfLinearizabilityTest,
+		),
+		lostUpdateTestName: lost_update_test.NewLostUpdateTest(
+			suite.testContexts[lostUpdateTestName],
+		),
+	}
+	for testName, generatedTest := range suite.generatedTests {
+		result[testName] = generatedTest.CreateTest()
+	}
+	return result
+}
+
+// Gets the tables of the tests that are declared once and expanded into a test per row of params
+func (suite ExampleTestsuite) getTestTables() []*test_params.TestTable {
+	basicDatastoreAndApiTestTable := test_params.NewTestTable(
+		basicDatastoreAndApiTestName,
+		func(testName string, row interface{}) network_context.Test {
+			// Go doesn't have generics so we have to do this cast first
+			params := row.(basic_datastore_and_api_test.Params)
+			return basic_datastore_and_api_test.NewBasicDatastoreAndApiTest(
+				suite.testContexts[testName],
+				params,
+			)
+		},
+	).WithRow(basic_datastore_and_api_test.Params{
+		PersonId:     23,
+		NumBooksRead: 3,
+	}).WithRow(basic_datastore_and_api_test.Params{
+		PersonId:     24,
+		NumBooksRead: 0,
+	}).WithRow(basic_datastore_and_api_test.Params{
+		PersonId:     25,
+		NumBooksRead: 10,
+	})
+
+	return []*test_params.TestTable{
+		basicDatastoreAndApiTestTable,
+	}
+}
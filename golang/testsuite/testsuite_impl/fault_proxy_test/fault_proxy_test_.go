@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package fault_proxy_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/fault_proxy"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	owner = "test-infra-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 90
+
+	testPersonId = 72
+
+	// Longer than the API service's timeout on datastore requests, so requests through the proxy time out
+	injectedLatencyMillis = 3000
+)
+
+/*
+Demonstrates injecting faults between an API service and the datastore: the API service's requests fail while the
+ proxy delays or blackholes its traffic, and succeed again once the faults are cleared
+
+The test also demonstrates a teardown hook (see test_lifecycle.TeardownableTest), which releases the proxies whether
+ or not the run got as far as clearing their faults.
+*/
+type FaultProxyTest struct {
+	ctx *test_context.TestContext
+}
+
+func NewFaultProxyTest(ctx *test_context.TestContext) *FaultProxyTest {
+	return &FaultProxyTest{ctx: ctx}
+}
+
+func (test FaultProxyTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test FaultProxyTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Slow}
+}
+
+func (test FaultProxyTest) GetOwner() string {
+	return owner
+}
+
+// The suite leaves this test out if no fault proxy image was configured
+func (test FaultProxyTest) UsesFaultProxies() bool {
+	return true
+}
+
+func (test FaultProxyTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	step := test.ctx.Step("start datastore and two API services")
+	if err := network.SetupDatastoreAndTwoApis(); err != nil {
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred setting up the network"))
+	}
+	step.Done()
+	return network, nil
+}
+
+func (test FaultProxyTest) Run(network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+	proxiedApiServiceId := castedNetwork.GetApiServiceIds()[0]
+
+	insertStep := test.ctx.Step(fmt.Sprintf("insert fault proxy between API service '%v' and the datastore", proxiedApiServiceId))
+	proxiedApiServiceId, proxyClient, err := castedNetwork.InsertFaultProxyBeforeDatastore(proxiedApiServiceId)
+	if err != nil {
+		return insertStep.Fail(stacktrace.Propagate(err, "An error occurred inserting the fault proxy"))
+	}
+	proxiedClient, err := castedNetwork.GetApiClient(proxiedApiServiceId)
+	if err != nil {
+		return insertStep.Fail(stacktrace.Propagate(err, "An error occurred getting the client for proxied API service '%v'", proxiedApiServiceId))
+	}
+	logrus.Infof("Fault proxy inserted; API service is now '%v'", proxiedApiServiceId)
+	insertStep.Done()
+
+	addPersonStep := test.ctx.Step("add test person through the proxy without faults")
+	if err := proxiedClient.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person through the proxy while no faults were injected"))
+	}
+	addPersonStep.Done()
+
+	latencyStep := test.ctx.Step(fmt.Sprintf("verify requests time out with %vms of latency injected", injectedLatencyMillis))
+	if err := proxyClient.SetFaults(fault_proxy.Faults{LatencyMillis: injectedLatencyMillis}); err != nil {
+		return latencyStep.Fail(stacktrace.Propagate(err, "An error occurred injecting latency"))
+	}
+	if err := proxiedClient.IncrementBooksRead(testPersonId); err == nil {
+		return latencyStep.Fail(stacktrace.NewError("Expected incrementing books read to time out while latency was injected, but it succeeded"))
+	}
+	latencyStep.Done()
+
+	blackholeStep := test.ctx.Step("verify requests fail with traffic blackholed")
+	if err := proxyClient.SetFaults(fault_proxy.Faults{Blackhole: true}); err != nil {
+		return blackholeStep.Fail(stacktrace.Propagate(err, "An error occurred blackholing traffic"))
+	}
+	if _, err := proxiedClient.GetPerson(testPersonId); err == nil {
+		return blackholeStep.Fail(stacktrace.NewError("Expected getting the test person to fail while traffic was blackholed, but it succeeded"))
+	}
+	if _, err := proxyClient.ResetConnections(); err != nil {
+		return blackholeStep.Fail(stacktrace.Propagate(err, "An error occurred resetting the connections stuck in the blackhole"))
+	}
+	blackholeStep.Done()
+
+	clearStep := test.ctx.Step("verify requests succeed once faults are cleared")
+	if err := proxyClient.ClearFaults(); err != nil {
+		return clearStep.Fail(stacktrace.Propagate(err, "An error occurred clearing the faults"))
+	}
+	if err := proxiedClient.IncrementBooksRead(testPersonId); err != nil {
+		return clearStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read after the faults were cleared"))
+	}
+	// Only the increment made after the faults were cleared should have landed
+	if err := castedNetwork.AssertApisSeeBooksRead(castedNetwork.GetApiServiceIds(), testPersonId, 1); err != nil {
+		return clearStep.Fail(stacktrace.Propagate(err, "The API services didn't agree on the test person after the faults were cleared"))
+	}
+	clearStep.Done()
+	return nil
+}
+
+// A run that fails or is cancelled partway through can leave faults injected, so the proxies are released here
+func (test FaultProxyTest) Teardown(network networks.Network) error {
+	// The network is nil if setup failed, in which case no proxies were inserted
+	if network == nil {
+		return nil
+	}
+	castedNetwork := network.(*networks_impl.TestNetwork)
+	if err := castedNetwork.RemoveFaultProxies(); err != nil {
+		return stacktrace.Propagate(err, "An error occurred removing the fault proxies")
+	}
+	return nil
+}
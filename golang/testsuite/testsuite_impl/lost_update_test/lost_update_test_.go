@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package lost_update_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	owner = "api-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 60
+
+	testPersonId = 64
+
+	numConcurrentIncrements = 20
+)
+
+/*
+Fires concurrent IncrementBooksRead calls at two API services sharing one datastore, and verifies that none of the
+ increments were lost to read-modify-write races
+
+NOTE: The example microservice's API has a real read-modify-write race: it increments by getting the person from the
+ datastore and upserting them with one more book read, with nothing stopping two increments from reading the same
+ value. This test therefore fails against it (reporting the loss rate) until increments are made atomic, so it's
+ tagged known-failing and only runs when that tag is included.
+*/
+type LostUpdateTest struct {
+	ctx *test_context.TestContext
+}
+
+func NewLostUpdateTest(ctx *test_context.TestContext) *LostUpdateTest {
+	return &LostUpdateTest{ctx: ctx}
+}
+
+func (test LostUpdateTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test LostUpdateTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Perf, test_tags.KnownFailing}
+}
+
+func (test LostUpdateTest) GetOwner() string {
+	return owner
+}
+
+func (test LostUpdateTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	if err := network.SetupDatastoreAndTwoApis(); err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred setting up the network")
+	}
+	return network, nil
+}
+
+func (test LostUpdateTest) Run(network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+
+	modifyingClient, err := castedNetwork.GetPersonModifyingApiClient()
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the person-modifying API client")
+	}
+	addPersonStep := test.ctx.Step(fmt.Sprintf("add test person %v", testPersonId))
+	if err := modifyingClient.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
+	}
+	addPersonStep.Done()
+
+	incrementsStep := test.ctx.Step(fmt.Sprintf("fire %v concurrent increments", numConcurrentIncrements))
+	result, err := castedNetwork.RunConcurrentIncrements(testPersonId, numConcurrentIncrements)
+	if err != nil {
+		return incrementsStep.Fail(stacktrace.Propagate(err, "An error occurred running the concurrent increments"))
+	}
+	if result.NumFailed > 0 {
+		return incrementsStep.Fail(stacktrace.NewError(
+			"Expected all %v concurrent increments to succeed, but %v failed",
+			result.NumIncrements,
+			result.NumFailed,
+		))
+	}
+	if result.FinalBooksRead != numConcurrentIncrements {
+		return incrementsStep.Fail(stacktrace.NewError(
+			"Expected books read to be %v after %v concurrent increments, but was %v; %v updates were lost (a loss rate of %.1f%%)",
+			numConcurrentIncrements,
+			numConcurrentIncrements,
+			result.FinalBooksRead,
+			result.GetNumLostUpdates(),
+			result.GetLossRate()*100,
+		))
+	}
+	incrementsStep.Done()
+	logrus.Infof("Verified that none of the %v concurrent increments were lost", numConcurrentIncrements)
+	return nil
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package testsuite_impl
+
+import (
+	"context"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_lifecycle"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/lib/test_progress"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/assertions"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/reports"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_metadata"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_steps"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"github.com/sirupsen/logrus"
+	"time"
+)
+
+const (
+	// The directory of the suite's output directory that reports go in
+	reportsDirname = "reports"
+
+	reportsSuiteName = "example-testsuite"
+)
+
+/*
+Wraps a test so that its setup & run are timed and, once the test finishes (setup fails, or run completes), its steps
+ are logged, its JSON report is written, and the suite's JSON & JUnit XML reports are regenerated
+
+Each phase is marked on the test's step recorder, so that steps left running by a failed phase are marked as failed,
+ and a phase that fails on an assertion publishes the assertion's failure to the test's progress publisher.
+
+A panic in setup or run is recorded as that phase's failure before being re-panicked for Kurtosis to handle.
+*/
+type reportingTest struct {
+	underlying   network_context.Test
+	metadata     *test_metadata.TestMetadata
+	reportWriter *reports.ReportWriter
+}
+
+func newReportingTest(underlying network_context.Test, metadata *test_metadata.TestMetadata, reportWriter *reports.ReportWriter) *reportingTest {
+	return &reportingTest{underlying: underlying, metadata: metadata, reportWriter: reportWriter}
+}
+
+func (test reportingTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	test.underlying.Configure(builder)
+}
+
+func (test reportingTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	var network networks.Network
+	err := test.runPhase(test_lifecycle.SetupPhase, func() error {
+		var setupErr error
+		network, setupErr = test.underlying.Setup(networkCtx)
+		return setupErr
+	})
+	if err != nil {
+		// The test won't run, so this is its last chance to report
+		test.finishTest()
+		return nil, err
+	}
+	return network, nil
+}
+
+func (test reportingTest) Run(network networks.Network) error {
+	return test.RunWithContext(context.Background(), network)
+}
+
+func (test reportingTest) RunWithContext(ctx context.Context, network networks.Network) error {
+	err := test.runPhase(test_lifecycle.RunPhase, func() error {
+		return test_lifecycle.RunTest(ctx, test.underlying, network)
+	})
+	test.finishTest()
+	return err
+}
+
+func (test reportingTest) Teardown(network networks.Network) error {
+	return test_lifecycle.TeardownTest(test.underlying, network)
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+// Runs the phase's function, recording the phase's result (including a panic, which is re-panicked) in the metadata
+func (test reportingTest) runPhase(phase string, function func() error) (resultErr error) {
+	stepRecorder := test.metadata.GetStepRecorder()
+	stepRecorder.StartPhase(phase)
+	startTime := time.Now()
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		recoverResult := recover()
+		panicErr := stacktrace.NewError("A panic occurred during %v: %v", phase, recoverResult)
+		stepRecorder.EndPhase(panicErr)
+		test.metadata.RecordPhaseResult(&test_metadata.PhaseResult{
+			Phase:     phase,
+			StartTime: startTime,
+			Duration:  time.Since(startTime),
+			Error:     panicErr,
+		})
+		test.finishTest()
+		panic(recoverResult)
+	}()
+	resultErr = function()
+	completed = true
+
+	stepRecorder.EndPhase(resultErr)
+	if resultErr != nil && stacktrace.GetCode(resultErr) == assertions.FailureErrorCode {
+		assertionErr := stacktrace.RootCause(resultErr)
+		test.metadata.GetProgressPublisher().Publish(test_progress.NewAssertionFailedEvent(
+			stepRecorder.GetFailingStepPath(),
+			fmt.Sprintf("%#s", assertionErr),
+		))
+	}
+	test.metadata.RecordPhaseResult(&test_metadata.PhaseResult{
+		Phase:     phase,
+		StartTime: startTime,
+		Duration:  time.Since(startTime),
+		Error:     resultErr,
+	})
+	return resultErr
+}
+
+// Logs the test's steps and writes its report; a report that can't be written shouldn't fail the test, so we only log
+//  the error
+func (test reportingTest) finishTest() {
+	testName := test.metadata.GetTestName()
+	stepRecorder := test.metadata.GetStepRecorder()
+	if steps := stepRecorder.GetSteps(); len(steps) > 0 {
+		logrus.Infof("Steps of test '%v':\n%v", testName, test_steps.GetStepTreeString(steps))
+	}
+
+	testReport := reports.NewTestReport(test.metadata)
+	if testReport.Failure != nil && testReport.Failure.Step != "" {
+		logrus.Errorf("Test '%v' failed during %v at step '%v'", testName, testReport.Failure.Phase, testReport.Failure.Step)
+	}
+	if err := test.reportWriter.WriteTestReport(testReport); err != nil {
+		logrus.Errorf("An error occurred writing the report for test '%v':\n%v", testName, err)
+		return
+	}
+	test.metadata.RecordArtifactPath(reports.TestReportArtifactName, test.reportWriter.GetTestReportFilepath(testName))
+}
@@ -0,0 +1,117 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package service_replacement_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+const (
+	owner = "test-infra-team"
+
+	setupTimeoutSeconds = 60
+	runTimeoutSeconds   = 120
+
+	testPersonId = 58
+)
+
+/*
+Demonstrates a rolling upgrade of the API services followed by a datastore crash, using the replacement operations
+ on TestNetwork
+*/
+type ServiceReplacementTest struct {
+	ctx *test_context.TestContext
+}
+
+func NewServiceReplacementTest(ctx *test_context.TestContext) *ServiceReplacementTest {
+	return &ServiceReplacementTest{ctx: ctx}
+}
+
+func (test ServiceReplacementTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test ServiceReplacementTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Slow}
+}
+
+func (test ServiceReplacementTest) GetOwner() string {
+	return owner
+}
+
+func (test ServiceReplacementTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	step := test.ctx.Step("start datastore and two API services")
+	if err := network.SetupDatastoreAndTwoApis(); err != nil {
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred setting up the network"))
+	}
+	step.Done()
+	return network, nil
+}
+
+func (test ServiceReplacementTest) Run(network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+	client := castedNetwork.GetLoadBalancingApiClient(networks_impl.RoundRobin)
+
+	addPersonStep := test.ctx.Step("add test person and increment books read")
+	if err := client.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
+	}
+	if err := client.IncrementBooksRead(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read"))
+	}
+	addPersonStep.Done()
+
+	// A real upgrade would use a newer image; the test's own image is enough to exercise the mechanics
+	rollStep := test.ctx.Step("roll API services one at a time")
+	for _, serviceId := range castedNetwork.GetApiServiceIds() {
+		replaceStep := rollStep.Step(fmt.Sprintf("replace API service '%v'", serviceId))
+		if _, err := castedNetwork.ReplaceServiceImage(serviceId, test.ctx.GetImages().GetApiImage()); err != nil {
+			return replaceStep.Fail(stacktrace.Propagate(err, "An error occurred replacing API service '%v'", serviceId))
+		}
+		if err := client.IncrementBooksRead(testPersonId); err != nil {
+			return replaceStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read after replacing API service '%v'", serviceId))
+		}
+		replaceStep.Done()
+	}
+	expectedBooksRead := 1 + len(castedNetwork.GetApiServiceIds())
+	if err := castedNetwork.AssertApisSeeBooksRead(castedNetwork.GetApiServiceIds(), testPersonId, expectedBooksRead); err != nil {
+		return rollStep.Fail(stacktrace.Propagate(err, "The API services lost writes during the rolling upgrade"))
+	}
+	rollStep.Done()
+
+	restartStep := test.ctx.Step("restart datastore")
+	if _, err := castedNetwork.RestartDatastore(); err != nil {
+		return restartStep.Fail(stacktrace.Propagate(err, "An error occurred restarting the datastore"))
+	}
+	restartStep.Done()
+
+	verifyStep := test.ctx.Step("verify network recovered from datastore restart")
+	personModifierClient, err := castedNetwork.GetPersonModifyingApiClient()
+	if err != nil {
+		return verifyStep.Fail(stacktrace.Propagate(err, "An error occurred getting the person-modifying API client after the restart"))
+	}
+	// The datastore only keeps data in memory, so the restart is expected to have wiped the test person
+	if _, err := personModifierClient.GetPerson(testPersonId); err == nil {
+		return verifyStep.Fail(stacktrace.NewError("Expected the test person to be gone after the datastore restarted, but it was still found"))
+	}
+	if err := personModifierClient.AddPerson(testPersonId); err != nil {
+		return verifyStep.Fail(stacktrace.Propagate(err, "An error occurred re-adding test person after the datastore restarted"))
+	}
+	if err := castedNetwork.AssertApisSeeBooksRead(castedNetwork.GetApiServiceIds(), testPersonId, 0); err != nil {
+		return verifyStep.Fail(stacktrace.Propagate(err, "The API services didn't recover after the datastore restarted"))
+	}
+	verifyStep.Done()
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package split_brain_test
+
+import (
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/networks_impl"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+const (
+	owner = "test-infra-team"
+
+	setupTimeoutSeconds = 90
+	runTimeoutSeconds   = 90
+
+	numApiServices         = 4
+	numIsolatedApiServices = 2
+
+	testPersonId = 37
+
+	numIncrementsDuringPartition = 3
+)
+
+/*
+Demonstrates partition testing: half the API services are cut off from the datastore, and the test verifies that
+ the cut-off side refuses reads & writes (rather than diverging from the datastore's side), and that every API
+ service agrees on the same state once the partition heals
+*/
+type SplitBrainTest struct {
+	ctx *test_context.TestContext
+}
+
+func NewSplitBrainTest(ctx *test_context.TestContext) *SplitBrainTest {
+	return &SplitBrainTest{ctx: ctx}
+}
+
+func (test SplitBrainTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(
+		setupTimeoutSeconds,
+	).WithRunTimeoutSeconds(
+		runTimeoutSeconds,
+	).WithPartitioningEnabled(
+		true,
+	)
+}
+
+func (test SplitBrainTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Partition, test_tags.Slow}
+}
+
+func (test SplitBrainTest) GetOwner() string {
+	return owner
+}
+
+func (test SplitBrainTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	network := networks_impl.NewTestNetwork(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), setupTimeoutSeconds*time.Second)
+	step := test.ctx.Step(fmt.Sprintf("start datastore and %v API services", numApiServices))
+	if err := network.SetupDatastoreAndNApis(numApiServices); err != nil {
+		return nil, step.Fail(stacktrace.Propagate(err, "An error occurred setting up the network"))
+	}
+	step.Done()
+	return network, nil
+}
+
+func (test SplitBrainTest) Run(network networks.Network) error {
+	castedNetwork := network.(*networks_impl.TestNetwork)
+	allApiServiceIds := castedNetwork.GetApiServiceIds()
+	isolatedApiServiceIds := allApiServiceIds[:numIsolatedApiServices]
+	connectedApiServiceIds := allApiServiceIds[numIsolatedApiServices:]
+
+	connectedClient, err := castedNetwork.GetApiClient(connectedApiServiceIds[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the client for API service '%v'", connectedApiServiceIds[0])
+	}
+	isolatedClient, err := castedNetwork.GetApiClient(isolatedApiServiceIds[0])
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the client for API service '%v'", isolatedApiServiceIds[0])
+	}
+
+	addPersonStep := test.ctx.Step("add test person before partitioning the network")
+	if err := connectedClient.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
+	}
+	if err := castedNetwork.AssertApisSeeBooksRead(allApiServiceIds, testPersonId, 0); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "Not every API service saw the test person before the partition"))
+	}
+	addPersonStep.Done()
+
+	isolateStep := test.ctx.Step(fmt.Sprintf("isolate %v API services from the datastore", numIsolatedApiServices))
+	if err := castedNetwork.IsolateApisFromDatastore(isolatedApiServiceIds); err != nil {
+		return isolateStep.Fail(stacktrace.Propagate(err, "An error occurred isolating API services %v", isolatedApiServiceIds))
+	}
+	isolateStep.Done()
+
+	verifyIsolatedStep := test.ctx.Step("verify isolated side refuses reads & writes")
+	if err := castedNetwork.AssertApisCannotReachDatastore(isolatedApiServiceIds, testPersonId); err != nil {
+		return verifyIsolatedStep.Fail(stacktrace.Propagate(err, "The partition didn't cut the isolated API services off from the datastore"))
+	}
+	if err := isolatedClient.IncrementBooksRead(testPersonId); err == nil {
+		return verifyIsolatedStep.Fail(stacktrace.NewError("Expected incrementing books read through isolated API service '%v' to fail, but it succeeded", isolatedApiServiceIds[0]))
+	}
+	verifyIsolatedStep.Done()
+
+	verifyConnectedStep := test.ctx.Step(fmt.Sprintf("increment books read %v times on the datastore's side", numIncrementsDuringPartition))
+	for i := 0; i < numIncrementsDuringPartition; i++ {
+		if err := connectedClient.IncrementBooksRead(testPersonId); err != nil {
+			return verifyConnectedStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read during the partition"))
+		}
+	}
+	if err := castedNetwork.AssertApisSeeBooksRead(connectedApiServiceIds, testPersonId, numIncrementsDuringPartition); err != nil {
+		return verifyConnectedStep.Fail(stacktrace.Propagate(err, "The API services on the datastore's side didn't see the writes made during the partition"))
+	}
+	verifyConnectedStep.Done()
+
+	healStep := test.ctx.Step("heal partition")
+	if err := castedNetwork.HealPartition(); err != nil {
+		return healStep.Fail(stacktrace.Propagate(err, "An error occurred healing the partition"))
+	}
+	healStep.Done()
+
+	verifyConvergedStep := test.ctx.Step("verify network converged after healing")
+	if err := castedNetwork.AssertApisSeeBooksRead(allApiServiceIds, testPersonId, numIncrementsDuringPartition); err != nil {
+		return verifyConvergedStep.Fail(stacktrace.Propagate(err, "The API services disagreed about the test person after the partition healed"))
+	}
+	if err := isolatedClient.IncrementBooksRead(testPersonId); err != nil {
+		return verifyConvergedStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing books read through formerly-isolated API service '%v'", isolatedApiServiceIds[0]))
+	}
+	if err := castedNetwork.AssertApisSeeBooksRead(allApiServiceIds, testPersonId, numIncrementsDuringPartition+1); err != nil {
+		return verifyConvergedStep.Fail(stacktrace.Propagate(err, "The API services didn't see the write made through a formerly-isolated API service"))
+	}
+	verifyConvergedStep.Done()
+	return nil
+}
@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package topology_test
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/test_tags"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/topology"
+	"github.com/kurtosis-tech/kurtosis-testsuite-api-lib/golang/lib/testsuite"
+	"github.com/palantir/stacktrace"
+	"time"
+)
+
+const (
+	owner = "test-infra-team"
+
+	setupTimeoutSeconds = 90
+	runTimeoutSeconds   = 60
+
+	apiServiceName = "api"
+
+	testPersonId = 64
+)
+
+// Demonstrates a test whose network is described by a topology file rather than Go code
+type TopologyTest struct {
+	ctx              *test_context.TestContext
+	topologyFilepath string
+}
+
+func NewTopologyTest(ctx *test_context.TestContext, topologyFilepath string) *TopologyTest {
+	return &TopologyTest{ctx: ctx, topologyFilepath: topologyFilepath}
+}
+
+func (test TopologyTest) Configure(builder *testsuite.TestConfigurationBuilder) {
+	builder.WithSetupTimeoutSeconds(setupTimeoutSeconds).WithRunTimeoutSeconds(runTimeoutSeconds)
+}
+
+func (test TopologyTest) GetTags() []test_tags.Tag {
+	return []test_tags.Tag{test_tags.Smoke}
+}
+
+func (test TopologyTest) GetOwner() string {
+	return owner
+}
+
+func (test TopologyTest) Setup(networkCtx network_context.NetworkContext) (networks.Network, error) {
+	readinessWaiter := readiness.NewWaiter(time.Now().Add(setupTimeoutSeconds * time.Second))
+	topologyFile, err := topology.LoadTopologyFile(test.topologyFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred loading the topology file")
+	}
+	buildStep := test.ctx.Step("build network from topology file")
+	network, err := topology.NewTopologyBuilder(networkCtx, test.ctx.GetImages(), test.ctx.GetHttpTransport(), readinessWaiter).Build(topologyFile)
+	if err != nil {
+		return nil, buildStep.Fail(stacktrace.Propagate(err, "An error occurred building the network from the topology file"))
+	}
+	buildStep.Done()
+	return network, nil
+}
+
+func (test TopologyTest) Run(network networks.Network) error {
+	castedNetwork := network.(*topology.TopologyNetwork)
+
+	apiClients, err := castedNetwork.GetApiClients(apiServiceName)
+	if err != nil {
+		return stacktrace.Propagate(err, "An error occurred getting the API clients")
+	}
+	if len(apiClients) < 2 {
+		return stacktrace.NewError("Expected the topology to contain at least two API instances, but found %v", len(apiClients))
+	}
+	firstApiClient := apiClients[0]
+	lastApiClient := apiClients[len(apiClients)-1]
+
+	addPersonStep := test.ctx.Step("add test person via the first API instance")
+	if err := firstApiClient.AddPerson(testPersonId); err != nil {
+		return addPersonStep.Fail(stacktrace.Propagate(err, "An error occurred adding test person"))
+	}
+	addPersonStep.Done()
+
+	incrementStep := test.ctx.Step("increment books read via the last API instance")
+	if err := lastApiClient.IncrementBooksRead(testPersonId); err != nil {
+		return incrementStep.Fail(stacktrace.Propagate(err, "An error occurred incrementing the number of books read"))
+	}
+	incrementStep.Done()
+
+	verifyStep := test.ctx.Step("verify books read via the first API instance")
+	person, err := firstApiClient.GetPerson(testPersonId)
+	if err != nil {
+		return verifyStep.Fail(stacktrace.Propagate(err, "An error occurred getting the test person"))
+	}
+	if person.BooksRead != 1 {
+		return verifyStep.Fail(stacktrace.NewError(
+			"Expected number of books read to be incremented, but was '%v'",
+			person.BooksRead,
+		))
+	}
+	verifyStep.Done()
+	return nil
+}
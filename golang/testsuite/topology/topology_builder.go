@@ -0,0 +1,342 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package topology
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/kurtosis-tech/kurtosis-client/golang/kurtosis_core_rpc_api_bindings"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/networks"
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/readiness"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/service_images"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"net/http"
+	"os"
+	"strconv"
+	"text/template"
+)
+
+const (
+	instanceIdSeparator = "-"
+)
+
+// The data that templates in the topology file are rendered with
+type templateData struct {
+	DatastoreImage string
+	ApiImage       string
+
+	// Only set when rendering a service's generated files, CMD args, and env vars
+	ServiceId     string
+	InstanceIndex int
+
+	// Only set when rendering a service's CMD args and env vars
+	OwnIp string
+}
+
+// Turns a topology file into calls on the NetworkContext
+type TopologyBuilder struct {
+	networkCtx      network_context.NetworkContext
+	images          *service_images.ServiceImages
+	httpTransport   http.RoundTripper
+	readinessWaiter *readiness.Waiter
+}
+
+// The built network's clients send their requests through the given transport, or the default one if nil
+func NewTopologyBuilder(
+	networkCtx network_context.NetworkContext,
+	images *service_images.ServiceImages,
+	httpTransport http.RoundTripper,
+	readinessWaiter *readiness.Waiter,
+) *TopologyBuilder {
+	return &TopologyBuilder{
+		networkCtx:      networkCtx,
+		images:          images,
+		httpTransport:   httpTransport,
+		readinessWaiter: readinessWaiter,
+	}
+}
+
+// Starts the topology's services, in parallel wherever their dependencies allow
+func (builder *TopologyBuilder) Build(topology *TopologyFile) (*TopologyNetwork, error) {
+	if len(topology.Partitions) > 0 {
+		if err := builder.createPartitions(topology); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred creating the topology's partitions")
+		}
+	}
+
+	// Instance IDs are known up front, so services can declare dependencies on all instances of another service
+	instanceIdsByName := map[string][]services.ServiceID{}
+	for _, service := range topology.Services {
+		for instanceIdx := 0; instanceIdx < service.Count; instanceIdx++ {
+			instanceIdsByName[service.Name] = append(instanceIdsByName[service.Name], getInstanceId(service, instanceIdx))
+		}
+	}
+
+	serviceGraph := services_impl.NewServiceGraph()
+	for _, service := range topology.Services {
+		dependencyIds := []services.ServiceID{}
+		for _, dependencyName := range service.getAllDependencies() {
+			dependencyIds = append(dependencyIds, instanceIdsByName[dependencyName]...)
+		}
+		for instanceIdx, serviceId := range instanceIdsByName[service.Name] {
+			getDefinition := builder.getDefinitionFunc(service, serviceId, instanceIdx, instanceIdsByName)
+			if err := serviceGraph.AddServiceToPartition(serviceId, networks.PartitionID(service.Partition), dependencyIds, getDefinition); err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred adding service '%v' to the service graph", serviceId)
+			}
+		}
+	}
+
+	serviceContexts, err := serviceGraph.Start(builder.networkCtx, builder.images, builder.readinessWaiter)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred starting the topology's services")
+	}
+
+	network := newTopologyNetwork(builder.networkCtx, builder.httpTransport)
+	for _, service := range topology.Services {
+		for _, serviceId := range instanceIdsByName[service.Name] {
+			network.addInstance(service.Name, service.Type, serviceContexts[serviceId])
+		}
+	}
+	return network, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (builder *TopologyBuilder) createPartitions(topology *TopologyFile) error {
+	partitionServices := map[networks.PartitionID]map[services.ServiceID]bool{}
+	partitionConnections := map[networks.PartitionID]map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{}
+	for _, partition := range topology.Partitions {
+		partitionId := networks.PartitionID(partition.Id)
+		partitionServices[partitionId] = map[services.ServiceID]bool{}
+		connections := map[networks.PartitionID]*kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{}
+		for _, connectedPartitionId := range partition.ConnectedTo {
+			connections[networks.PartitionID(connectedPartitionId)] = &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{
+				IsBlocked: false,
+			}
+		}
+		partitionConnections[partitionId] = connections
+	}
+	defaultConnection := &kurtosis_core_rpc_api_bindings.PartitionConnectionInfo{
+		IsBlocked: topology.BlockUnconnectedPartitions,
+	}
+	if err := builder.networkCtx.RepartitionNetwork(partitionServices, partitionConnections, defaultConnection); err != nil {
+		return stacktrace.Propagate(err, "An error occurred repartitioning the network")
+	}
+	return nil
+}
+
+func (builder *TopologyBuilder) getDefinitionFunc(
+	service *ServiceSpec,
+	serviceId services.ServiceID,
+	instanceIdx int,
+	instanceIdsByName map[string][]services.ServiceID,
+) services_impl.DefinitionFunc {
+	return func(dependencyContexts map[services.ServiceID]*services.ServiceContext) (services_impl.ServiceDefinition, error) {
+		data := templateData{
+			DatastoreImage: builder.images.GetDatastoreImage(),
+			ApiImage:       builder.images.GetApiImage(),
+			ServiceId:      string(serviceId),
+			InstanceIndex:  instanceIdx,
+		}
+		getIps := func(serviceName string) ([]string, error) {
+			return getInstanceIps(serviceName, instanceIdsByName, dependencyContexts)
+		}
+
+		image := ""
+		if service.Image != "" {
+			renderedImage, err := renderTemplate("image", service.Image, data, getIps, template.FuncMap{})
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred rendering the image of service '%v'", service.Name)
+			}
+			image = renderedImage
+		}
+
+		switch service.Type {
+		case DatastoreServiceType:
+			if image == "" {
+				image = builder.images.GetDatastoreImage()
+			}
+			return services_impl.NewDatastoreServiceDefinition(image), nil
+		case ApiServiceType:
+			if image == "" {
+				image = builder.images.GetApiImage()
+			}
+			datastoreIps, err := getIps(service.Datastore)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred getting the IP of datastore '%v'", service.Datastore)
+			}
+			return services_impl.NewApiServiceDefinition(image, datastoreIps[0], services_impl.DatastorePort), nil
+		default:
+			return getCustomServiceDefinition(service, image, data, getIps), nil
+		}
+	}
+}
+
+func getCustomServiceDefinition(
+	service *ServiceSpec,
+	image string,
+	data templateData,
+	getIps func(serviceName string) ([]string, error),
+) *services_impl.CustomServiceDefinition {
+	usedPorts := map[string]bool{}
+	for _, port := range service.Ports {
+		usedPorts[port] = true
+	}
+
+	generatedFiles := map[string]func(*os.File) error{}
+	for fileKey, fileTemplate := range service.GeneratedFiles {
+		// Copies, so the closure doesn't see later loop iterations
+		fileKey := fileKey
+		fileTemplate := fileTemplate
+		generatedFiles[fileKey] = func(fp *os.File) error {
+			contents, err := renderTemplate(fileKey, fileTemplate, data, getIps, template.FuncMap{})
+			if err != nil {
+				return stacktrace.Propagate(err, "An error occurred rendering generated file '%v'", fileKey)
+			}
+			if _, err := fp.WriteString(contents); err != nil {
+				return stacktrace.Propagate(err, "An error occurred writing generated file '%v'", fileKey)
+			}
+			return nil
+		}
+	}
+
+	runConfigFunc := func(ipAddr string, generatedFileFilepaths map[string]string, staticFileFilepaths map[services.StaticFileID]string) (*services.ContainerRunConfig, error) {
+		runData := data
+		runData.OwnIp = ipAddr
+		extraFuncs := template.FuncMap{
+			"generatedFile": func(fileKey string) (string, error) {
+				filepath, found := generatedFileFilepaths[fileKey]
+				if !found {
+					return "", stacktrace.NewError("No generated file with key '%v' exists", fileKey)
+				}
+				return filepath, nil
+			},
+		}
+
+		cmdArgs := []string{}
+		for idx, argTemplate := range service.Cmd {
+			arg, err := renderTemplate(fmt.Sprintf("cmd[%v]", idx), argTemplate, runData, getIps, extraFuncs)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred rendering CMD arg %v", idx)
+			}
+			cmdArgs = append(cmdArgs, arg)
+		}
+		envVars := map[string]string{}
+		for envVarName, valueTemplate := range service.EnvVars {
+			value, err := renderTemplate(envVarName, valueTemplate, runData, getIps, extraFuncs)
+			if err != nil {
+				return nil, stacktrace.Propagate(err, "An error occurred rendering env var '%v'", envVarName)
+			}
+			envVars[envVarName] = value
+		}
+
+		runConfigBuilder := services.NewContainerRunConfigBuilder().WithEnvironmentVariableOverrides(envVars)
+		if len(cmdArgs) > 0 {
+			runConfigBuilder = runConfigBuilder.WithCmdOverride(cmdArgs)
+		}
+		return runConfigBuilder.Build(), nil
+	}
+
+	definitionBuilder := services_impl.NewCustomServiceDefinitionBuilder(
+		image,
+	).WithUsedPorts(
+		usedPorts,
+	).WithGeneratedFiles(
+		generatedFiles,
+	).WithRunConfigFunc(
+		runConfigFunc,
+	)
+	if service.Readiness != nil {
+		definitionBuilder = definitionBuilder.WithReadinessProbe(getReadinessProbe(service.Readiness))
+	}
+	return definitionBuilder.Build()
+}
+
+/*
+Renders a template from the topology file, with functions for looking up the IPs of services that have already
+ been started (which is why services must declare what they depend on)
+*/
+func renderTemplate(
+	name string,
+	templateStr string,
+	data templateData,
+	getIps func(serviceName string) ([]string, error),
+	extraFuncs template.FuncMap,
+) (string, error) {
+	funcs := template.FuncMap{
+		"ip": func(serviceName string) (string, error) {
+			ips, err := getIps(serviceName)
+			if err != nil {
+				return "", err
+			}
+			return ips[0], nil
+		},
+		"ips": getIps,
+	}
+	for funcName, function := range extraFuncs {
+		funcs[funcName] = function
+	}
+
+	parsedTemplate, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(templateStr)
+	if err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred parsing template '%v'", name)
+	}
+	buffer := &bytes.Buffer{}
+	if err := parsedTemplate.Execute(buffer, data); err != nil {
+		return "", stacktrace.Propagate(err, "An error occurred rendering template '%v'", name)
+	}
+	return buffer.String(), nil
+}
+
+// Only a service's dependencies are guaranteed to be started when it's being defined, so only they can be looked up
+func getInstanceIps(
+	serviceName string,
+	instanceIdsByName map[string][]services.ServiceID,
+	dependencyContexts map[services.ServiceID]*services.ServiceContext,
+) ([]string, error) {
+	result := []string{}
+	for _, instanceId := range instanceIdsByName[serviceName] {
+		instanceCtx, found := dependencyContexts[instanceId]
+		if !found {
+			return nil, stacktrace.NewError("Service '%v' can only be referenced if it's declared in dependsOn", serviceName)
+		}
+		result = append(result, instanceCtx.GetIPAddress())
+	}
+	if len(result) == 0 {
+		return nil, stacktrace.NewError("Service '%v' has no instances", serviceName)
+	}
+	return result, nil
+}
+
+func getInstanceId(service *ServiceSpec, instanceIdx int) services.ServiceID {
+	if service.Count == 1 {
+		return services.ServiceID(service.Name)
+	}
+	return services.ServiceID(service.Name + instanceIdSeparator + strconv.Itoa(instanceIdx))
+}
+
+// The topology file is validated on load, so exactly one kind of probe is guaranteed to be declared
+func getReadinessProbe(spec *ReadinessSpec) readiness.Probe {
+	switch {
+	case spec.HttpGet != nil:
+		return readiness.NewHttpGetProbe(
+			spec.HttpGet.Port,
+			spec.HttpGet.Path,
+		).WithExpectedStatus(
+			spec.HttpGet.ExpectedStatus,
+		).WithExpectedBody(
+			spec.HttpGet.ExpectedBody,
+		)
+	case spec.Tcp != nil:
+		return readiness.NewTcpProbe(spec.Tcp.Port)
+	default:
+		return readiness.NewGrpcHealthProbe(spec.Grpc.Port, spec.Grpc.Service)
+	}
+}
@@ -0,0 +1,270 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package topology
+
+import (
+	"encoding/json"
+	"github.com/palantir/stacktrace"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	DatastoreServiceType = "datastore"
+	ApiServiceType       = "api"
+	CustomServiceType    = "custom"
+
+	jsonFileExtension     = ".json"
+	yamlFileExtension     = ".yml"
+	yamlFileExtensionLong = ".yaml"
+
+	defaultServiceCount = 1
+
+	defaultHttpReadinessExpectedStatus = 200
+)
+
+// A network described declaratively, so that new topologies can be added without writing Go
+type TopologyFile struct {
+	Services []*ServiceSpec `yaml:"services" json:"services"`
+
+	// If any partitions are declared, every service must declare which partition it belongs to
+	Partitions []*PartitionSpec `yaml:"partitions" json:"partitions"`
+
+	// Whether traffic between two partitions that have no explicit connection is blocked
+	BlockUnconnectedPartitions bool `yaml:"blockUnconnectedPartitions" json:"blockUnconnectedPartitions"`
+}
+
+type ServiceSpec struct {
+	Name string `yaml:"name" json:"name"`
+
+	// One of the *ServiceType constants; the datastore & API types use the service definitions from the catalog
+	Type string `yaml:"type" json:"type"`
+
+	// Template rendered with the test's images (e.g. '{{ .DatastoreImage }}'); defaults to the test's image for the
+	//  datastore & API types and is required for custom services
+	Image string `yaml:"image" json:"image"`
+
+	// Number of instances to start; when greater than one, instances get IDs of the form 'name-N'
+	Count int `yaml:"count" json:"count"`
+
+	// Names of services that must be started & ready before this one is started
+	DependsOn []string `yaml:"dependsOn" json:"dependsOn"`
+
+	Partition string `yaml:"partition" json:"partition"`
+
+	// API services only: the name of the datastore service the API should connect to
+	Datastore string `yaml:"datastore" json:"datastore"`
+
+	// Custom services only: ports in "port/protocol" form
+	Ports []string `yaml:"ports" json:"ports"`
+
+	// Custom services only: mapping of generated file key -> template for the file's contents
+	GeneratedFiles map[string]string `yaml:"generatedFiles" json:"generatedFiles"`
+
+	// Custom services only: templates for the container's CMD args
+	Cmd []string `yaml:"cmd" json:"cmd"`
+
+	// Custom services only: mapping of env var name -> template for its value
+	EnvVars map[string]string `yaml:"envVars" json:"envVars"`
+
+	// Custom services only: if omitted, the service is considered ready as soon as it's started
+	Readiness *ReadinessSpec `yaml:"readiness" json:"readiness"`
+}
+
+// Exactly one kind of probe must be declared
+type ReadinessSpec struct {
+	HttpGet *HttpGetReadinessSpec `yaml:"httpGet" json:"httpGet"`
+	Tcp     *TcpReadinessSpec     `yaml:"tcp" json:"tcp"`
+	Grpc    *GrpcReadinessSpec    `yaml:"grpc" json:"grpc"`
+}
+
+type HttpGetReadinessSpec struct {
+	Port int    `yaml:"port" json:"port"`
+	Path string `yaml:"path" json:"path"`
+
+	// Defaults to 200
+	ExpectedStatus int `yaml:"expectedStatus" json:"expectedStatus"`
+
+	// If non-empty, the response body must match this exactly for the service to be considered ready
+	ExpectedBody string `yaml:"expectedBody" json:"expectedBody"`
+}
+
+type TcpReadinessSpec struct {
+	Port int `yaml:"port" json:"port"`
+}
+
+type GrpcReadinessSpec struct {
+	Port int `yaml:"port" json:"port"`
+
+	// The service name to ask the gRPC health service about; empty asks about the server as a whole
+	Service string `yaml:"service" json:"service"`
+}
+
+type PartitionSpec struct {
+	Id string `yaml:"id" json:"id"`
+
+	// IDs of other partitions that this partition can talk to, regardless of BlockUnconnectedPartitions
+	ConnectedTo []string `yaml:"connectedTo" json:"connectedTo"`
+}
+
+// Loads a topology from a YAML or JSON file, depending on the file's extension
+func LoadTopologyFile(topologyFilepath string) (*TopologyFile, error) {
+	fileBytes, err := ioutil.ReadFile(topologyFilepath)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred reading topology file '%v'", topologyFilepath)
+	}
+
+	topology := &TopologyFile{}
+	extension := strings.ToLower(filepath.Ext(topologyFilepath))
+	switch extension {
+	case jsonFileExtension:
+		if err := json.Unmarshal(fileBytes, topology); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred deserializing topology JSON file '%v'", topologyFilepath)
+		}
+	case yamlFileExtension, yamlFileExtensionLong:
+		if err := yaml.UnmarshalStrict(fileBytes, topology); err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred deserializing topology YAML file '%v'", topologyFilepath)
+		}
+	default:
+		return nil, stacktrace.NewError("Topology file '%v' has unrecognized extension '%v'", topologyFilepath, extension)
+	}
+
+	if err := topology.validate(); err != nil {
+		return nil, stacktrace.Propagate(err, "Topology file '%v' is invalid", topologyFilepath)
+	}
+	return topology, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (topology *TopologyFile) validate() error {
+	if len(topology.Services) == 0 {
+		return stacktrace.NewError("The topology doesn't declare any services")
+	}
+
+	partitionIds := map[string]bool{}
+	for _, partition := range topology.Partitions {
+		if strings.TrimSpace(partition.Id) == "" {
+			return stacktrace.NewError("Found a partition with an empty ID")
+		}
+		if _, found := partitionIds[partition.Id]; found {
+			return stacktrace.NewError("Partition '%v' is declared more than once", partition.Id)
+		}
+		partitionIds[partition.Id] = true
+	}
+	for _, partition := range topology.Partitions {
+		for _, connectedPartitionId := range partition.ConnectedTo {
+			if _, found := partitionIds[connectedPartitionId]; !found {
+				return stacktrace.NewError("Partition '%v' is connected to partition '%v', which isn't declared", partition.Id, connectedPartitionId)
+			}
+		}
+	}
+
+	serviceSpecs := map[string]*ServiceSpec{}
+	for _, service := range topology.Services {
+		if strings.TrimSpace(service.Name) == "" {
+			return stacktrace.NewError("Found a service with an empty name")
+		}
+		if _, found := serviceSpecs[service.Name]; found {
+			return stacktrace.NewError("Service '%v' is declared more than once", service.Name)
+		}
+		serviceSpecs[service.Name] = service
+	}
+
+	for _, service := range topology.Services {
+		if service.Count == 0 {
+			service.Count = defaultServiceCount
+		}
+		if service.Count < 0 {
+			return stacktrace.NewError("Service '%v' has negative count '%v'", service.Name, service.Count)
+		}
+		if service.Type == "" {
+			service.Type = CustomServiceType
+		}
+
+		switch service.Type {
+		case DatastoreServiceType:
+		case ApiServiceType:
+			datastoreSpec, found := serviceSpecs[service.Datastore]
+			if !found {
+				return stacktrace.NewError("API service '%v' must declare the name of an existing datastore service, but declared '%v'", service.Name, service.Datastore)
+			}
+			if datastoreSpec.Type != DatastoreServiceType {
+				return stacktrace.NewError("API service '%v' declares datastore '%v', which isn't of type '%v'", service.Name, service.Datastore, DatastoreServiceType)
+			}
+			if datastoreSpec.Count > 1 {
+				return stacktrace.NewError("API service '%v' declares datastore '%v', which has more than one instance", service.Name, service.Datastore)
+			}
+		case CustomServiceType:
+			if strings.TrimSpace(service.Image) == "" {
+				return stacktrace.NewError("Custom service '%v' must declare an image", service.Name)
+			}
+			if service.Readiness != nil {
+				if err := service.Readiness.validate(); err != nil {
+					return stacktrace.Propagate(err, "Custom service '%v' declares an invalid readiness probe", service.Name)
+				}
+			}
+		default:
+			return stacktrace.NewError("Service '%v' has unrecognized type '%v'", service.Name, service.Type)
+		}
+
+		for _, dependencyName := range service.DependsOn {
+			if _, found := serviceSpecs[dependencyName]; !found {
+				return stacktrace.NewError("Service '%v' depends on service '%v', which isn't declared", service.Name, dependencyName)
+			}
+		}
+
+		if len(partitionIds) > 0 {
+			if _, found := partitionIds[service.Partition]; !found {
+				return stacktrace.NewError("The topology declares partitions, so service '%v' must belong to one of them but declared '%v'", service.Name, service.Partition)
+			}
+		} else if service.Partition != "" {
+			return stacktrace.NewError("Service '%v' declares partition '%v', but the topology doesn't declare any partitions", service.Name, service.Partition)
+		}
+	}
+	return nil
+}
+
+func (readiness *ReadinessSpec) validate() error {
+	numProbes := 0
+	if readiness.HttpGet != nil {
+		numProbes++
+		if readiness.HttpGet.Port == 0 {
+			return stacktrace.NewError("The HTTP GET probe doesn't declare a port")
+		}
+		if readiness.HttpGet.ExpectedStatus == 0 {
+			readiness.HttpGet.ExpectedStatus = defaultHttpReadinessExpectedStatus
+		}
+	}
+	if readiness.Tcp != nil {
+		numProbes++
+		if readiness.Tcp.Port == 0 {
+			return stacktrace.NewError("The TCP probe doesn't declare a port")
+		}
+	}
+	if readiness.Grpc != nil {
+		numProbes++
+		if readiness.Grpc.Port == 0 {
+			return stacktrace.NewError("The gRPC health probe doesn't declare a port")
+		}
+	}
+	if numProbes != 1 {
+		return stacktrace.NewError("Exactly one of httpGet, tcp, or grpc must be declared, but found %v", numProbes)
+	}
+	return nil
+}
+
+// Gets all the services a service must wait for, including implicit ones (e.g. an API's datastore)
+func (service *ServiceSpec) getAllDependencies() []string {
+	result := append([]string{}, service.DependsOn...)
+	if service.Type == ApiServiceType {
+		result = append(result, service.Datastore)
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2021 - present Kurtosis Technologies LLC.
+ * All Rights Reserved.
+ */
+
+package topology
+
+import (
+	"github.com/kurtosis-tech/kurtosis-client/golang/lib/services"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/network_context"
+	"github.com/kurtosis-tech/kurtosis-libs/golang/testsuite/services_impl"
+	"github.com/palantir/stacktrace"
+	"net/http"
+)
+
+// The Network produced from a topology file, with typed accessors for the services it declared
+type TopologyNetwork struct {
+	networkCtx network_context.NetworkContext
+
+	// What the clients the network hands out send their requests through (the default transport if nil)
+	httpTransport http.RoundTripper
+
+	// Mapping of service name -> type declared in the topology file
+	serviceTypes map[string]string
+
+	// Mapping of service name -> IDs of its instances, in creation order
+	instanceIds map[string][]services.ServiceID
+
+	// Mapping of instance ID -> name of the service it's an instance of
+	instanceServiceNames map[services.ServiceID]string
+
+	serviceContexts map[services.ServiceID]*services.ServiceContext
+}
+
+func newTopologyNetwork(networkCtx network_context.NetworkContext, httpTransport http.RoundTripper) *TopologyNetwork {
+	return &TopologyNetwork{
+		networkCtx:           networkCtx,
+		httpTransport:        httpTransport,
+		serviceTypes:         map[string]string{},
+		instanceIds:          map[string][]services.ServiceID{},
+		instanceServiceNames: map[services.ServiceID]string{},
+		serviceContexts:      map[services.ServiceID]*services.ServiceContext{},
+	}
+}
+
+// Escape hatch for anything the typed accessors don't cover
+func (network *TopologyNetwork) GetNetworkContext() network_context.NetworkContext {
+	return network.networkCtx
+}
+
+func (network *TopologyNetwork) GetInstanceIds(serviceName string) ([]services.ServiceID, error) {
+	instanceIds, found := network.instanceIds[serviceName]
+	if !found {
+		return nil, stacktrace.NewError("No service with name '%v' exists in the topology", serviceName)
+	}
+	return append([]services.ServiceID{}, instanceIds...), nil
+}
+
+func (network *TopologyNetwork) GetServiceContext(serviceId services.ServiceID) (*services.ServiceContext, error) {
+	serviceCtx, found := network.serviceContexts[serviceId]
+	if !found {
+		return nil, stacktrace.NewError("No service with ID '%v' exists in the topology", serviceId)
+	}
+	return serviceCtx, nil
+}
+
+func (network *TopologyNetwork) GetDatastoreClient(serviceId services.ServiceID) (*services_impl.DatastoreClient, error) {
+	serviceCtx, err := network.getServiceContextOfType(serviceId, DatastoreServiceType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the datastore service context")
+	}
+	return services_impl.NewDatastoreClient(serviceCtx.GetIPAddress(), network.httpTransport), nil
+}
+
+func (network *TopologyNetwork) GetApiClient(serviceId services.ServiceID) (*services_impl.ApiClient, error) {
+	serviceCtx, err := network.getServiceContextOfType(serviceId, ApiServiceType)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the API service context")
+	}
+	return services_impl.NewApiClient(serviceCtx.GetIPAddress(), network.httpTransport), nil
+}
+
+// Gets a client for every instance of the given API service, in instance order
+func (network *TopologyNetwork) GetApiClients(serviceName string) ([]*services_impl.ApiClient, error) {
+	instanceIds, err := network.GetInstanceIds(serviceName)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the instances of service '%v'", serviceName)
+	}
+	result := []*services_impl.ApiClient{}
+	for _, instanceId := range instanceIds {
+		client, err := network.GetApiClient(instanceId)
+		if err != nil {
+			return nil, stacktrace.Propagate(err, "An error occurred getting the API client for instance '%v'", instanceId)
+		}
+		result = append(result, client)
+	}
+	return result, nil
+}
+
+// ====================================================================================================
+//                                       Private helper functions
+// ====================================================================================================
+func (network *TopologyNetwork) addInstance(serviceName string, serviceType string, serviceCtx *services.ServiceContext) {
+	serviceId := serviceCtx.GetServiceID()
+	network.serviceTypes[serviceName] = serviceType
+	network.instanceIds[serviceName] = append(network.instanceIds[serviceName], serviceId)
+	network.instanceServiceNames[serviceId] = serviceName
+	network.serviceContexts[serviceId] = serviceCtx
+}
+
+func (network *TopologyNetwork) getServiceContextOfType(serviceId services.ServiceID, expectedType string) (*services.ServiceContext, error) {
+	serviceCtx, err := network.GetServiceContext(serviceId)
+	if err != nil {
+		return nil, stacktrace.Propagate(err, "An error occurred getting the service context for '%v'", serviceId)
+	}
+	serviceName := network.instanceServiceNames[serviceId]
+	if actualType := network.serviceTypes[serviceName]; actualType != expectedType {
+		return nil, stacktrace.NewError("Service '%v' is of type '%v', not '%v'", serviceId, actualType, expectedType)
+	}
+	return serviceCtx, nil
+}
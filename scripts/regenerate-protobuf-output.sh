@@ -0,0 +1,47 @@
+#!/usr/bin/env bash
+# ^^^^^^^^^^^^^^^^^ this is the most platform-agnostic way to guarantee this script runs with Bash
+# 2021-07-08 WATERMARK, DO NOT REMOVE - This script was generated from the Kurtosis Bash script template
+
+set -euo pipefail   # Bash "strict mode"
+script_dirpath="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+root_dirpath="$(dirname "${script_dirpath}")"
+
+
+
+# ==================================================================================================
+#                                             Constants
+# ==================================================================================================
+INPUT_DIRNAME="suite-api"
+GOLANG_DIRNAME="golang"
+GOLANG_BINDINGS_REL_DIRPATH="lib/rpc_api/bindings"   # Must match the go_package option in the .proto files
+
+
+
+# ==================================================================================================
+#                                             Main Logic
+# ==================================================================================================
+input_dirpath="${root_dirpath}/${INPUT_DIRNAME}"
+golang_bindings_dirpath="${root_dirpath}/${GOLANG_DIRNAME}/${GOLANG_BINDINGS_REL_DIRPATH}"
+
+if ! mkdir -p "${golang_bindings_dirpath}"; then
+    echo "Error: Couldn't create Golang bindings directory '${golang_bindings_dirpath}'" >&2
+    exit 1
+fi
+if ! find "${golang_bindings_dirpath}" -name '*.pb.go' -delete; then
+    echo "Error: Couldn't clear out the existing Golang bindings in '${golang_bindings_dirpath}'" >&2
+    exit 1
+fi
+
+for input_filepath in "${input_dirpath}"/*.proto; do
+    if ! protoc \
+            -I="${input_dirpath}" \
+            --go_out="${golang_bindings_dirpath}" \
+            --go_opt=paths=source_relative \
+            --go-grpc_out="${golang_bindings_dirpath}" \
+            --go-grpc_opt=paths=source_relative \
+            "${input_filepath}"; then
+        echo "Error: Couldn't generate Golang bindings for '${input_filepath}'" >&2
+        exit 1
+    fi
+done
+echo "Successfully regenerated Golang bindings in '${golang_bindings_dirpath}'"